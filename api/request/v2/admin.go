@@ -0,0 +1,51 @@
+package v2
+
+// ListSyncFailuresReq 查询表格下同步失败记录列表请求参数
+type ListSyncFailuresReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	Status        *string `form:"status" binding:"omitempty,oneof=retrying dead discarded"` // 为空时返回全部状态
+	PageToken     *string `form:"page_token" binding:"omitempty"`                           // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
+
+// SyncFailureReq 查看、重试、放弃同步失败记录请求参数，记录 ID 通过路径参数传递
+type SyncFailureReq struct {
+	TableIdentify *string `json:"table_identify" form:"table_identify" binding:"required"`
+}
+
+// ReconcileDeletedRecordsReq 清理飞书中已删除记录请求参数
+type ReconcileDeletedRecordsReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+}
+
+// ListFailedNotificationsReq 查询表格下投递失败的学生通知请求参数
+type ListFailedNotificationsReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	RecordID      *string `form:"record_id" binding:"omitempty"`  // 为空时返回全部记录
+	PageToken     *string `form:"page_token" binding:"omitempty"` // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
+
+// SendDigestReq 立即发送表格摘要卡片请求参数
+type SendDigestReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+}
+
+// ListRecordMessagesAdminReq 查询反馈记录下的对话请求参数，记录 ID 通过路径参数传递
+type ListRecordMessagesAdminReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	PageToken     *string `form:"page_token" binding:"omitempty"` // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
+
+// PostStaffMessageReq 工作人员回复反馈记录请求参数，记录 ID 通过路径参数传递
+type PostStaffMessageReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+	StaffName     *string `json:"staff_name" binding:"required"` // 回复署名，学生可见
+	Content       *string `json:"content" binding:"required"`
+}
+
+// GetRatingStatsReq 查询表格满意度评价统计请求参数
+type GetRatingStatsReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+}
@@ -0,0 +1,44 @@
+package v2
+
+// GetNotificationPreferenceReq 查询学生通知偏好请求参数
+type GetNotificationPreferenceReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	StudentID     *string `form:"student_id" binding:"required"`
+}
+
+// UpdateNotificationPreferenceReq 更新学生通知偏好请求参数，整体覆盖原有设置
+type UpdateNotificationPreferenceReq struct {
+	TableIdentify *string         `json:"table_identify" binding:"required"`
+	StudentID     *string         `json:"student_id" binding:"required"`
+	OptOut        *bool           `json:"opt_out" binding:"required"`   // 不再接收通知
+	Channels      []string        `json:"channels" binding:"omitempty"` // 按优先级排列的通知渠道（ccnubox/email），为空时使用表格的通知渠道
+	QuietHours    []QuietHoursReq `json:"quiet_hours" binding:"omitempty,dive"`
+}
+
+// QuietHoursReq 免打扰时段，时间格式为 HH:MM，end 早于 start 时表示跨天
+type QuietHoursReq struct {
+	Start *string `json:"start" binding:"required"`
+	End   *string `json:"end" binding:"required"`
+}
+
+// ListNotificationsReq 查询学生站内通知请求参数
+type ListNotificationsReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	StudentID     *string `form:"student_id" binding:"required"`
+	UnreadOnly    *bool   `form:"unread_only" binding:"omitempty"` // 只返回未读通知
+	PageToken     *string `form:"page_token" binding:"omitempty"`  // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
+
+// MarkNotificationsReadReq 标记站内通知已读请求参数
+type MarkNotificationsReadReq struct {
+	TableIdentify *string  `json:"table_identify" binding:"required"`
+	StudentID     *string  `json:"student_id" binding:"required"`
+	IDs           []uint64 `json:"ids" binding:"omitempty"` // 要标记的通知 ID，为空时标记全部未读通知
+}
+
+// GetUnreadNotificationCountReq 查询未读站内通知数请求参数
+type GetUnreadNotificationCountReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	StudentID     *string `form:"student_id" binding:"required"`
+}
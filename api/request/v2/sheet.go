@@ -27,6 +27,11 @@ type ForceSyncTableRecordsReq struct {
 	TableIdentify *string `json:"table_identify" binding:"required"`
 }
 
+// IncrementalSyncTableRecordsReq 按修改时间增量同步指定表格记录请求参数
+type IncrementalSyncTableRecordsReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+}
+
 // GetFAQProblemTableRecordReg 获取常见问题记录请求参数
 type GetFAQProblemTableRecordReg struct {
 	TableIdentify *string `form:"table_identify" binding:"required"`
@@ -44,3 +49,54 @@ type FAQResolutionUpdateReq struct {
 type SyncFaqRecordReq struct {
 	TableIdentify *string `json:"table_identify" binding:"required"`
 }
+
+// GetSyncJobReq 查询同步任务请求参数，任务 ID 通过路径参数传递
+type GetSyncJobReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+}
+
+// ListSyncJobsReq 查询表格下的同步任务列表请求参数
+type ListSyncJobsReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	PageToken     *string `form:"page_token" binding:"omitempty"` // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
+
+// ListRecordMessagesReq 学生查询反馈记录下的对话请求参数，记录 ID 通过路径参数传递
+type ListRecordMessagesReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	StudentID     *string `form:"student_id" binding:"required"`
+	PageToken     *string `form:"page_token" binding:"omitempty"` // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
+
+// PostRecordMessageReq 学生在反馈记录下追问请求参数，记录 ID 通过路径参数传递
+type PostRecordMessageReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+	StudentID     *string `json:"student_id" binding:"required"`
+	Content       *string `json:"content" binding:"required"`
+}
+
+// EditRecordReq 学生修改反馈记录请求参数，记录 ID 通过路径参数传递，未传的字段保持不变
+type EditRecordReq struct {
+	TableIdentify *string  `json:"table_identify" binding:"required"`
+	StudentID     *string  `json:"student_id" binding:"required"`
+	Content       *string  `json:"content" binding:"omitempty"`      // 反馈内容
+	Images        []string `json:"images" binding:"omitempty"`       // 截图的文件 token，传空数组时清空截图
+	ContactInfo   *string  `json:"contact_info" binding:"omitempty"` // 联系方式
+}
+
+// WithdrawRecordReq 学生撤回反馈记录请求参数，记录 ID 通过路径参数传递
+type WithdrawRecordReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+	StudentID     *string `json:"student_id" binding:"required"`
+	Reason        *string `json:"reason" binding:"omitempty,max=255"` // 撤回原因，可选
+}
+
+// RateRecordReq 学生评价已完成的反馈记录请求参数，记录 ID 通过路径参数传递
+type RateRecordReq struct {
+	TableIdentify *string `json:"table_identify" binding:"required"`
+	StudentID     *string `json:"student_id" binding:"required"`
+	Score         *int    `json:"score" binding:"required,min=1,max=5"` // 满意度，1-5
+	Comment       *string `json:"comment" binding:"omitempty,max=500"`  // 评价内容，可选
+}
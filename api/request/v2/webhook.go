@@ -0,0 +1,22 @@
+package v2
+
+// CreateWebhookReq 创建 webhook 订阅请求参数
+type CreateWebhookReq struct {
+	TableIdentify *string  `json:"table_identify" binding:"required"`
+	URL           *string  `json:"url" binding:"required,url"`
+	Events        []string `json:"events" binding:"required,min=1,dive,oneof=record.created record.updated record.completed"`
+	Secret        *string  `json:"secret" binding:"omitempty,min=16,max=128"` // 签名密钥，为空时自动生成
+}
+
+// WebhookReq 查询、删除 webhook 订阅与重新投递请求参数，ID 通过路径参数传递
+type WebhookReq struct {
+	TableIdentify *string `json:"table_identify" form:"table_identify" binding:"required"`
+}
+
+// ListWebhookDeliveriesReq 查询 webhook 投递记录请求参数，订阅 ID 通过路径参数传递
+type ListWebhookDeliveriesReq struct {
+	TableIdentify *string `form:"table_identify" binding:"required"`
+	Status        *string `form:"status" binding:"omitempty,oneof=pending delivered dead"` // 为空时返回全部状态
+	PageToken     *string `form:"page_token" binding:"omitempty"`                          // 分页参数,第一次不需要
+	LimitSize     *int    `form:"limit_size" binding:"omitempty"`
+}
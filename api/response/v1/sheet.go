@@ -5,6 +5,7 @@ import "github.com/muxi-Infra/FeedBack-Backend/domain"
 // CreatTableRecordResp 创建表格记录返回参数
 type CreatTableRecordResp struct {
 	RecordID string `json:"record_id"`
+	Merged   bool   `json:"merged"` // 与近期提交重复并合并为原记录的追问，record_id 为原记录 ID
 }
 
 // GetTableRecordResp 获取表格记录返回参数（个人历史记录）
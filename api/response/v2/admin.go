@@ -0,0 +1,38 @@
+package v2
+
+import "github.com/muxi-Infra/FeedBack-Backend/domain"
+
+// ListSyncFailuresResp 查询同步失败记录列表返回参数
+type ListSyncFailuresResp struct {
+	Failures  []domain.SyncFailure `json:"failures"`
+	HasMore   bool                 `json:"has_more"`
+	PageToken string               `json:"page_token"`
+}
+
+// GetSyncFailureResp 查询同步失败记录返回参数
+type GetSyncFailureResp struct {
+	Failure domain.SyncFailure `json:"failure"`
+}
+
+// ReconcileDeletedRecordsResp 清理飞书中已删除记录返回参数
+type ReconcileDeletedRecordsResp struct {
+	Deleted int `json:"deleted"` // 本次软删除的记录数
+}
+
+// RetrySyncFailureResp 重试同步失败记录返回参数
+type RetrySyncFailureResp struct {
+	Succeeded bool    `json:"succeeded"` // 本次重试是否成功
+	Error     *string `json:"error"`     // 失败原因
+}
+
+// ListFailedNotificationsResp 查询投递失败的学生通知返回参数
+type ListFailedNotificationsResp struct {
+	Deliveries []domain.NotificationDelivery `json:"deliveries"`
+	HasMore    bool                          `json:"has_more"`
+	PageToken  string                        `json:"page_token"`
+}
+
+// GetRatingStatsResp 查询表格满意度评价统计返回参数
+type GetRatingStatsResp struct {
+	Stats domain.RatingStats `json:"stats"`
+}
@@ -0,0 +1,25 @@
+package v2
+
+import "github.com/muxi-Infra/FeedBack-Backend/domain"
+
+// GetNotificationPreferenceResp 查询学生通知偏好返回参数
+type GetNotificationPreferenceResp struct {
+	Preference domain.NotificationPreference `json:"preference"`
+}
+
+// ListNotificationsResp 查询学生站内通知返回参数
+type ListNotificationsResp struct {
+	Notifications []domain.Notification `json:"notifications"`
+	HasMore       bool                  `json:"has_more"`
+	PageToken     string                `json:"page_token"`
+}
+
+// MarkNotificationsReadResp 标记站内通知已读返回参数
+type MarkNotificationsReadResp struct {
+	Updated int64 `json:"updated"` // 本次标记为已读的通知数
+}
+
+// GetUnreadNotificationCountResp 查询未读站内通知数返回参数
+type GetUnreadNotificationCountResp struct {
+	Count int64 `json:"count"`
+}
@@ -11,18 +11,28 @@ type GetTableRecordResp struct {
 
 // SyncUnsyncedTableRecordsResp 同步指定表格下所有未同步的记录请求参数（不区分用户））
 type SyncUnsyncedTableRecordsResp struct {
+	JobID     string   `json:"job_id"`     // 同步任务 ID，用于查询同步进度
 	RecordIDs []string `json:"record_ids"` // 成功投递到队列的记录 ID
 	QueueFull bool     `json:"queue_full"` // 队列是否已满
 	Total     int      `json:"total"`      // 本次尝试同步的总记录数
 }
 
 type ForceSyncUserTableRecordsResp struct {
+	JobID     string   `json:"job_id"`     // 同步任务 ID，用于查询同步进度
 	RecordIDs []string `json:"record_ids"` // 成功投递到队列的记录 ID
 	QueueFull bool     `json:"queue_full"` // 队列是否已满
 	Total     int      `json:"total"`      // 本次尝试同步的总记录数
 }
 
 type ForceSyncTableRecordsResp struct {
+	JobID     string   `json:"job_id"`     // 同步任务 ID，用于查询同步进度
+	RecordIDs []string `json:"record_ids"` // 成功投递到队列的记录 ID
+	QueueFull bool     `json:"queue_full"` // 队列是否已满
+	Total     int      `json:"total"`      // 本次尝试同步的总记录数
+}
+
+type IncrementalSyncTableRecordsResp struct {
+	JobID     string   `json:"job_id"`     // 同步任务 ID，用于查询同步进度
 	RecordIDs []string `json:"record_ids"` // 成功投递到队列的记录 ID
 	QueueFull bool     `json:"queue_full"` // 队列是否已满
 	Total     int      `json:"total"`      // 本次尝试同步的总记录数
@@ -31,3 +41,38 @@ type ForceSyncTableRecordsResp struct {
 type GetTableRecordByRecordIdResp struct {
 	Records []domain.FAQTableRecord `json:"records"`
 }
+
+// SyncFAQRecordResp 同步 FAQ 记录返回参数
+type SyncFAQRecordResp struct {
+	JobID string `json:"job_id"` // 同步任务 ID
+	Total int    `json:"total"`  // 本次同步的 FAQ 记录数
+}
+
+// GetSyncJobResp 查询同步任务返回参数
+type GetSyncJobResp struct {
+	Job domain.SyncJob `json:"job"`
+}
+
+// ListSyncJobsResp 查询同步任务列表返回参数
+type ListSyncJobsResp struct {
+	Jobs      []domain.SyncJob `json:"jobs"`
+	HasMore   bool             `json:"has_more"`
+	PageToken string           `json:"page_token"`
+}
+
+// ListRecordMessagesResp 查询反馈记录下的对话返回参数
+type ListRecordMessagesResp struct {
+	Messages  []domain.FeedbackMessage `json:"messages"`
+	HasMore   bool                     `json:"has_more"`
+	PageToken string                   `json:"page_token"`
+}
+
+// PostRecordMessageResp 发送留言返回参数
+type PostRecordMessageResp struct {
+	Message domain.FeedbackMessage `json:"message"`
+}
+
+// RateRecordResp 评价反馈记录返回参数
+type RateRecordResp struct {
+	Rating domain.RecordRating `json:"rating"`
+}
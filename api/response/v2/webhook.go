@@ -0,0 +1,20 @@
+package v2
+
+import "github.com/muxi-Infra/FeedBack-Backend/domain"
+
+// CreateWebhookResp 创建 webhook 订阅返回参数，secret 只在创建时返回
+type CreateWebhookResp struct {
+	Webhook domain.WebhookSubscription `json:"webhook"`
+}
+
+// ListWebhooksResp 查询 webhook 订阅列表返回参数
+type ListWebhooksResp struct {
+	Webhooks []domain.WebhookSubscription `json:"webhooks"`
+}
+
+// ListWebhookDeliveriesResp 查询 webhook 投递记录返回参数
+type ListWebhookDeliveriesResp struct {
+	Deliveries []domain.WebhookDelivery `json:"deliveries"`
+	HasMore    bool                     `json:"has_more"`
+	PageToken  string                   `json:"page_token"`
+}
@@ -24,7 +24,13 @@ var ProviderSet = wire.NewSet(
 	NewMiddlewareConfig,
 	NewBaseTable,
 	NewLarkMessageConfig,
+	NewLarkEventConfig,
+	NewSyncConfig,
+	NewDuplicateConfig,
+	NewModerationConfig,
 	NewCCNUBoxMessageConfig,
+	NewMailConfig,
+	NewNoticeConfig,
 	NewMysqlConfig,
 	NewRedisConfig,
 	NewLimiterConfig,
@@ -255,6 +261,138 @@ func NewLarkMessageConfig() *LarkMessage {
 	return larkMessage
 }
 
+// LarkEvent 飞书开放平台事件订阅配置，verificationToken 为空时不启用事件回调
+type LarkEvent struct {
+	VerificationToken string `mapstructure:"verificationToken" yaml:"verificationToken" json:"verificationToken"`
+	EncryptKey        string `mapstructure:"encryptKey" yaml:"encryptKey" json:"encryptKey"`
+}
+
+func NewLarkEventConfig() *LarkEvent {
+	larkEvent := &LarkEvent{}
+	err := vp.UnmarshalKey("larkEvent", &larkEvent)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析 larkEvent 配置: %v", err))
+	}
+	return larkEvent
+}
+
+// SyncConfig 飞书 -> 数据库增量同步配置
+type SyncConfig struct {
+	IncrementalInterval int     `mapstructure:"incrementalInterval" yaml:"incrementalInterval" json:"incrementalInterval"` // 增量同步间隔（秒）
+	ModifiedTimeField   string  `mapstructure:"modifiedTimeField" yaml:"modifiedTimeField" json:"modifiedTimeField"`       // 表格中“修改时间”字段名，用于按天过滤，为空时需要扫描全表，任务类型记为 incremental_scan
+	InitialLookback     int     `mapstructure:"initialLookback" yaml:"initialLookback" json:"initialLookback"`             // 首次增量同步的回溯时间（秒）
+	MaxRecordAttempts   int     `mapstructure:"maxRecordAttempts" yaml:"maxRecordAttempts" json:"maxRecordAttempts"`       // 单条记录连续同步失败的次数上限，达到后进入死信，不再自动重试
+	ReconcileMaxRatio   float64 `mapstructure:"reconcileMaxRatio" yaml:"reconcileMaxRatio" json:"reconcileMaxRatio"`       // 对账时待删除记录占数据库记录的比例上限（0-1），超过时中止对账
+	ReconcileMaxCount   int     `mapstructure:"reconcileMaxCount" yaml:"reconcileMaxCount" json:"reconcileMaxCount"`       // 对账时单次待删除记录数上限，超过时中止对账
+}
+
+func NewSyncConfig() *SyncConfig {
+	cfg := &SyncConfig{}
+	err := vp.UnmarshalKey("sync", &cfg)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析 sync 配置: %v", err))
+	}
+	// 未配置时使用默认值
+	if cfg.IncrementalInterval <= 0 {
+		cfg.IncrementalInterval = 600
+	}
+	if cfg.InitialLookback <= 0 {
+		cfg.InitialLookback = 86400
+	}
+	if cfg.MaxRecordAttempts <= 0 {
+		cfg.MaxRecordAttempts = 5
+	}
+	if cfg.ReconcileMaxRatio <= 0 {
+		cfg.ReconcileMaxRatio = 0.2
+	}
+	if cfg.ReconcileMaxCount <= 0 {
+		cfg.ReconcileMaxCount = 500
+	}
+	return cfg
+}
+
+// DuplicateConfig 重复提交检测与提交频率限制配置
+type DuplicateConfig struct {
+	Enabled     bool    `mapstructure:"enabled" yaml:"enabled" json:"enabled"`             // 是否开启重复提交检测，提交次数限制由 quotaLimit 单独控制
+	Window      int     `mapstructure:"window" yaml:"window" json:"window"`                // 查重的时间范围（秒），只与该时间内同一学生在同一表格的提交比较
+	Threshold   float64 `mapstructure:"threshold" yaml:"threshold" json:"threshold"`       // 相似度阈值（0-1），归一化后的内容相似度不低于阈值时视为重复
+	Policy      string  `mapstructure:"policy" yaml:"policy" json:"policy"`                // 重复时的处理策略：reject 拒绝、merge 合并为原记录下的追问、flag 正常提交并标记
+	FlagField   string  `mapstructure:"flagField" yaml:"flagField" json:"flagField"`       // flag 策略下写入标记的列名，为空时只记录日志
+	QuotaLimit  int     `mapstructure:"quotaLimit" yaml:"quotaLimit" json:"quotaLimit"`    // 单个学生在 quotaWindow 内对同一表格的提交次数上限，为 0 时不限制
+	QuotaWindow int     `mapstructure:"quotaWindow" yaml:"quotaWindow" json:"quotaWindow"` // 提交次数统计窗口（秒）
+}
+
+func NewDuplicateConfig() *DuplicateConfig {
+	cfg := &DuplicateConfig{}
+	err := vp.UnmarshalKey("duplicate", &cfg)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析 duplicate 配置: %v", err))
+	}
+	// 未配置时使用默认值
+	if cfg.Window <= 0 {
+		cfg.Window = 600
+	}
+	if cfg.Threshold <= 0 || cfg.Threshold > 1 {
+		cfg.Threshold = 0.9
+	}
+	switch cfg.Policy {
+	case "reject", "merge", "flag":
+	case "":
+		cfg.Policy = "reject"
+	default:
+		panic(fmt.Sprintf("duplicate 配置无效: 未知的 policy %s", cfg.Policy))
+	}
+	if cfg.QuotaWindow <= 0 {
+		cfg.QuotaWindow = 3600
+	}
+	return cfg
+}
+
+// ModerationConfig 反馈内容写入飞书前的内容审核配置
+type ModerationConfig struct {
+	Action     string         `mapstructure:"action" yaml:"action" json:"action"`             // 表格未配置 moderation 列时的默认处理：allow 放行、mask 打码、reject 拒绝（检测器出错时同样拒绝）
+	Mask       string         `mapstructure:"mask" yaml:"mask" json:"mask"`                   // 打码时替换每个字符使用的内容
+	Words      []string       `mapstructure:"words" yaml:"words" json:"words"`                // 敏感词
+	WordsFile  string         `mapstructure:"wordsFile" yaml:"wordsFile" json:"wordsFile"`    // 敏感词词典文件，每行一个词，与 words 合并使用
+	Phone      bool           `mapstructure:"phone" yaml:"phone" json:"phone"`                // 是否检测手机号
+	IDCard     bool           `mapstructure:"idCard" yaml:"idCard" json:"idCard"`             // 是否检测身份证号
+	SkipFields []string       `mapstructure:"skipFields" yaml:"skipFields" json:"skipFields"` // 不参与审核的列
+	Hook       ModerationHook `mapstructure:"hook" yaml:"hook" json:"hook"`                   // 外部审核服务
+}
+
+// ModerationHook 外部审核服务，URL 为空时不启用
+type ModerationHook struct {
+	URL     string `mapstructure:"url" yaml:"url" json:"url"`
+	Timeout int    `mapstructure:"timeout" yaml:"timeout" json:"timeout"` // 请求超时时间（秒）
+}
+
+func NewModerationConfig() *ModerationConfig {
+	cfg := &ModerationConfig{}
+	err := vp.UnmarshalKey("moderation", &cfg)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析 moderation 配置: %v", err))
+	}
+	// 未配置时使用默认值
+	switch cfg.Action {
+	case "allow", "mask", "reject":
+	case "":
+		cfg.Action = "allow"
+	default:
+		panic(fmt.Sprintf("moderation 配置无效: 未知的 action %s", cfg.Action))
+	}
+	if cfg.Mask == "" {
+		cfg.Mask = "*"
+	}
+	if cfg.SkipFields == nil {
+		// 学号与联系方式本身就是需要提交的个人信息，截图只包含图片的 file_token
+		cfg.SkipFields = []string{"学号", "联系方式（QQ/邮箱）", "截图"}
+	}
+	if cfg.Hook.Timeout <= 0 {
+		cfg.Hook.Timeout = 3
+	}
+	return cfg
+}
+
 type CCNUBoxMessage struct {
 	TableIdentify string `yaml:"tableIdentify" json:"tableIdentify"`
 	BasicUser     string `yaml:"basicUser" json:"basicUser"`
@@ -274,6 +412,94 @@ func NewCCNUBoxMessageConfig() *CCNUBoxMessage {
 	return ccnuBoxMessage
 }
 
+// MailConfig 邮件通知配置，host 为空时不启用邮件通知渠道
+type MailConfig struct {
+	Host         string                  `mapstructure:"host" yaml:"host" json:"host"`
+	Port         int                     `mapstructure:"port" yaml:"port" json:"port"`
+	Username     string                  `mapstructure:"username" yaml:"username" json:"username"`
+	Password     string                  `mapstructure:"password" yaml:"password" json:"password"`
+	From         string                  `mapstructure:"from" yaml:"from" json:"from"`
+	Timeout      int                     `mapstructure:"timeout" yaml:"timeout" json:"timeout"`                // 单封邮件发送超时时间（秒）
+	ContactField string                  `mapstructure:"contactField" yaml:"contactField" json:"contactField"` // 表格中填写邮箱或 QQ 号的字段名
+	Default      MailTemplate            `mapstructure:"default" yaml:"default" json:"default"`                // 未单独配置模板的表格使用的模板
+	Templates    map[string]MailTemplate `mapstructure:"templates" yaml:"templates" json:"templates"`          // 按表格标识配置的模板
+}
+
+// MailTemplate 邮件标题与正文模板，使用 text/template 语法
+type MailTemplate struct {
+	Subject string `mapstructure:"subject" yaml:"subject" json:"subject"`
+	Body    string `mapstructure:"body" yaml:"body" json:"body"`
+}
+
+func NewMailConfig() *MailConfig {
+	cfg := &MailConfig{}
+	err := vp.UnmarshalKey("mail", &cfg)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析 mail 配置: %v", err))
+	}
+	if cfg.Host == "" {
+		return cfg
+	}
+	if cfg.From == "" {
+		panic("mail 配置无效: from 不能为空")
+	}
+	// 未配置时使用默认值
+	if cfg.Port <= 0 {
+		cfg.Port = 587
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10
+	}
+	if cfg.ContactField == "" {
+		cfg.ContactField = "联系方式（QQ/邮箱）"
+	}
+	if cfg.Default.Subject == "" {
+		cfg.Default.Subject = "{{.Title}}"
+	}
+	if cfg.Default.Body == "" {
+		cfg.Default.Body = "{{if .Reply}}您在「{{.TableName}}」提交的反馈有新的回复：{{.Reply}}{{else}}您在「{{.TableName}}」提交的反馈进度已更新为「{{.Progress}}」：{{.Content}}{{end}}\n\n查看详情：{{.ShareURL}}\n"
+	}
+	return cfg
+}
+
+// NoticeConfig 学生通知内容配置，key 为记录的进度，只有配置了内容的进度才会在变化时通知学生
+type NoticeConfig struct {
+	Progress map[string]NoticeMessage `mapstructure:"progress" yaml:"progress" json:"progress"`
+	Reply    NoticeMessage            `mapstructure:"reply" yaml:"reply" json:"reply"` // 工作人员回复时的通知，内容后附回复摘要
+}
+
+// NoticeMessage 进度变化时通知学生的标题与内容
+type NoticeMessage struct {
+	Title   string `mapstructure:"title" yaml:"title" json:"title"`
+	Content string `mapstructure:"content" yaml:"content" json:"content"`
+}
+
+func NewNoticeConfig() *NoticeConfig {
+	cfg := &NoticeConfig{}
+	err := vp.UnmarshalKey("notice", &cfg)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析 notice 配置: %v", err))
+	}
+	// 未配置时只在处理完成时通知，与原有行为一致
+	if len(cfg.Progress) == 0 {
+		cfg.Progress = map[string]NoticeMessage{
+			"已完成": {Title: "反馈处理完成提醒", Content: "您的问题已经处理完成，点击查看详情"},
+		}
+	}
+	if cfg.Reply.Title == "" {
+		cfg.Reply.Title = "反馈回复提醒"
+	}
+	if cfg.Reply.Content == "" {
+		cfg.Reply.Content = "工作人员回复了您的反馈"
+	}
+	for progress, m := range cfg.Progress {
+		if m.Title == "" || m.Content == "" {
+			panic(fmt.Sprintf("notice 配置无效: 进度 %s 的 title 和 content 不能为空", progress))
+		}
+	}
+	return cfg
+}
+
 type RedisConfig struct {
 	Addr     string `yaml:"addr" mapstructure:"addr"`
 	Password string `yaml:"password" mapstructure:"password"`
@@ -0,0 +1,468 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	reqV2 "github.com/muxi-Infra/FeedBack-Backend/api/request/v2"
+	"github.com/muxi-Infra/FeedBack-Backend/api/response"
+	respV2 "github.com/muxi-Infra/FeedBack-Backend/api/response/v2"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/service"
+)
+
+// AdminHandler 管理员接口，使用 BasicAuth 保护，按 table_identify 指定表格
+type AdminHandler interface {
+	ListSyncFailures(c *gin.Context, r reqV2.ListSyncFailuresReq) (response.Response, error)
+	GetSyncFailure(c *gin.Context, r reqV2.SyncFailureReq) (response.Response, error)
+	RetrySyncFailure(c *gin.Context, r reqV2.SyncFailureReq) (response.Response, error)
+	DiscardSyncFailure(c *gin.Context, r reqV2.SyncFailureReq) (response.Response, error)
+	ReconcileDeletedRecords(c *gin.Context, r reqV2.ReconcileDeletedRecordsReq) (response.Response, error)
+	ListFailedNotifications(c *gin.Context, r reqV2.ListFailedNotificationsReq) (response.Response, error)
+	SendDigest(c *gin.Context, r reqV2.SendDigestReq) (response.Response, error)
+	ListRecordMessages(c *gin.Context, r reqV2.ListRecordMessagesAdminReq) (response.Response, error)
+	PostStaffMessage(c *gin.Context, r reqV2.PostStaffMessageReq) (response.Response, error)
+	GetRatingStats(c *gin.Context, r reqV2.GetRatingStatsReq) (response.Response, error)
+}
+
+type Admin struct {
+	s service.SheetService
+	a service.AuthService
+	m service.MessageService
+	d service.DigestService
+	t service.ThreadService
+}
+
+func NewAdmin(s service.SheetService, a service.AuthService, m service.MessageService, d service.DigestService, t service.ThreadService) AdminHandler {
+	return &Admin{
+		s: s,
+		a: a,
+		m: m,
+		d: d,
+		t: t,
+	}
+}
+
+// ListSyncFailures 查询同步失败记录列表
+//
+//	@Summary		查询同步失败记录列表
+//	@Description	分页查询指定表格下同步到数据库失败的记录，可按状态（retrying/dead/discarded）过滤。
+//	@Tags			Admin
+//	@ID				list-sync-failures
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	query		reqV2.ListSyncFailuresReq							true	"查询同步失败记录列表请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.ListSyncFailuresResp}	"成功返回同步失败记录列表"
+//	@Failure		400		{object}	response.Response									"请求参数错误"
+//	@Failure		401		{object}	response.Response									"未授权，BasicAuth 验证失败"
+//	@Failure		500		{object}	response.Response									"服务器内部错误"
+//	@Router			/api/v2/admin/sync/failures [get]
+func (a *Admin) ListSyncFailures(c *gin.Context, r reqV2.ListSyncFailuresReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	status := ""
+	if r.Status != nil {
+		status = *r.Status
+	}
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := a.s.ListSyncFailures(status, r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListSyncFailuresResp{
+		Failures:  make([]domain.SyncFailure, 0),
+		HasMore:   false,
+		PageToken: "",
+	}
+	if serviceResult.Failures != nil {
+		resp.Failures = serviceResult.Failures
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// GetSyncFailure 查看同步失败记录
+//
+//	@Summary		查看同步失败记录
+//	@Description	查看单条记录的失败次数、最近一次错误信息与状态。
+//	@Tags			Admin
+//	@ID				get-sync-failure
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			record_id	path		string												true	"飞书记录 ID"
+//	@Param			request		query		reqV2.SyncFailureReq								true	"查看同步失败记录请求参数"
+//	@Success		200			{object}	response.Response{data=respV2.GetSyncFailureResp}	"成功返回同步失败记录"
+//	@Failure		401			{object}	response.Response									"未授权，BasicAuth 验证失败"
+//	@Failure		404			{object}	response.Response									"同步失败记录不存在"
+//	@Failure		500			{object}	response.Response									"服务器内部错误"
+//	@Router			/api/v2/admin/sync/failures/{record_id} [get]
+func (a *Admin) GetSyncFailure(c *gin.Context, r reqV2.SyncFailureReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	failure, err := a.s.GetSyncFailure(c.Param("record_id"), &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.GetSyncFailureResp{
+			Failure: *failure,
+		},
+	}, nil
+}
+
+// RetrySyncFailure 重试同步失败记录
+//
+//	@Summary		重试同步失败记录
+//	@Description	立即从飞书重新拉取并同步该记录，成功后清除失败记录。重试前失败次数清零并恢复为重试中，死信与已放弃的记录也可以重试，再次失败时重新开始自动重试。
+//	@Tags			Admin
+//	@ID				retry-sync-failure
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			record_id	path		string												true	"飞书记录 ID"
+//	@Param			request		query		reqV2.SyncFailureReq								true	"重试同步失败记录请求参数"
+//	@Success		200			{object}	response.Response{data=respV2.RetrySyncFailureResp}	"重试完成"
+//	@Failure		401			{object}	response.Response									"未授权，BasicAuth 验证失败"
+//	@Failure		404			{object}	response.Response									"同步失败记录不存在"
+//	@Failure		500			{object}	response.Response									"服务器内部错误"
+//	@Router			/api/v2/admin/sync/failures/{record_id}/retry [post]
+func (a *Admin) RetrySyncFailure(c *gin.Context, r reqV2.SyncFailureReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	stats, err := a.s.RetrySyncFailure(c.Param("record_id"), &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.RetrySyncFailureResp{
+			Succeeded: stats.Failed == 0 && stats.Updated > 0,
+			Error:     stats.Error,
+		},
+	}, nil
+}
+
+// DiscardSyncFailure 放弃同步失败记录
+//
+//	@Summary		放弃同步失败记录
+//	@Description	将记录标记为已放弃，定时同步不再重试该记录。记录之后同步成功时会自动清除。
+//	@Tags			Admin
+//	@ID				discard-sync-failure
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			record_id	path		string				true	"飞书记录 ID"
+//	@Param			request		query		reqV2.SyncFailureReq	true	"放弃同步失败记录请求参数"
+//	@Success		200			{object}	response.Response	"放弃成功"
+//	@Failure		401			{object}	response.Response	"未授权，BasicAuth 验证失败"
+//	@Failure		404			{object}	response.Response	"同步失败记录不存在"
+//	@Failure		500			{object}	response.Response	"服务器内部错误"
+//	@Router			/api/v2/admin/sync/failures/{record_id} [delete]
+func (a *Admin) DiscardSyncFailure(c *gin.Context, r reqV2.SyncFailureReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	err = a.s.DiscardSyncFailure(c.Param("record_id"), &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    nil,
+	}, nil
+}
+
+// ReconcileDeletedRecords 清理飞书中已删除的记录
+//
+//	@Summary		清理飞书中已删除的记录
+//	@Description	对比飞书与数据库中的记录 ID，软删除飞书中已不存在的反馈记录，被删除的记录不再出现在查询接口与通知中。定时任务每 4 小时自动执行一次。
+//	@Tags			Admin
+//	@ID				reconcile-deleted-records
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	body		reqV2.ReconcileDeletedRecordsReq							true	"清理已删除记录请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.ReconcileDeletedRecordsResp}	"清理完成"
+//	@Failure		400		{object}	response.Response											"请求参数错误"
+//	@Failure		401		{object}	response.Response											"未授权，BasicAuth 验证失败"
+//	@Failure		409		{object}	response.Response											"飞书表格为空或待删除记录超过上限，已中止"
+//	@Failure		500		{object}	response.Response											"服务器内部错误"
+//	@Router			/api/v2/admin/sync/reconcile [post]
+func (a *Admin) ReconcileDeletedRecords(c *gin.Context, r reqV2.ReconcileDeletedRecordsReq) (response.Response, error) {
+	// FAQ 表格在同步时已处理删除
+	if bytes.Contains([]byte(*r.TableIdentify), []byte("-faq")) {
+		return response.Response{}, errs.TableIdentifierInvalidError(errors.New("faq table does not support reconcile"))
+	}
+
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	deleted, err := a.s.ReconcileDeletedRecords(&tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.ReconcileDeletedRecordsResp{
+			Deleted: deleted,
+		},
+	}, nil
+}
+
+// ListFailedNotifications 查询投递失败的学生通知
+//
+//	@Summary		查询投递失败的学生通知
+//	@Description	分页查询指定表格下投递失败的学生通知，每次尝试一条，包含尝试次数、HTTP 状态码与返回内容。失败的通知会按退避时间自动重试，成功后记录才会标记为已通知。
+//	@Tags			Admin
+//	@ID				list-failed-notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	query		reqV2.ListFailedNotificationsReq							true	"查询投递失败的学生通知请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.ListFailedNotificationsResp}	"成功返回投递失败的通知"
+//	@Failure		400		{object}	response.Response											"请求参数错误"
+//	@Failure		401		{object}	response.Response											"未授权，BasicAuth 验证失败"
+//	@Failure		500		{object}	response.Response											"服务器内部错误"
+//	@Router			/api/v2/admin/notifications/failures [get]
+func (a *Admin) ListFailedNotifications(c *gin.Context, r reqV2.ListFailedNotificationsReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	recordID := ""
+	if r.RecordID != nil {
+		recordID = *r.RecordID
+	}
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := a.m.ListFailedNotifications(recordID, r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListFailedNotificationsResp{
+		Deliveries: make([]domain.NotificationDelivery, 0),
+		HasMore:    false,
+		PageToken:  "",
+	}
+	if serviceResult.Deliveries != nil {
+		resp.Deliveries = serviceResult.Deliveries
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// SendDigest 立即发送表格摘要卡片
+//
+//	@Summary		立即发送表格摘要卡片
+//	@Description	立即向表格配置的接收者发送摘要卡片，统计区间为截至当前的一个计划周期（未配置 digest_schedule 时为最近一天），不影响定时发送。
+//	@Tags			Admin
+//	@ID				send-digest
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	body		reqV2.SendDigestReq	true	"立即发送表格摘要卡片请求参数"
+//	@Success		200		{object}	response.Response	"发送成功"
+//	@Failure		400		{object}	response.Response	"请求参数错误"
+//	@Failure		401		{object}	response.Response	"未授权，BasicAuth 验证失败"
+//	@Failure		500		{object}	response.Response	"服务器内部错误"
+//	@Router			/api/v2/admin/digest/send [post]
+func (a *Admin) SendDigest(c *gin.Context, r reqV2.SendDigestReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	err = a.d.SendDigest(&tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    nil,
+	}, nil
+}
+
+// ListRecordMessages 查询反馈记录下的对话
+//
+//	@Summary		查询反馈记录下的对话
+//	@Description	分页查询反馈记录下学生与工作人员的对话，按时间正序。
+//	@Tags			Admin
+//	@ID				admin-list-record-messages
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			record_id	path		string													true	"飞书记录 ID"
+//	@Param			request		query		reqV2.ListRecordMessagesAdminReq						true	"查询对话请求参数"
+//	@Success		200			{object}	response.Response{data=respV2.ListRecordMessagesResp}	"成功返回对话"
+//	@Failure		400			{object}	response.Response										"请求参数错误"
+//	@Failure		401			{object}	response.Response										"未授权，BasicAuth 验证失败"
+//	@Failure		404			{object}	response.Response										"反馈记录不存在"
+//	@Failure		500			{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v2/admin/records/{record_id}/messages [get]
+func (a *Admin) ListRecordMessages(c *gin.Context, r reqV2.ListRecordMessagesAdminReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := a.t.ListMessages(c.Param("record_id"), "", r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListRecordMessagesResp{
+		Messages:  make([]domain.FeedbackMessage, 0),
+		HasMore:   false,
+		PageToken: "",
+	}
+	if serviceResult.Messages != nil {
+		resp.Messages = serviceResult.Messages
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// PostStaffMessage 工作人员回复反馈记录
+//
+//	@Summary		工作人员回复反馈记录
+//	@Description	以 staff_name 署名回复反馈记录，学生会按通知偏好收到回复通知，对话会回写到表格配置的对话列。也可以在表格配置的回复列中填写，同步时自动导入。
+//	@Tags			Admin
+//	@ID				post-staff-message
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			record_id	path		string													true	"飞书记录 ID"
+//	@Param			request		body		reqV2.PostStaffMessageReq								true	"回复请求参数"
+//	@Success		200			{object}	response.Response{data=respV2.PostRecordMessageResp}	"回复成功"
+//	@Failure		400			{object}	response.Response										"请求参数错误或留言内容无效"
+//	@Failure		401			{object}	response.Response										"未授权，BasicAuth 验证失败"
+//	@Failure		404			{object}	response.Response										"反馈记录不存在"
+//	@Failure		500			{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v2/admin/records/{record_id}/messages [post]
+func (a *Admin) PostStaffMessage(c *gin.Context, r reqV2.PostStaffMessageReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	msg, err := a.t.PostStaffMessage(c.Param("record_id"), *r.StaffName, *r.Content, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.PostRecordMessageResp{
+			Message: *msg,
+		},
+	}, nil
+}
+
+// GetRatingStats 查询表格满意度评价统计
+//
+//	@Summary		查询表格满意度评价统计
+//	@Description	返回表格的评价数与平均分，并按处理人分组统计。处理人取评价时记录中 handler_field 列的值，未配置或未填写时只计入表格统计。
+//	@Tags			Admin
+//	@ID				get-rating-stats
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	query		reqV2.GetRatingStatsReq								true	"查询评价统计请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.GetRatingStatsResp}	"成功返回评价统计"
+//	@Failure		400		{object}	response.Response									"请求参数错误"
+//	@Failure		401		{object}	response.Response									"未授权，BasicAuth 验证失败"
+//	@Failure		500		{object}	response.Response									"服务器内部错误"
+//	@Router			/api/v2/admin/ratings/stats [get]
+func (a *Admin) GetRatingStats(c *gin.Context, r reqV2.GetRatingStatsReq) (response.Response, error) {
+	tableConfig, err := a.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	stats, err := a.s.GetRatingStats(&tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.GetRatingStatsResp{
+			Stats: *stats,
+		},
+	}, nil
+}
@@ -8,4 +8,8 @@ var ProviderSet = wire.NewSet(
 	NewSheet,
 	NewSheetV2,
 	NewMessage,
+	NewLarkEvent,
+	NewAdmin,
+	NewWebhook,
+	NewNotification,
 )
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher"
+	larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/api/response"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/service"
+)
+
+const (
+	recordActionDeleted = "record_deleted"
+)
+
+type LarkEventHandler interface {
+	HandleEvent(c *gin.Context)
+}
+
+type LarkEvent struct {
+	dispatcher *dispatcher.EventDispatcher
+	token      string
+	a          service.AuthService
+	s          service.SheetService
+	log        logger.Logger
+}
+
+func NewLarkEvent(conf *config.LarkEvent, a service.AuthService, s service.SheetService, log logger.Logger) LarkEventHandler {
+	e := &LarkEvent{
+		token: conf.VerificationToken,
+		a:     a,
+		s:     s,
+		log:   log,
+	}
+
+	// 未配置 verificationToken 时不启用事件回调
+	if conf.VerificationToken != "" {
+		e.dispatcher = dispatcher.NewEventDispatcher(conf.VerificationToken, conf.EncryptKey).
+			OnP2FileBitableRecordChangedV1(e.onBitableRecordChanged)
+	}
+
+	return e
+}
+
+// HandleEvent 接收飞书开放平台事件回调
+//
+//	@Summary		飞书事件回调
+//	@Description	接收飞书开放平台推送的事件（如 drive.file.bitable_record_changed_v1），校验 Verification Token 与签名、处理 URL 验证，并将变更的记录写入同步队列。
+//	@Tags			Event
+//	@ID				handle-lark-event
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{string}	string				"事件处理成功或返回 challenge"
+//	@Failure		404	{object}	response.Response	"事件回调未配置"
+//	@Failure		500	{object}	response.Response	"事件校验或处理失败"
+//	@Router			/api/v2/lark/event [post]
+func (e *LarkEvent) HandleEvent(c *gin.Context) {
+	if e.dispatcher == nil {
+		writeError(c, errs.LarkEventNotConfiguredError(errors.New("lark event verification token is empty")))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		writeError(c, errs.HTTPResponseReadError(err))
+		return
+	}
+
+	resp := e.dispatcher.Handle(c.Request.Context(), &larkevent.EventReq{
+		Header:     c.Request.Header,
+		Body:       body,
+		RequestURI: c.Request.RequestURI,
+	})
+
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			c.Writer.Header().Add(k, v)
+		}
+	}
+	c.Status(resp.StatusCode)
+	_, _ = c.Writer.Write(resp.Body)
+}
+
+// onBitableRecordChanged 处理多维表格记录变更事件，只把变更的记录 ID 投递到同步队列
+func (e *LarkEvent) onBitableRecordChanged(ctx context.Context, event *larkdrive.P2FileBitableRecordChangedV1) error {
+	// SDK 只在 URL 验证时校验 Verification Token，未配置 encryptKey 时事件推送的 token 需要在这里校验
+	if event.EventV2Base == nil || event.EventV2Base.Header == nil || event.EventV2Base.Header.Token != e.token {
+		return errors.New("lark event verification token mismatch")
+	}
+
+	if event.Event == nil || event.Event.FileToken == nil || event.Event.TableId == nil {
+		return nil
+	}
+
+	tableConfig, err := e.a.GetTableConfigByToken(*event.Event.FileToken, *event.Event.TableId)
+	if err != nil {
+		// 不是本服务管理的表格，直接忽略，避免飞书重复推送
+		e.log.Warn("lark event table not configured",
+			logger.String("file_token", *event.Event.FileToken),
+			logger.String("table_id", *event.Event.TableId),
+		)
+		return nil
+	}
+
+	var recordIDs, deletedIDs []string
+	for _, action := range event.Event.ActionList {
+		if action == nil || action.RecordId == nil {
+			continue
+		}
+		if action.Action != nil && *action.Action == recordActionDeleted {
+			deletedIDs = append(deletedIDs, *action.RecordId)
+			continue
+		}
+		recordIDs = append(recordIDs, *action.RecordId)
+	}
+
+	e.log.Info("lark record changed event received",
+		logger.String("table_identity", *tableConfig.TableIdentity),
+		logger.Int("record_count", len(recordIDs)),
+		logger.Int("deleted_count", len(deletedIDs)),
+	)
+
+	if len(deletedIDs) > 0 {
+		if err := e.s.SyncDeletedRecords(deletedIDs, &tableConfig); err != nil {
+			return err
+		}
+	}
+	if len(recordIDs) == 0 {
+		return nil
+	}
+
+	return e.s.SyncChangedRecords(recordIDs, &tableConfig)
+}
+
+// writeError 按统一响应格式返回错误，用于不经过 ginx 包装的处理函数
+func writeError(c *gin.Context, err error) {
+	c.Error(err)
+	customError := errorx.ToCustomError(err)
+	c.JSON(customError.HttpCode, response.Response{
+		Code:    customError.Code,
+		Message: customError.Msg,
+		Data:    nil,
+	})
+}
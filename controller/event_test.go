@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
+	"github.com/muxi-Infra/FeedBack-Backend/api/response"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	ServiceMock "github.com/muxi-Infra/FeedBack-Backend/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testVerificationToken = "mock-verification-token"
+	testEncryptKey        = "mock-encrypt-key"
+)
+
+// 创建一个 mock 的 LarkEvent 对象
+func NewMockLarkEvent(ctrl *gomock.Controller, conf *config.LarkEvent) (LarkEventHandler, *ServiceMock.MockAuthService, *ServiceMock.MockSheetService) {
+	mockAuthService := ServiceMock.NewMockAuthService(ctrl)
+	mockSheetService := ServiceMock.NewMockSheetService(ctrl)
+	return NewLarkEvent(conf, mockAuthService, mockSheetService, logger.NewZapLogger(zap.NewNop())), mockAuthService, mockSheetService
+}
+
+// recordChangedEvent 构造多维表格记录变更事件，actions 为 record_id -> action
+func recordChangedEvent(token, fileToken, tableID string, actions ...[2]string) []byte {
+	list := make([]map[string]string, 0, len(actions))
+	for _, a := range actions {
+		list = append(list, map[string]string{"record_id": a[0], "action": a[1]})
+	}
+	body, _ := json.Marshal(map[string]any{
+		"schema": "2.0",
+		"header": map[string]string{
+			"event_id":   "mock-event-id",
+			"event_type": "drive.file.bitable_record_changed_v1",
+			"token":      token,
+		},
+		"event": map[string]any{
+			"file_token":  fileToken,
+			"table_id":    tableID,
+			"action_list": list,
+		},
+	})
+	return body
+}
+
+// encryptEvent 按飞书的加密方式（AES-256-CBC，密钥为 encryptKey 的 SHA256）加密事件
+func encryptEvent(t *testing.T, plain []byte) []byte {
+	key := sha256.Sum256([]byte(testEncryptKey))
+	block, err := aes.NewCipher(key[:])
+	require.NoError(t, err)
+
+	padding := aes.BlockSize - len(plain)%aes.BlockSize
+	plain = append(plain, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	buf := make([]byte, aes.BlockSize+len(plain))
+	cipher.NewCBCEncrypter(block, buf[:aes.BlockSize]).CryptBlocks(buf[aes.BlockSize:], plain)
+
+	body, _ := json.Marshal(map[string]string{"encrypt": base64.StdEncoding.EncodeToString(buf)})
+	return body
+}
+
+func serveEvent(h LarkEventHandler, body []byte, header http.Header) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/v2/lark/event", bytes.NewReader(body))
+	for k, vs := range header {
+		c.Request.Header[k] = vs
+	}
+	h.HandleEvent(c)
+	return w
+}
+
+func signedHeader(body []byte) http.Header {
+	timestamp, nonce := "1700000000", "mock-nonce"
+	return http.Header{
+		larkevent.EventRequestTimestamp: {timestamp},
+		larkevent.EventRequestNonce:     {nonce},
+		larkevent.EventSignature:        {larkevent.Signature(timestamp, nonce, testEncryptKey, string(body))},
+	}
+}
+
+func TestHandleEventURLVerification(t *testing.T) {
+	testCases := []struct {
+		name         string
+		token        string
+		expectedCode int
+	}{
+		{name: "challenge success", token: testVerificationToken, expectedCode: http.StatusOK},
+		{name: "wrong verification token", token: "forged-token", expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			h, _, _ := NewMockLarkEvent(ctrl, &config.LarkEvent{VerificationToken: testVerificationToken})
+
+			body, _ := json.Marshal(map[string]string{"challenge": "mock-challenge", "token": tc.token, "type": "url_verification"})
+			w := serveEvent(h, body, nil)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			if tc.expectedCode == http.StatusOK {
+				assert.JSONEq(t, `{"challenge":"mock-challenge"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleEventNotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	h, _, _ := NewMockLarkEvent(ctrl, &config.LarkEvent{})
+
+	w := serveEvent(h, recordChangedEvent("", "mock-table-token", "mock-table-id", [2]string{"rec-1", "record_edited"}), nil)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var resp response.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, errs.LarkEventNotConfiguredErrorCode, resp.Code)
+}
+
+// 事件按 file_token 与 table_id 找到表格配置，已删除的记录与其他变更分别处理，未配置的表格直接忽略
+func TestHandleEventRecordChanged(t *testing.T) {
+	identity, token, tableID := "mock-table-identity", "mock-table-token", "mock-table-id"
+	tableConfig := domain.TableConfig{TableIdentity: &identity, TableToken: &token, TableID: &tableID}
+
+	type testCase struct {
+		name         string
+		token        string
+		actions      [][2]string
+		setupMocks   func(mockAuthSvc *ServiceMock.MockAuthService, mockSheetSvc *ServiceMock.MockSheetService)
+		expectedCode int
+	}
+
+	testCases := []testCase{
+		{
+			name:  "route deleted and changed records",
+			token: testVerificationToken,
+			actions: [][2]string{
+				{"rec-added", "record_added"},
+				{"rec-deleted", "record_deleted"},
+				{"rec-edited", "record_edited"},
+			},
+			setupMocks: func(mockAuthSvc *ServiceMock.MockAuthService, mockSheetSvc *ServiceMock.MockSheetService) {
+				mockAuthSvc.EXPECT().GetTableConfigByToken(token, tableID).Return(tableConfig, nil)
+				gomock.InOrder(
+					mockSheetSvc.EXPECT().SyncDeletedRecords([]string{"rec-deleted"}, &tableConfig).Return(nil),
+					mockSheetSvc.EXPECT().SyncChangedRecords([]string{"rec-added", "rec-edited"}, &tableConfig).Return(nil),
+				)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "only deleted records",
+			token:   testVerificationToken,
+			actions: [][2]string{{"rec-deleted", "record_deleted"}},
+			setupMocks: func(mockAuthSvc *ServiceMock.MockAuthService, mockSheetSvc *ServiceMock.MockSheetService) {
+				mockAuthSvc.EXPECT().GetTableConfigByToken(token, tableID).Return(tableConfig, nil)
+				mockSheetSvc.EXPECT().SyncDeletedRecords([]string{"rec-deleted"}, &tableConfig).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "unknown table ignored",
+			token:   testVerificationToken,
+			actions: [][2]string{{"rec-edited", "record_edited"}},
+			setupMocks: func(mockAuthSvc *ServiceMock.MockAuthService, mockSheetSvc *ServiceMock.MockSheetService) {
+				mockAuthSvc.EXPECT().GetTableConfigByToken(token, tableID).
+					Return(domain.TableConfig{}, errs.TableIdentifierInvalidError(errors.New("table not found")))
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "sync failed",
+			token:   testVerificationToken,
+			actions: [][2]string{{"rec-edited", "record_edited"}},
+			setupMocks: func(mockAuthSvc *ServiceMock.MockAuthService, mockSheetSvc *ServiceMock.MockSheetService) {
+				mockAuthSvc.EXPECT().GetTableConfigByToken(token, tableID).Return(tableConfig, nil)
+				mockSheetSvc.EXPECT().SyncChangedRecords([]string{"rec-edited"}, &tableConfig).
+					Return(errs.SyncQueueFullError(errors.New("sync queue is full")))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "wrong verification token",
+			token:        "forged-token",
+			actions:      [][2]string{{"rec-edited", "record_edited"}},
+			setupMocks:   func(*ServiceMock.MockAuthService, *ServiceMock.MockSheetService) {},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			h, mockAuthSvc, mockSheetSvc := NewMockLarkEvent(ctrl, &config.LarkEvent{VerificationToken: testVerificationToken})
+			tc.setupMocks(mockAuthSvc, mockSheetSvc)
+
+			w := serveEvent(h, recordChangedEvent(tc.token, token, tableID, tc.actions...), nil)
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+// 配置 encryptKey 后只接受加密且签名正确的事件
+func TestHandleEventEncrypted(t *testing.T) {
+	identity, token, tableID := "mock-table-identity", "mock-table-token", "mock-table-id"
+	tableConfig := domain.TableConfig{TableIdentity: &identity, TableToken: &token, TableID: &tableID}
+	event := recordChangedEvent(testVerificationToken, token, tableID, [2]string{"rec-edited", "record_edited"})
+
+	testCases := []struct {
+		name         string
+		body         func(t *testing.T) ([]byte, http.Header)
+		expectedCode int
+	}{
+		{
+			name: "valid signature",
+			body: func(t *testing.T) ([]byte, http.Header) {
+				body := encryptEvent(t, event)
+				return body, signedHeader(body)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "invalid signature",
+			body: func(t *testing.T) ([]byte, http.Header) {
+				body := encryptEvent(t, event)
+				header := signedHeader(body)
+				header.Set(larkevent.EventSignature, "forged-signature")
+				return body, header
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "plaintext event",
+			body: func(t *testing.T) ([]byte, http.Header) {
+				return event, signedHeader(event)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			h, mockAuthSvc, mockSheetSvc := NewMockLarkEvent(ctrl, &config.LarkEvent{VerificationToken: testVerificationToken, EncryptKey: testEncryptKey})
+			if tc.expectedCode == http.StatusOK {
+				mockAuthSvc.EXPECT().GetTableConfigByToken(token, tableID).Return(tableConfig, nil)
+				mockSheetSvc.EXPECT().SyncChangedRecords([]string{"rec-edited"}, &tableConfig).Return(nil)
+			}
+
+			body, header := tc.body(t)
+			w := serveEvent(h, body, header)
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
@@ -23,7 +23,7 @@ func NewMessage(s service.MessageService) MessageHandler {
 // TriggerNotification 手动触发通知
 //
 //	@Summary		手动触发通知
-//	@Description	将 `table_identify` 写入通知通道以触发下游消费
+//	@Description	登记 `table_identify` 的通知任务，由主节点上的通知消费者立即处理
 //	@Tags			Message
 //	@ID				trigger-notification
 //	@Accept			json
@@ -0,0 +1,269 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	reqV2 "github.com/muxi-Infra/FeedBack-Backend/api/request/v2"
+	"github.com/muxi-Infra/FeedBack-Backend/api/response"
+	respV2 "github.com/muxi-Infra/FeedBack-Backend/api/response/v2"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/ijwt"
+	"github.com/muxi-Infra/FeedBack-Backend/service"
+)
+
+// NotificationHandler 学生通知相关接口
+type NotificationHandler interface {
+	GetNotificationPreference(c *gin.Context, r reqV2.GetNotificationPreferenceReq, uc ijwt.UserClaims) (response.Response, error)
+	UpdateNotificationPreference(c *gin.Context, r reqV2.UpdateNotificationPreferenceReq, uc ijwt.UserClaims) (response.Response, error)
+	ListNotifications(c *gin.Context, r reqV2.ListNotificationsReq, uc ijwt.UserClaims) (response.Response, error)
+	MarkNotificationsRead(c *gin.Context, r reqV2.MarkNotificationsReadReq, uc ijwt.UserClaims) (response.Response, error)
+	GetUnreadNotificationCount(c *gin.Context, r reqV2.GetUnreadNotificationCountReq, uc ijwt.UserClaims) (response.Response, error)
+}
+
+type Notification struct {
+	m service.MessageService
+}
+
+func NewNotification(m service.MessageService) NotificationHandler {
+	return &Notification{
+		m: m,
+	}
+}
+
+// GetNotificationPreference 查询学生通知偏好
+//
+//	@Summary		查询学生通知偏好
+//	@Description	查询学生在表格下的通知偏好，未设置时返回默认值（接收通知、使用表格的通知渠道、无免打扰时段）。
+//	@Tags			Notification
+//	@ID				get-notification-preference
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string														true	"Bearer Token"
+//	@Param			request			query		reqV2.GetNotificationPreferenceReq							true	"查询通知偏好请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.GetNotificationPreferenceResp}	"成功返回通知偏好"
+//	@Failure		400				{object}	response.Response											"请求参数错误"
+//	@Failure		500				{object}	response.Response											"服务器内部错误"
+//	@Router			/api/v2/notification/preference [get]
+func (n *Notification) GetNotificationPreference(c *gin.Context, r reqV2.GetNotificationPreferenceReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	pref, err := n.m.GetNotificationPreference(*r.StudentID, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.GetNotificationPreferenceResp{
+			Preference: *pref,
+		},
+	}, nil
+}
+
+// UpdateNotificationPreference 更新学生通知偏好
+//
+//	@Summary		更新学生通知偏好
+//	@Description	整体覆盖学生在表格下的通知偏好。opt_out 为 true 时不再通知；channels 按优先级使用第一个已启用的渠道；免打扰时段内的通知推迟到时段结束后发送，时间按服务器时区计算。
+//	@Tags			Notification
+//	@ID				update-notification-preference
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string									true	"Bearer Token"
+//	@Param			request			body		reqV2.UpdateNotificationPreferenceReq	true	"更新通知偏好请求参数"
+//	@Success		200				{object}	response.Response						"更新成功"
+//	@Failure		400				{object}	response.Response						"请求参数错误或通知偏好设置无效"
+//	@Failure		500				{object}	response.Response						"服务器内部错误"
+//	@Router			/api/v2/notification/preference [put]
+func (n *Notification) UpdateNotificationPreference(c *gin.Context, r reqV2.UpdateNotificationPreferenceReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	pref := domain.NotificationPreference{
+		StudentID:  *r.StudentID,
+		OptOut:     *r.OptOut,
+		Channels:   r.Channels,
+		QuietHours: make([]domain.QuietHours, 0, len(r.QuietHours)),
+	}
+	for _, q := range r.QuietHours {
+		pref.QuietHours = append(pref.QuietHours, domain.QuietHours{Start: *q.Start, End: *q.End})
+	}
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	err = n.m.UpdateNotificationPreference(&pref, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    nil,
+	}, nil
+}
+
+// ListNotifications 查询学生站内通知
+//
+//	@Summary		查询学生站内通知
+//	@Description	分页查询学生在表格下的站内通知，按时间倒序。每次反馈进度变化都会写入一条通知，不受推送渠道和通知偏好影响。
+//	@Tags			Notification
+//	@ID				list-notifications
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string												true	"Bearer Token"
+//	@Param			request			query		reqV2.ListNotificationsReq							true	"查询站内通知请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.ListNotificationsResp}	"成功返回站内通知"
+//	@Failure		400				{object}	response.Response									"请求参数错误"
+//	@Failure		500				{object}	response.Response									"服务器内部错误"
+//	@Router			/api/v2/notifications [get]
+func (n *Notification) ListNotifications(c *gin.Context, r reqV2.ListNotificationsReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	unreadOnly := false
+	if r.UnreadOnly != nil {
+		unreadOnly = *r.UnreadOnly
+	}
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := n.m.ListNotifications(*r.StudentID, unreadOnly, r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListNotificationsResp{
+		Notifications: make([]domain.Notification, 0),
+		HasMore:       false,
+		PageToken:     "",
+	}
+	if serviceResult.Notifications != nil {
+		resp.Notifications = serviceResult.Notifications
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// MarkNotificationsRead 标记站内通知已读
+//
+//	@Summary		标记站内通知已读
+//	@Description	将指定的站内通知标记为已读，ids 为空时标记全部未读通知。
+//	@Tags			Notification
+//	@ID				mark-notifications-read
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string													true	"Bearer Token"
+//	@Param			request			body		reqV2.MarkNotificationsReadReq							true	"标记已读请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.MarkNotificationsReadResp}	"标记成功"
+//	@Failure		400				{object}	response.Response										"请求参数错误"
+//	@Failure		500				{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v2/notifications/read [post]
+func (n *Notification) MarkNotificationsRead(c *gin.Context, r reqV2.MarkNotificationsReadReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	updated, err := n.m.MarkNotificationsRead(*r.StudentID, r.IDs, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.MarkNotificationsReadResp{
+			Updated: updated,
+		},
+	}, nil
+}
+
+// GetUnreadNotificationCount 查询未读站内通知数
+//
+//	@Summary		查询未读站内通知数
+//	@Description	查询学生在表格下的未读站内通知数，用于展示角标。
+//	@Tags			Notification
+//	@ID				get-unread-notification-count
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string														true	"Bearer Token"
+//	@Param			request			query		reqV2.GetUnreadNotificationCountReq							true	"查询未读通知数请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.GetUnreadNotificationCountResp}	"成功返回未读通知数"
+//	@Failure		400				{object}	response.Response											"请求参数错误"
+//	@Failure		500				{object}	response.Response											"服务器内部错误"
+//	@Router			/api/v2/notifications/unread_count [get]
+func (n *Notification) GetUnreadNotificationCount(c *gin.Context, r reqV2.GetUnreadNotificationCountReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	count, err := n.m.CountUnreadNotifications(*r.StudentID, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.GetUnreadNotificationCountResp{
+			Count: count,
+		},
+	}, nil
+}
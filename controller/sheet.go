@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -26,12 +27,20 @@ type SheetV1Handler interface {
 type SheetV1 struct {
 	s service.SheetService
 	m service.MessageService
+	o service.OutboxService
+	d service.SubmissionService
+	v service.ModerationService
+	t service.ThreadService
 }
 
-func NewSheet(s service.SheetService, m service.MessageService) SheetV1Handler {
+func NewSheet(s service.SheetService, m service.MessageService, o service.OutboxService, d service.SubmissionService, v service.ModerationService, t service.ThreadService) SheetV1Handler {
 	sheet := &SheetV1{
 		s: s,
 		m: m,
+		o: o,
+		d: d,
+		v: v,
+		t: t,
 	}
 
 	return sheet
@@ -40,7 +49,7 @@ func NewSheet(s service.SheetService, m service.MessageService) SheetV1Handler {
 // CreateTableRecord 创建多维表格记录
 //
 //	@Summary		创建反馈记录
-//	@Description	向指定的多维表格应用中添加用户反馈记录，支持文本内容、截图附件和联系方式。创建成功后会异步发送通知消息。
+//	@Description	向指定的多维表格应用中添加用户反馈记录，支持文本内容、截图附件和联系方式。创建成功后会异步发送通知消息。提交前会与该学生近期的提交查重，按配置拒绝、合并为原记录的追问（merged 为 true，返回原记录 ID，追问失败时照常创建）或标记后照常创建；超出提交次数上限时拒绝。文本内容写入飞书前会经过内容审核，按表格配置打码敏感词、手机号、身份证号等，或拒绝提交。
 //	@Tags			Sheet
 //	@ID				create-table-record
 //	@Accept			json
@@ -48,7 +57,9 @@ func NewSheet(s service.SheetService, m service.MessageService) SheetV1Handler {
 //	@Param			Authorization	header		string												true	"Bearer Token"
 //	@Param			request			body		reqV1.CreatTableRecordReg							true	"新增记录请求参数"
 //	@Success		200				{object}	response.Response{data=respV1.CreatTableRecordResp}	"成功返回创建记录结果"
-//	@Failure		400				{object}	response.Response									"请求参数错误或飞书接口调用失败"
+//	@Failure		400				{object}	response.Response									"请求参数错误、内容未通过审核或飞书接口调用失败"
+//	@Failure		409				{object}	response.Response									"与近期提交的反馈重复"
+//	@Failure		429				{object}	response.Response									"提交过于频繁"
 //	@Failure		500				{object}	response.Response									"服务器内部错误"
 //	@Router			/api/v1/sheet/records [post]
 func (s *SheetV1) CreateTableRecord(c *gin.Context, r reqV1.CreatTableRecordReg, uc ijwt.UserClaims) (response.Response, error) {
@@ -76,9 +87,40 @@ func (s *SheetV1) CreateTableRecord(c *gin.Context, r reqV1.CreatTableRecordReg,
 		ViewID:        &uc.ViewId,
 	}
 
+	// 内容审核，打码后的内容同样用于查重和后续的通知
+	err = s.v.ModerateRecord(c.Request.Context(), record, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+	content, _ := record.Record["反馈内容"].(string)
+
+	// 查重与提交频率限制，合并时不创建新记录，直接返回原记录
+	check, err := s.d.CheckSubmission(*r.StudentID, record, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+	if check.Policy == service.DuplicatePolicyMerge && s.mergeSubmission(c.Request.Context(), check, *r.StudentID, content, &tableConfig) {
+		return response.Response{
+			Code:    0,
+			Message: "Success",
+			Data: respV1.CreatTableRecordResp{
+				RecordID: check.DuplicateOf,
+				Merged:   true,
+			},
+		}, nil
+	}
+
+	// 先登记处理任务（创建记录、获取分享链接、落库、通知管理员），登记失败时不创建飞书记录
+	// 创建后请求中断或确认失败时，由后台使用同一个幂等标识补建，不会丢失后续处理
+	intent, err := s.o.PrepareCreatedRecord(record, content, tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
 	// 发起请求
-	createdRecordID, err := s.s.CreateLarkRecord(record, &tableConfig)
+	createdRecordID, err := s.s.CreateLarkRecord(record, intent.ClientToken, &tableConfig)
 	if err != nil {
+		s.o.AbortCreatedRecord(intent, err)
 		return response.Response{}, err
 	}
 
@@ -90,22 +132,8 @@ func (s *SheetV1) CreateTableRecord(c *gin.Context, r reqV1.CreatTableRecordReg,
 		}, nil
 	}
 
-	// TODO 后续想改成 kafka 异步处理
-	go func(recordID, content string, tc domain.TableConfig) {
-		// 发送消息通知
-		recordDate, url, err := s.s.GetTableRecordReqByRecordID(&recordID, &tc)
-		if err != nil || url == nil {
-			return
-		}
-		err = s.m.SendLarkNotification(*tc.TableName, content, *url)
-		if err != nil {
-			return
-		}
-		err = s.s.CreateDBRecord(&recordID, url, recordDate, tc)
-		if err != nil {
-			return
-		}
-	}(*createdRecordID, *r.Content, tableConfig)
+	s.o.ConfirmCreatedRecord(intent, *createdRecordID)
+	s.d.RememberSubmission(*r.StudentID, *createdRecordID, content, &tableConfig)
 
 	resp := respV1.CreatTableRecordResp{
 		RecordID: *createdRecordID,
@@ -118,6 +146,17 @@ func (s *SheetV1) CreateTableRecord(c *gin.Context, r reqV1.CreatTableRecordReg,
 	}, nil
 }
 
+// mergeSubmission 将重复提交合并为原记录下的追问，内容完全相同时不再追问
+// 追问失败（如原记录尚未落库）时返回 false，由调用方照常创建记录，避免丢失学生的反馈
+func (s *SheetV1) mergeSubmission(ctx context.Context, check *domain.SubmissionCheck, studentID, content string, tableConfig *domain.TableConfig) bool {
+	if check.Similarity >= 1 {
+		return true
+	}
+
+	_, err := s.t.PostStudentMessage(ctx, check.DuplicateOf, studentID, content, tableConfig)
+	return err == nil
+}
+
 // GetTableRecordReqByKey 获取用户历史反馈记录
 //
 //	@Summary		查询历史反馈记录
@@ -1,13 +1,20 @@
 package controller
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/muxi-Infra/FeedBack-Backend/api/request/v1"
+	respV1 "github.com/muxi-Infra/FeedBack-Backend/api/response/v1"
 	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
 
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/ijwt"
+	"github.com/muxi-Infra/FeedBack-Backend/service"
 	ServiceMock "github.com/muxi-Infra/FeedBack-Backend/service/mock"
 
 	"github.com/gin-gonic/gin"
@@ -17,15 +24,30 @@ import (
 )
 
 // 创建一个 mock 的 SheetV1 对象
-func NewMockSheet(crtl *gomock.Controller) (*SheetV1, *ServiceMock.MockSheetService, *ServiceMock.MockMessageService) {
+func NewMockSheet(crtl *gomock.Controller) (*SheetV1, *ServiceMock.MockSheetService, *ServiceMock.MockMessageService, *ServiceMock.MockOutboxService) {
 	// 接口 mock
 	mockSheetService := ServiceMock.NewMockSheetService(crtl)
 	mockMessageService := ServiceMock.NewMockMessageService(crtl)
+	mockOutboxService := ServiceMock.NewMockOutboxService(crtl)
+	// 查重与内容审核不是这里的测试对象，默认视为未重复、审核通过
+	mockSubmissionService := ServiceMock.NewMockSubmissionService(crtl)
+	mockSubmissionService.EXPECT().CheckSubmission(gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.SubmissionCheck{}, nil).AnyTimes()
+	mockSubmissionService.EXPECT().RememberSubmission(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockModerationService := ServiceMock.NewMockModerationService(crtl)
+	mockModerationService.EXPECT().ModerateRecord(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	return &SheetV1{
 		s: mockSheetService,
 		m: mockMessageService,
-	}, mockSheetService, mockMessageService
+		o: mockOutboxService,
+		d: mockSubmissionService,
+		v: mockModerationService,
+	}, mockSheetService, mockMessageService, mockOutboxService
+}
+
+// newTestGinContext 审核等依赖请求 context 的接口使用
+func newTestGinContext() *gin.Context {
+	return &gin.Context{Request: httptest.NewRequest(http.MethodPost, "/", nil)}
 }
 
 // uc
@@ -45,7 +67,7 @@ func TestCreateAppTableRecord(t *testing.T) {
 		name          string
 		req           v1.CreatTableRecordReg
 		uc            ijwt.UserClaims
-		setupMocks    func(mockSheetSvc *ServiceMock.MockSheetService, mockMessageSvc *ServiceMock.MockMessageService)
+		setupMocks    func(mockSheetSvc *ServiceMock.MockSheetService, mockOutboxSvc *ServiceMock.MockOutboxService)
 		expectedCode  int
 		expectedError bool
 	}
@@ -64,30 +86,67 @@ func TestCreateAppTableRecord(t *testing.T) {
 				},
 			},
 			uc: uc,
-			setupMocks: func(mockSheetSvc *ServiceMock.MockSheetService, mockMessageSvc *ServiceMock.MockMessageService) {
-				mockSheetSvc.EXPECT().
-					CreateLarkRecord(gomock.Any(), gomock.Any()).
-					Return(stringPtr("mock-record-id"), nil)
-
-				// Allow CreateDBRecord called by background goroutine
+			setupMocks: func(mockSheetSvc *ServiceMock.MockSheetService, mockOutboxSvc *ServiceMock.MockOutboxService) {
+				intent := &domain.RecordIntent{ID: 1, ClientToken: "mock-client-token"}
+
+				// 先登记处理任务，再使用任务的幂等标识创建飞书记录，最后确认记录 ID
+				gomock.InOrder(
+					mockOutboxSvc.EXPECT().
+						PrepareCreatedRecord(gomock.Any(), "测试反馈内容", gomock.Any()).
+						Return(intent, nil),
+					mockSheetSvc.EXPECT().
+						CreateLarkRecord(gomock.Any(), "mock-client-token", gomock.Any()).
+						Return(stringPtr("mock-record-id"), nil),
+					mockOutboxSvc.EXPECT().
+						ConfirmCreatedRecord(intent, "mock-record-id"),
+				)
+			},
+			expectedCode:  0,
+			expectedError: false,
+		},
+		{
+			name: "create record fails without creating lark record when outbox intent failed",
+			req: v1.CreatTableRecordReg{
+				TableIdentify: stringPtr("mock-table-identity"),
+				StudentID:     stringPtr("2021001234"),
+				Content:       stringPtr("测试反馈内容"),
+			},
+			uc: uc,
+			setupMocks: func(mockSheetSvc *ServiceMock.MockSheetService, mockOutboxSvc *ServiceMock.MockOutboxService) {
+				// 登记失败时不能创建飞书记录，否则后续处理会丢失
+				mockOutboxSvc.EXPECT().
+					PrepareCreatedRecord(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
 				mockSheetSvc.EXPECT().
-					CreateDBRecord(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
-					AnyTimes()
+					CreateLarkRecord(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			expectedCode:  0,
+			expectedError: true,
+		},
+		{
+			name: "create record aborts outbox intent when lark create failed",
+			req: v1.CreatTableRecordReg{
+				TableIdentify: stringPtr("mock-table-identity"),
+				StudentID:     stringPtr("2021001234"),
+				Content:       stringPtr("测试反馈内容"),
+			},
+			uc: uc,
+			setupMocks: func(mockSheetSvc *ServiceMock.MockSheetService, mockOutboxSvc *ServiceMock.MockOutboxService) {
+				intent := &domain.RecordIntent{ID: 1, ClientToken: "mock-client-token"}
+				larkErr := errors.New("lark error")
 
-				// Mock the goroutine calls
+				mockOutboxSvc.EXPECT().
+					PrepareCreatedRecord(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(intent, nil)
 				mockSheetSvc.EXPECT().
-					GetTableRecordReqByRecordID(gomock.Any(), gomock.Any()).
-					Return(map[string]any{}, stringPtr("http://mock-url.com"), nil).
-					AnyTimes()
-
-				mockMessageSvc.EXPECT().
-					SendLarkNotification(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
-					AnyTimes()
+					CreateLarkRecord(gomock.Any(), "mock-client-token", gomock.Any()).
+					Return(nil, larkErr)
+				mockOutboxSvc.EXPECT().
+					AbortCreatedRecord(intent, larkErr)
 			},
 			expectedCode:  0,
-			expectedError: false,
+			expectedError: true,
 		},
 		{
 			name: "create record with missing student id",
@@ -156,13 +215,13 @@ func TestCreateAppTableRecord(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			sheet, mockSheetSvc, mockMessageSvc := NewMockSheet(ctrl)
+			sheet, mockSheetSvc, _, mockOutboxSvc := NewMockSheet(ctrl)
 
 			if tc.setupMocks != nil {
-				tc.setupMocks(mockSheetSvc, mockMessageSvc)
+				tc.setupMocks(mockSheetSvc, mockOutboxSvc)
 			}
 
-			result, err := sheet.CreateTableRecord(&gin.Context{}, tc.req, tc.uc)
+			result, err := sheet.CreateTableRecord(newTestGinContext(), tc.req, tc.uc)
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -177,6 +236,90 @@ func TestCreateAppTableRecord(t *testing.T) {
 	}
 }
 
+// merge 策略下由接口追加追问并返回原记录；追问失败时照常创建记录，内容完全相同时不再追问
+func TestCreateAppTableRecordMergesDuplicate(t *testing.T) {
+	type testCase struct {
+		name       string
+		similarity float64
+		postErr    error
+		wantPost   bool
+		wantMerged bool
+	}
+
+	testCases := []testCase{
+		{name: "merged as follow-up", similarity: 0.9, wantPost: true, wantMerged: true},
+		{name: "exact duplicate not posted again", similarity: 1, wantMerged: true},
+		{name: "follow-up failed falls back to create", similarity: 0.9, postErr: errors.New("record not found"), wantPost: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sheet, mockSheetSvc, _, mockOutboxSvc := NewMockSheet(ctrl)
+			mockSubmissionSvc := ServiceMock.NewMockSubmissionService(ctrl)
+			mockThreadSvc := ServiceMock.NewMockThreadService(ctrl)
+			sheet.d = mockSubmissionSvc
+			sheet.t = mockThreadSvc
+
+			mockSubmissionSvc.EXPECT().CheckSubmission("2021001234", gomock.Any(), gomock.Any()).
+				Return(&domain.SubmissionCheck{Policy: service.DuplicatePolicyMerge, DuplicateOf: "rec-original", Similarity: tc.similarity}, nil)
+			if tc.wantPost {
+				mockThreadSvc.EXPECT().PostStudentMessage(gomock.Any(), "rec-original", "2021001234", "测试反馈内容", gomock.Any()).
+					Return(&domain.FeedbackMessage{}, tc.postErr)
+			}
+			if !tc.wantMerged {
+				intent := &domain.RecordIntent{ID: 1, ClientToken: "mock-client-token"}
+				mockOutboxSvc.EXPECT().PrepareCreatedRecord(gomock.Any(), "测试反馈内容", gomock.Any()).Return(intent, nil)
+				mockSheetSvc.EXPECT().CreateLarkRecord(gomock.Any(), "mock-client-token", gomock.Any()).Return(stringPtr("rec-new"), nil)
+				mockOutboxSvc.EXPECT().ConfirmCreatedRecord(intent, "rec-new")
+				mockSubmissionSvc.EXPECT().RememberSubmission("2021001234", "rec-new", "测试反馈内容", gomock.Any())
+			}
+
+			result, err := sheet.CreateTableRecord(newTestGinContext(), v1.CreatTableRecordReg{
+				TableIdentify: stringPtr("mock-table-identity"),
+				StudentID:     stringPtr("2021001234"),
+				Content:       stringPtr("测试反馈内容"),
+			}, uc)
+			assert.NoError(t, err)
+
+			resp, ok := result.Data.(respV1.CreatTableRecordResp)
+			assert.True(t, ok)
+			assert.Equal(t, tc.wantMerged, resp.Merged)
+			if tc.wantMerged {
+				assert.Equal(t, "rec-original", resp.RecordID)
+			} else {
+				assert.Equal(t, "rec-new", resp.RecordID)
+			}
+		})
+	}
+}
+
+// 内容未通过审核时返回 400，不查重也不创建记录
+func TestCreateAppTableRecordRejectedByModeration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sheet, _, _, _ := NewMockSheet(ctrl)
+	mockSubmissionSvc := ServiceMock.NewMockSubmissionService(ctrl)
+	mockModerationSvc := ServiceMock.NewMockModerationService(ctrl)
+	sheet.d = mockSubmissionSvc
+	sheet.v = mockModerationSvc
+
+	mockModerationSvc.EXPECT().ModerateRecord(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errs.ContentRejectedError(errors.New("field 反馈内容 matched keyword")))
+
+	_, err := sheet.CreateTableRecord(newTestGinContext(), v1.CreatTableRecordReg{
+		TableIdentify: stringPtr("mock-table-identity"),
+		StudentID:     stringPtr("2021001234"),
+		Content:       stringPtr("测试反馈内容"),
+	}, uc)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, errorx.ToCustomError(err).HttpCode)
+	assert.Equal(t, errs.ContentRejectedErrorCode, errorx.ToCustomError(err).Code)
+}
+
 func TestGetTableRecordReqByKey(t *testing.T) {
 	type testCase struct {
 		name          string
@@ -214,7 +357,7 @@ func TestGetTableRecordReqByKey(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			sheet, mockSheetSvc, mockMessageSvc := NewMockSheet(ctrl)
+			sheet, mockSheetSvc, mockMessageSvc, _ := NewMockSheet(ctrl)
 
 			if tc.setupMocks != nil {
 				tc.setupMocks(mockSheetSvc, mockMessageSvc)
@@ -273,7 +416,7 @@ func TestGetFAQResolutionRecord(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			sheet, mockSheetSvc, mockMessageSvc := NewMockSheet(ctrl)
+			sheet, mockSheetSvc, mockMessageSvc, _ := NewMockSheet(ctrl)
 
 			if tc.setupMocks != nil {
 				tc.setupMocks(mockSheetSvc, mockMessageSvc)
@@ -333,7 +476,7 @@ func TestGetPhotoUrl(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			sheet, mockSheetSvc, mockMessageSvc := NewMockSheet(ctrl)
+			sheet, mockSheetSvc, mockMessageSvc, _ := NewMockSheet(ctrl)
 
 			if tc.setupMocks != nil {
 				tc.setupMocks(mockSheetSvc, mockMessageSvc)
@@ -19,20 +19,30 @@ type SheetV2Handler interface {
 	SyncUnsyncedTableRecords(c *gin.Context, r reqV2.SyncUnsyncedTableRecordsReq, uc ijwt.UserClaims) (response.Response, error)
 	ForceSyncUserTableRecords(c *gin.Context, r reqV2.ForceSyncUserTableRecordsReq, uc ijwt.UserClaims) (response.Response, error)
 	ForceSyncTableRecords(c *gin.Context, r reqV2.ForceSyncTableRecordsReq, uc ijwt.UserClaims) (response.Response, error)
+	IncrementalSyncTableRecords(c *gin.Context, r reqV2.IncrementalSyncTableRecordsReq, uc ijwt.UserClaims) (response.Response, error)
 	GetFAQRecord(c *gin.Context, r reqV2.GetFAQProblemTableRecordReg, uc ijwt.UserClaims) (response.Response, error)
 	UpdateFAQResolutionRecord(c *gin.Context, r reqV2.FAQResolutionUpdateReq, uc ijwt.UserClaims) (response.Response, error)
 	SyncFAQRecord(c *gin.Context, r reqV2.SyncFaqRecordReq, uc ijwt.UserClaims) (response.Response, error)
+	GetSyncJob(c *gin.Context, r reqV2.GetSyncJobReq, uc ijwt.UserClaims) (response.Response, error)
+	ListSyncJobs(c *gin.Context, r reqV2.ListSyncJobsReq, uc ijwt.UserClaims) (response.Response, error)
+	ListRecordMessages(c *gin.Context, r reqV2.ListRecordMessagesReq, uc ijwt.UserClaims) (response.Response, error)
+	PostRecordMessage(c *gin.Context, r reqV2.PostRecordMessageReq, uc ijwt.UserClaims) (response.Response, error)
+	EditRecord(c *gin.Context, r reqV2.EditRecordReq, uc ijwt.UserClaims) (response.Response, error)
+	WithdrawRecord(c *gin.Context, r reqV2.WithdrawRecordReq, uc ijwt.UserClaims) (response.Response, error)
+	RateRecord(c *gin.Context, r reqV2.RateRecordReq, uc ijwt.UserClaims) (response.Response, error)
 }
 
 type SheetV2 struct {
 	s service.SheetService
 	m service.MessageService
+	t service.ThreadService
 }
 
-func NewSheetV2(s service.SheetService, m service.MessageService) SheetV2Handler {
+func NewSheetV2(s service.SheetService, m service.MessageService, t service.ThreadService) SheetV2Handler {
 	sheet := &SheetV2{
 		s: s,
 		m: m,
+		t: t,
 	}
 
 	return sheet
@@ -126,18 +136,19 @@ func (s *SheetV2) SyncUnsyncedTableRecords(c *gin.Context, r reqV2.SyncUnsyncedT
 	}
 
 	// 调用 service 层
-	recordIDs, total, full, err := s.s.SyncUnsyncedTableRecords(&tableConfig)
+	submission, err := s.s.SyncUnsyncedTableRecords(&tableConfig)
 	if err != nil {
 		return response.Response{}, err
 	}
 
 	resp := respV2.SyncUnsyncedTableRecordsResp{
+		JobID:     submission.JobID,
 		RecordIDs: make([]string, 0),
-		QueueFull: full,
-		Total:     total,
+		QueueFull: submission.QueueFull,
+		Total:     submission.Total,
 	}
-	if recordIDs != nil {
-		resp.RecordIDs = recordIDs
+	if submission.RecordIDs != nil {
+		resp.RecordIDs = submission.RecordIDs
 	}
 
 	return response.Response{
@@ -176,19 +187,19 @@ func (s *SheetV2) ForceSyncUserTableRecords(c *gin.Context, r reqV2.ForceSyncUse
 	}
 
 	// 调用 service 层
-	recordIDs, total, full, err := s.s.ForceSyncUserTableRecords(r.StudentID, &tableConfig)
+	submission, err := s.s.ForceSyncUserTableRecords(r.StudentID, &tableConfig)
 	if err != nil {
 		return response.Response{}, err
 	}
 
 	resp := respV2.ForceSyncUserTableRecordsResp{
+		JobID:     submission.JobID,
 		RecordIDs: make([]string, 0),
-		QueueFull: full,
-		Total:     total,
+		QueueFull: submission.QueueFull,
+		Total:     submission.Total,
 	}
-
-	if recordIDs != nil {
-		resp.RecordIDs = recordIDs
+	if submission.RecordIDs != nil {
+		resp.RecordIDs = submission.RecordIDs
 	}
 
 	return response.Response{
@@ -227,19 +238,73 @@ func (s *SheetV2) ForceSyncTableRecords(c *gin.Context, r reqV2.ForceSyncTableRe
 	}
 
 	// 调用 service 层
-	recordIDs, total, full, err := s.s.ForceSyncTableRecords(&tableConfig)
+	submission, err := s.s.ForceSyncTableRecords(&tableConfig)
 	if err != nil {
 		return response.Response{}, err
 	}
 
 	resp := respV2.ForceSyncTableRecordsResp{
+		JobID:     submission.JobID,
 		RecordIDs: make([]string, 0),
-		QueueFull: full,
-		Total:     total,
+		QueueFull: submission.QueueFull,
+		Total:     submission.Total,
+	}
+	if submission.RecordIDs != nil {
+		resp.RecordIDs = submission.RecordIDs
 	}
 
-	if recordIDs != nil {
-		resp.RecordIDs = recordIDs
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// IncrementalSyncTableRecords 按修改时间增量同步表格记录
+//
+//	@Summary		增量同步表格记录
+//	@Description	拉取飞书中最后修改时间不早于上次同步高水位的记录并同步到数据库，用于在不全表扫描的情况下同步已同步记录的后续修改。
+//	@Tags			SheetV2
+//	@ID				incremental-sync-table-records
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string																true	"Bearer Token"
+//	@Param			request			body		reqV2.IncrementalSyncTableRecordsReq								true	"增量同步请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.IncrementalSyncTableRecordsResp}	"同步成功"
+//	@Failure		400				{object}	response.Response													"请求参数错误"
+//	@Failure		500				{object}	response.Response													"服务器内部错误"
+//	@Router			/api/v2/sheet/sync/incremental [post]
+func (s *SheetV2) IncrementalSyncTableRecords(c *gin.Context, r reqV2.IncrementalSyncTableRecordsReq, uc ijwt.UserClaims) (response.Response, error) {
+	// 校验表权限
+	if err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity); err != nil {
+		return response.Response{}, err
+	}
+	if bytes.Contains([]byte(*r.TableIdentify), []byte("-faq")) {
+		return response.Response{}, errs.TableIdentifierInvalidError(errors.New("FAQ 表格不支持增量同步"))
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	// 调用 service 层
+	submission, err := s.s.IncrementalSyncTableRecords(&tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.IncrementalSyncTableRecordsResp{
+		JobID:     submission.JobID,
+		RecordIDs: make([]string, 0),
+		QueueFull: submission.QueueFull,
+		Total:     submission.Total,
+	}
+	if submission.RecordIDs != nil {
+		resp.RecordIDs = submission.RecordIDs
 	}
 
 	return response.Response{
@@ -355,7 +420,7 @@ func (s *SheetV2) UpdateFAQResolutionRecord(c *gin.Context, r reqV2.FAQResolutio
 //	@Produce		json
 //	@Param			Authorization	header		string					true	"Bearer Token"
 //	@Param			request			body		reqV2.SyncFaqRecordReq	true	"同步FAQ记录请求参数"
-//	@Success		200				{object}	response.Response		"成功同步FAQ记录"
+//	@Success		200				{object}	response.Response{data=respV2.SyncFAQRecordResp}	"成功同步FAQ记录"
 //	@Failure		500				{object}	response.Response		"服务器内部错误"
 //	@Router			/api/v2/sheet/sync/faq [post]
 func (s *SheetV2) SyncFAQRecord(c *gin.Context, r reqV2.SyncFaqRecordReq, uc ijwt.UserClaims) (response.Response, error) {
@@ -372,7 +437,310 @@ func (s *SheetV2) SyncFAQRecord(c *gin.Context, r reqV2.SyncFaqRecordReq, uc ijw
 		ViewID:        &uc.ViewId,
 	}
 
-	err = s.s.SyncFAQRecord(tableConfig)
+	submission, err := s.s.SyncFAQRecord(tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.SyncFAQRecordResp{
+			JobID: submission.JobID,
+			Total: submission.Total,
+		},
+	}, nil
+}
+
+// GetSyncJob 查询同步任务进度
+//
+//	@Summary		查询同步任务
+//	@Description	根据同步接口返回的 job_id 查询同步任务的状态（queued/running/succeeded/partially_failed/failed）、记录数统计与错误示例。
+//	@Tags			SheetV2
+//	@ID				get-sync-job
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string											true	"Bearer Token"
+//	@Param			id				path		string											true	"同步任务 ID"
+//	@Param			request			query		reqV2.GetSyncJobReq								true	"查询同步任务请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.GetSyncJobResp}	"成功返回同步任务"
+//	@Failure		404				{object}	response.Response								"同步任务不存在"
+//	@Failure		500				{object}	response.Response								"服务器内部错误"
+//	@Router			/api/v2/sheet/sync/jobs/{id} [get]
+func (s *SheetV2) GetSyncJob(c *gin.Context, r reqV2.GetSyncJobReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	job, err := s.s.GetSyncJob(c.Param("id"), &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.GetSyncJobResp{
+			Job: *job,
+		},
+	}, nil
+}
+
+// ListSyncJobs 查询表格下的同步任务列表
+//
+//	@Summary		查询同步任务列表
+//	@Description	按创建时间倒序分页查询指定表格下的同步任务。
+//	@Tags			SheetV2
+//	@ID				list-sync-jobs
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string											true	"Bearer Token"
+//	@Param			request			query		reqV2.ListSyncJobsReq							true	"查询同步任务列表请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.ListSyncJobsResp}	"成功返回同步任务列表"
+//	@Failure		400				{object}	response.Response								"请求参数错误"
+//	@Failure		500				{object}	response.Response								"服务器内部错误"
+//	@Router			/api/v2/sheet/sync/jobs [get]
+func (s *SheetV2) ListSyncJobs(c *gin.Context, r reqV2.ListSyncJobsReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := s.s.ListSyncJobs(r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListSyncJobsResp{
+		Jobs:      make([]domain.SyncJob, 0),
+		HasMore:   false,
+		PageToken: "",
+	}
+	if serviceResult.Jobs != nil {
+		resp.Jobs = serviceResult.Jobs
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// ListRecordMessages 查询反馈记录下的对话
+//
+//	@Summary		查询反馈记录下的对话
+//	@Description	分页查询学生自己的反馈记录下与工作人员的对话，按时间正序。
+//	@Tags			SheetV2
+//	@ID				list-record-messages
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string													true	"Bearer Token"
+//	@Param			record_id		path		string													true	"飞书记录 ID"
+//	@Param			request			query		reqV2.ListRecordMessagesReq								true	"查询对话请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.ListRecordMessagesResp}	"成功返回对话"
+//	@Failure		400				{object}	response.Response										"请求参数错误"
+//	@Failure		404				{object}	response.Response										"反馈记录不存在"
+//	@Failure		500				{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v2/sheet/records/{record_id}/messages [get]
+func (s *SheetV2) ListRecordMessages(c *gin.Context, r reqV2.ListRecordMessagesReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := s.t.ListMessages(c.Param("record_id"), *r.StudentID, r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListRecordMessagesResp{
+		Messages:  make([]domain.FeedbackMessage, 0),
+		HasMore:   false,
+		PageToken: "",
+	}
+	if serviceResult.Messages != nil {
+		resp.Messages = serviceResult.Messages
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// PostRecordMessage 学生追问
+//
+//	@Summary		学生追问
+//	@Description	学生在自己的反馈记录下追问，工作人员会收到飞书卡片提醒，对话会回写到表格配置的对话列。
+//	@Tags			SheetV2
+//	@ID				post-record-message
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string													true	"Bearer Token"
+//	@Param			record_id		path		string													true	"飞书记录 ID"
+//	@Param			request			body		reqV2.PostRecordMessageReq								true	"追问请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.PostRecordMessageResp}	"发送成功"
+//	@Failure		400				{object}	response.Response										"请求参数错误或留言内容无效"
+//	@Failure		404				{object}	response.Response										"反馈记录不存在"
+//	@Failure		500				{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v2/sheet/records/{record_id}/messages [post]
+func (s *SheetV2) PostRecordMessage(c *gin.Context, r reqV2.PostRecordMessageReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	msg, err := s.t.PostStudentMessage(c.Request.Context(), c.Param("record_id"), *r.StudentID, *r.Content, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.PostRecordMessageResp{
+			Message: *msg,
+		},
+	}, nil
+}
+
+// EditRecord 学生修改反馈记录
+//
+//	@Summary		学生修改反馈记录
+//	@Description	修改自己提交的反馈内容、截图或联系方式，只能在表格配置的时长（edit_window）内且进度为待处理时修改。修改前后的内容会保存为审计记录。
+//	@Tags			SheetV2
+//	@ID				edit-record
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string				true	"Bearer Token"
+//	@Param			record_id		path		string				true	"飞书记录 ID"
+//	@Param			request			body		reqV2.EditRecordReq	true	"修改记录请求参数"
+//	@Success		200				{object}	response.Response	"修改成功"
+//	@Failure		400				{object}	response.Response	"请求参数错误或修改内容无效"
+//	@Failure		403				{object}	response.Response	"记录当前不可修改"
+//	@Failure		404				{object}	response.Response	"记录不存在"
+//	@Failure		500				{object}	response.Response	"服务器内部错误"
+//	@Router			/api/v2/sheet/records/{record_id} [put]
+func (s *SheetV2) EditRecord(c *gin.Context, r reqV2.EditRecordReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+	edit := domain.RecordEdit{
+		Content:     r.Content,
+		Images:      r.Images,
+		ContactInfo: r.ContactInfo,
+	}
+
+	err = s.s.EditRecord(c.Request.Context(), c.Param("record_id"), *r.StudentID, edit, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    nil,
+	}, nil
+}
+
+// WithdrawRecord 学生撤回反馈记录
+//
+//	@Summary		学生撤回反馈记录
+//	@Description	撤回自己提交的反馈记录，进度更新为已撤回，记录不会被删除。限制与修改相同。
+//	@Tags			SheetV2
+//	@ID				withdraw-record
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string					true	"Bearer Token"
+//	@Param			record_id		path		string					true	"飞书记录 ID"
+//	@Param			request			body		reqV2.WithdrawRecordReq	true	"撤回记录请求参数"
+//	@Success		200				{object}	response.Response		"撤回成功"
+//	@Failure		400				{object}	response.Response		"请求参数错误"
+//	@Failure		403				{object}	response.Response		"记录当前不可撤回"
+//	@Failure		404				{object}	response.Response		"记录不存在"
+//	@Failure		500				{object}	response.Response		"服务器内部错误"
+//	@Router			/api/v2/sheet/records/{record_id}/withdraw [post]
+func (s *SheetV2) WithdrawRecord(c *gin.Context, r reqV2.WithdrawRecordReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	err = s.s.WithdrawRecord(c.Param("record_id"), *r.StudentID, r.Reason, &tableConfig)
 	if err != nil {
 		return response.Response{}, err
 	}
@@ -383,3 +751,49 @@ func (s *SheetV2) SyncFAQRecord(c *gin.Context, r reqV2.SyncFaqRecordReq, uc ijw
 		Data:    nil,
 	}, nil
 }
+
+// RateRecord 学生评价反馈记录
+//
+//	@Summary		学生评价反馈记录
+//	@Description	对自己已完成的反馈记录进行 1-5 分的满意度评价，可附评价内容，每条记录只能评价一次。评价会回写到表格配置的评价列。
+//	@Tags			SheetV2
+//	@ID				rate-record
+//	@Accept			json
+//	@Produce		json
+//	@Param			Authorization	header		string										true	"Bearer Token"
+//	@Param			record_id		path		string										true	"飞书记录 ID"
+//	@Param			request			body		reqV2.RateRecordReq							true	"评价请求参数"
+//	@Success		200				{object}	response.Response{data=respV2.RateRecordResp}	"评价成功"
+//	@Failure		400				{object}	response.Response							"请求参数错误"
+//	@Failure		403				{object}	response.Response							"记录未完成，暂不能评价"
+//	@Failure		404				{object}	response.Response							"记录不存在"
+//	@Failure		409				{object}	response.Response							"记录已评价"
+//	@Failure		500				{object}	response.Response							"服务器内部错误"
+//	@Router			/api/v2/sheet/records/{record_id}/rating [post]
+func (s *SheetV2) RateRecord(c *gin.Context, r reqV2.RateRecordReq, uc ijwt.UserClaims) (response.Response, error) {
+	err := validateTableIdentify(*r.TableIdentify, uc.TableIdentity)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	tableConfig := domain.TableConfig{
+		TableIdentity: &uc.TableIdentity,
+		TableName:     &uc.TableName,
+		TableToken:    &uc.TableToken,
+		TableID:       &uc.TableId,
+		ViewID:        &uc.ViewId,
+	}
+
+	rating, err := s.s.RateRecord(c.Param("record_id"), *r.StudentID, *r.Score, r.Comment, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.RateRecordResp{
+			Rating: *rating,
+		},
+	}, nil
+}
@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	reqV2 "github.com/muxi-Infra/FeedBack-Backend/api/request/v2"
+	"github.com/muxi-Infra/FeedBack-Backend/api/response"
+	respV2 "github.com/muxi-Infra/FeedBack-Backend/api/response/v2"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/service"
+)
+
+// WebhookHandler webhook 订阅管理接口，使用 BasicAuth 保护，按 table_identify 指定表格
+type WebhookHandler interface {
+	CreateWebhook(c *gin.Context, r reqV2.CreateWebhookReq) (response.Response, error)
+	ListWebhooks(c *gin.Context, r reqV2.WebhookReq) (response.Response, error)
+	DeleteWebhook(c *gin.Context, r reqV2.WebhookReq) (response.Response, error)
+	ListWebhookDeliveries(c *gin.Context, r reqV2.ListWebhookDeliveriesReq) (response.Response, error)
+	RedeliverWebhook(c *gin.Context, r reqV2.WebhookReq) (response.Response, error)
+}
+
+type Webhook struct {
+	w service.WebhookService
+	a service.AuthService
+}
+
+func NewWebhook(w service.WebhookService, a service.AuthService) WebhookHandler {
+	return &Webhook{
+		w: w,
+		a: a,
+	}
+}
+
+// CreateWebhook 创建 webhook 订阅
+//
+//	@Summary		创建 webhook 订阅
+//	@Description	为表格创建 webhook 订阅，事件发生时向 url 推送 JSON 请求体。请求头 X-Feedback-Timestamp 为发送时间（Unix 秒），X-Feedback-Signature 为 sha256=HMAC-SHA256(secret, timestamp + "." + body) 的十六进制。投递失败按指数退避重试。
+//	@Tags			Webhook
+//	@ID				create-webhook
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	body		reqV2.CreateWebhookReq								true	"创建 webhook 订阅请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.CreateWebhookResp}	"创建成功，secret 只在此时返回"
+//	@Failure		400		{object}	response.Response									"请求参数错误"
+//	@Failure		401		{object}	response.Response									"未授权，BasicAuth 验证失败"
+//	@Failure		500		{object}	response.Response									"服务器内部错误"
+//	@Router			/api/v2/admin/webhooks [post]
+func (w *Webhook) CreateWebhook(c *gin.Context, r reqV2.CreateWebhookReq) (response.Response, error) {
+	tableConfig, err := w.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	secret := ""
+	if r.Secret != nil {
+		secret = *r.Secret
+	}
+
+	webhook, err := w.w.CreateWebhook(*r.URL, r.Events, secret, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.CreateWebhookResp{
+			Webhook: *webhook,
+		},
+	}, nil
+}
+
+// ListWebhooks 查询 webhook 订阅列表
+//
+//	@Summary		查询 webhook 订阅列表
+//	@Description	查询表格下的全部 webhook 订阅，不返回 secret。
+//	@Tags			Webhook
+//	@ID				list-webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			request	query		reqV2.WebhookReq								true	"查询 webhook 订阅列表请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.ListWebhooksResp}	"成功返回 webhook 订阅列表"
+//	@Failure		401		{object}	response.Response								"未授权，BasicAuth 验证失败"
+//	@Failure		500		{object}	response.Response								"服务器内部错误"
+//	@Router			/api/v2/admin/webhooks [get]
+func (w *Webhook) ListWebhooks(c *gin.Context, r reqV2.WebhookReq) (response.Response, error) {
+	tableConfig, err := w.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	webhooks, err := w.w.ListWebhooks(&tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data: respV2.ListWebhooksResp{
+			Webhooks: webhooks,
+		},
+	}, nil
+}
+
+// DeleteWebhook 删除 webhook 订阅
+//
+//	@Summary		删除 webhook 订阅
+//	@Description	删除 webhook 订阅，尚未投递的事件不再投递，投递记录保留。
+//	@Tags			Webhook
+//	@ID				delete-webhook
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			id		path		int					true	"webhook 订阅 ID"
+//	@Param			request	query		reqV2.WebhookReq	true	"删除 webhook 订阅请求参数"
+//	@Success		200		{object}	response.Response	"删除成功"
+//	@Failure		401		{object}	response.Response	"未授权，BasicAuth 验证失败"
+//	@Failure		404		{object}	response.Response	"webhook 订阅不存在"
+//	@Failure		500		{object}	response.Response	"服务器内部错误"
+//	@Router			/api/v2/admin/webhooks/{id} [delete]
+func (w *Webhook) DeleteWebhook(c *gin.Context, r reqV2.WebhookReq) (response.Response, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.Response{}, errs.WebhookNotFoundError(fmt.Errorf("invalid webhook id: %w", err))
+	}
+
+	tableConfig, err := w.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	err = w.w.DeleteWebhook(id, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    nil,
+	}, nil
+}
+
+// ListWebhookDeliveries 查询 webhook 投递记录
+//
+//	@Summary		查询 webhook 投递记录
+//	@Description	分页查询 webhook 订阅的投递记录，包含重试次数、最近一次的 HTTP 状态码与错误信息，可按状态（pending/delivered/dead）过滤。
+//	@Tags			Webhook
+//	@ID				list-webhook-deliveries
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			id		path		int													true	"webhook 订阅 ID"
+//	@Param			request	query		reqV2.ListWebhookDeliveriesReq							true	"查询 webhook 投递记录请求参数"
+//	@Success		200		{object}	response.Response{data=respV2.ListWebhookDeliveriesResp}	"成功返回投递记录"
+//	@Failure		400		{object}	response.Response										"请求参数错误"
+//	@Failure		401		{object}	response.Response										"未授权，BasicAuth 验证失败"
+//	@Failure		404		{object}	response.Response										"webhook 订阅不存在"
+//	@Failure		500		{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v2/admin/webhooks/{id}/deliveries [get]
+func (w *Webhook) ListWebhookDeliveries(c *gin.Context, r reqV2.ListWebhookDeliveriesReq) (response.Response, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.Response{}, errs.WebhookNotFoundError(fmt.Errorf("invalid webhook id: %w", err))
+	}
+
+	tableConfig, err := w.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	status := ""
+	if r.Status != nil {
+		status = *r.Status
+	}
+	limitSize := 0
+	if r.LimitSize != nil {
+		limitSize = *r.LimitSize
+	}
+
+	serviceResult, err := w.w.ListWebhookDeliveries(id, status, r.PageToken, limitSize, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	resp := respV2.ListWebhookDeliveriesResp{
+		Deliveries: make([]domain.WebhookDelivery, 0),
+		HasMore:    false,
+		PageToken:  "",
+	}
+	if serviceResult.Deliveries != nil {
+		resp.Deliveries = serviceResult.Deliveries
+	}
+	if serviceResult.PageToken != nil {
+		resp.PageToken = *serviceResult.PageToken
+	}
+	if serviceResult.HasMore != nil {
+		resp.HasMore = *serviceResult.HasMore
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    resp,
+	}, nil
+}
+
+// RedeliverWebhook 重新投递 webhook 事件
+//
+//	@Summary		重新投递 webhook 事件
+//	@Description	将投递记录重置为待投递并清零重试次数，由后台重新投递。请求体与事件 ID 保持不变，订阅方可据此去重。
+//	@Tags			Webhook
+//	@ID				redeliver-webhook
+//	@Accept			json
+//	@Produce		json
+//	@Security		BasicAuth
+//	@Param			delivery_id	path		int					true	"投递记录 ID"
+//	@Param			request		query		reqV2.WebhookReq	true	"重新投递请求参数"
+//	@Success		200			{object}	response.Response	"已重新加入投递队列"
+//	@Failure		401			{object}	response.Response	"未授权，BasicAuth 验证失败"
+//	@Failure		404			{object}	response.Response	"投递记录或 webhook 订阅不存在"
+//	@Failure		500			{object}	response.Response	"服务器内部错误"
+//	@Router			/api/v2/admin/webhooks/deliveries/{delivery_id}/redeliver [post]
+func (w *Webhook) RedeliverWebhook(c *gin.Context, r reqV2.WebhookReq) (response.Response, error) {
+	id, err := strconv.ParseUint(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		return response.Response{}, errs.WebhookDeliveryNotFoundError(fmt.Errorf("invalid delivery id: %w", err))
+	}
+
+	tableConfig, err := w.a.GetTableConfig(r.TableIdentify)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	err = w.w.RedeliverWebhook(id, &tableConfig)
+	if err != nil {
+		return response.Response{}, err
+	}
+
+	return response.Response{
+		Code:    0,
+		Message: "Success",
+		Data:    nil,
+	}, nil
+}
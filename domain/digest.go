@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Digest 表格在一个统计区间内的摘要，发送给管理员
+type Digest struct {
+	TableIdentify string
+	TableName     string
+	Since         time.Time
+	Until         time.Time
+
+	// 反馈记录表格
+	NewCount     int64
+	PendingCount []DigestProgressCount
+	Oldest       []DigestItem
+
+	// FAQ 表格，区间内投出或改投的票数，按投票的最新结果统计
+	IsFAQ           bool
+	VotedResolved   int64
+	VotedUnresolved int64
+}
+
+// DigestProgressCount 某个进度下未完成的记录数
+type DigestProgressCount struct {
+	Progress string
+	Count    int64
+}
+
+// DigestItem 未完成的记录
+type DigestItem struct {
+	RecordID  string
+	Content   string
+	Progress  string
+	ShareURL  string
+	CreatedAt time.Time
+}
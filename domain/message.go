@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type LarkMessage struct {
 	Type string          `json:"type"`
 	Data LarkMessageData `json:"data"`
@@ -11,6 +13,25 @@ type LarkMessageData struct {
 	TemplateVariable    map[string]interface{} `json:"template_variable"`
 }
 
+// LarkReceiver 飞书卡片的接收者，Type 为 chat_id、open_id、user_id、union_id 或 email
+type LarkReceiver struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// LarkDeliveryReport 一次飞书卡片发送的报告，每个接收者一条结果
+type LarkDeliveryReport struct {
+	Results []LarkDeliveryResult `json:"results"`
+}
+
+type LarkDeliveryResult struct {
+	Receiver  LarkReceiver `json:"receiver"`
+	Success   bool         `json:"success"`
+	RequestID string       `json:"request_id"` // 飞书返回的请求 ID，请求未发出时为空
+	Code      int          `json:"code"`       // 飞书错误码，成功或请求未发出时为 0
+	Error     string       `json:"error"`
+}
+
 type CCNUBoxFeedMessage struct {
 	Content   string `json:"content"`
 	StudentID string `json:"student_id"`
@@ -18,7 +39,74 @@ type CCNUBoxFeedMessage struct {
 	RecordID  string `json:"recordID"`
 }
 
+// NotificationResult 一次通知投递的结果，记录到投递日志中
+type NotificationResult struct {
+	StatusCode int    // HTTP 状态码，非 HTTP 渠道为 0
+	Response   string // 对方返回的内容
+}
+
 type NotificationRecipient struct {
-	StudentID string `json:"student_id"`
-	RecordID  string `json:"record_id"`
+	StudentID       string           `json:"student_id"`
+	RecordID        string           `json:"record_id"`
+	Record          map[string]any   `json:"record"`           // 记录内容，用于渲染通知内容
+	ShareURL        string           `json:"share_url"`        // 记录分享链接
+	Progress        string           `json:"progress"`         // 本次通知的进度
+	ProgressVersion uint64           `json:"progress_version"` // 记录的进度版本，区分同一进度的多次通知，回复通知为 0
+	Reply           *FeedbackMessage `json:"reply"`            // 工作人员的回复，为空时为进度通知
+}
+
+// NotificationDelivery 一次学生通知投递的记录
+type NotificationDelivery struct {
+	ID         uint64    `json:"id"`
+	RecordID   string    `json:"record_id"`
+	StudentID  string    `json:"student_id"`
+	Channel    string    `json:"channel"`
+	Progress   string    `json:"progress"`
+	MessageID  *uint64   `json:"message_id,omitempty"` // 回复通知对应的留言 ID，进度通知为空
+	Attempt    int       `json:"attempt"`
+	Success    bool      `json:"success"`
+	StatusCode int       `json:"status_code"`
+	Response   *string   `json:"response"`
+	Error      *string   `json:"error"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type NotificationDeliveries struct {
+	Deliveries []NotificationDelivery
+	HasMore    *bool   // 是否有更多
+	PageToken  *string // 分页参数
+}
+
+// QuietHours 免打扰时段，时间为 HH:MM（服务器时区），End 早于 Start 时表示跨天
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// NotificationPreference 学生在某张表格下的通知偏好
+type NotificationPreference struct {
+	StudentID  string       `json:"student_id"`
+	OptOut     bool         `json:"opt_out"`     // 不再接收通知
+	Channels   []string     `json:"channels"`    // 按优先级排列的通知渠道，为空时使用表格的通知渠道
+	QuietHours []QuietHours `json:"quiet_hours"` // 免打扰时段
+}
+
+// Notification 学生的站内通知
+type Notification struct {
+	ID        uint64     `json:"id"`
+	RecordID  string     `json:"record_id"`
+	Progress  string     `json:"progress"`
+	MessageID uint64     `json:"message_id"` // 工作人员回复的留言 ID，进度通知为 0
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	ShareURL  *string    `json:"share_url"`
+	IsRead    bool       `json:"is_read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type Notifications struct {
+	Notifications []Notification
+	HasMore       *bool   // 是否有更多
+	PageToken     *string // 分页参数
 }
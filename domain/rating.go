@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// RecordRating 学生对反馈记录的满意度评价
+type RecordRating struct {
+	RecordID  string    `json:"record_id"`
+	Score     int       `json:"score"`
+	Comment   *string   `json:"comment"`
+	Handler   string    `json:"handler"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RatingStat 评价数与平均分
+type RatingStat struct {
+	Handler string  `json:"handler,omitempty"` // 处理人，表格统计时为空
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+}
+
+// RatingStats 表格的评价统计，同时按处理人分组
+type RatingStats struct {
+	Table    RatingStat   `json:"table"`
+	Handlers []RatingStat `json:"handlers"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type TableRecords struct {
 	Records   []TableRecord
 	HasMore   *bool   // 是否有更多
@@ -23,6 +25,44 @@ type TableConfig struct {
 	TableID       *string `json:"table_id"`
 	ViewID        *string `json:"view_id"`
 	Notice        bool    `json:"notice"`
+	NoticeChannel string  `json:"notice_channel"` // 通知渠道，为空时使用表格标识
+
+	// 新反馈飞书卡片的路由，未配置时使用全局的 larkMessage 配置
+	LarkTemplateID        string         `json:"lark_template_id"`
+	LarkReceivers         []LarkReceiver `json:"lark_receivers"`
+	LarkTemplateVariables map[string]any `json:"lark_template_variables"` // 卡片模板的附加变量
+
+	// 管理员摘要卡片，DigestSchedule 为空时不发送，接收者未配置时使用新反馈卡片的接收者
+	DigestSchedule  string         `json:"digest_schedule"`
+	DigestReceivers []LarkReceiver `json:"digest_receivers"`
+
+	// 对话：同步时将 ReplyField 列的新内容作为工作人员的回复导入，对话记录回写到 ThreadField 列，为空时不处理
+	ReplyField  string `json:"reply_field"`
+	ThreadField string `json:"thread_field"`
+
+	// 学生提交后可以修改或撤回记录的时长，为 0 时不允许修改
+	EditWindow time.Duration `json:"edit_window"`
+
+	// 满意度评价：HandlerField 为处理人列，用于按处理人统计；评价回写到 RatingField、RatingCommentField 列，为空时不回写
+	HandlerField       string `json:"handler_field"`
+	RatingField        string `json:"rating_field"`
+	RatingCommentField string `json:"rating_comment_field"`
+
+	// 内容审核的处理方式：allow 放行、mask 打码、reject 拒绝，为空时使用全局的 moderation 配置
+	Moderation string `json:"moderation"`
+}
+
+// RecordIntent 创建飞书记录前登记的处理任务，ClientToken 用于幂等地创建飞书记录
+type RecordIntent struct {
+	ID          uint64
+	ClientToken string
+}
+
+// RecordEdit 学生对反馈记录的修改，为 nil 的字段保持不变
+type RecordEdit struct {
+	Content     *string
+	Images      []string // 截图的文件 token，传空数组时清空截图
+	ContactInfo *string
 }
 
 // FAQTableRecords 定义多维表格记录及其解决状态的集合
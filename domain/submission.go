@@ -0,0 +1,8 @@
+package domain
+
+// SubmissionCheck 创建记录前的重复提交检测结果
+type SubmissionCheck struct {
+	Policy      string  // 命中重复时采用的处理策略，未命中时为空
+	DuplicateOf string  // 与之重复的记录 ID
+	Similarity  float64 // 与重复记录归一化后内容的相似度（0-1）
+}
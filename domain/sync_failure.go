@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// SyncFailure 同步到数据库失败的记录
+type SyncFailure struct {
+	TableIdentify string     `json:"table_identify"`
+	RecordID      string     `json:"record_id"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	DeadAt        *time.Time `json:"dead_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type SyncFailures struct {
+	Failures  []SyncFailure
+	HasMore   *bool   // 是否有更多
+	PageToken *string // 分页参数
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// SyncSubmission 提交同步请求的结果
+type SyncSubmission struct {
+	JobID     string   // 同步任务 ID，可用于查询处理进度
+	RecordIDs []string // 成功投递到队列的记录 ID
+	Total     int      // 成功投递到队列的记录数
+	Batches   int      // 成功投递到队列的批次数
+	QueueFull bool     // 队列是否已满
+}
+
+// SyncStats 一个批次的同步结果
+type SyncStats struct {
+	Fetched int     // 从飞书获取到的记录数
+	Updated int     // 成功写入数据库的记录数
+	Failed  int     // 同步失败的记录数
+	Deleted int     // 飞书中已删除、从数据库软删除的记录数
+	Error   *string // 失败原因示例
+}
+
+// SyncJob 同步任务的处理进度
+type SyncJob struct {
+	JobID         string     `json:"job_id"`
+	TableIdentify string     `json:"table_identify"`
+	Kind          string     `json:"kind"`
+	State         string     `json:"state"`
+	Total         int        `json:"total"`
+	Batches       int        `json:"batches"`
+	BatchesDone   int        `json:"batches_done"`
+	Fetched       int        `json:"fetched"`
+	Updated       int        `json:"updated"`
+	Failed        int        `json:"failed"`
+	ErrorSamples  []string   `json:"error_samples"`
+	CreatedAt     time.Time  `json:"created_at"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+}
+
+type SyncJobs struct {
+	Jobs      []SyncJob
+	HasMore   *bool   // 是否有更多
+	PageToken *string // 分页参数
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// FeedbackMessage 反馈记录下的一条留言
+type FeedbackMessage struct {
+	ID         uint64    `json:"id"`
+	RecordID   string    `json:"record_id"`
+	Sender     string    `json:"sender"`      // student / staff
+	SenderName string    `json:"sender_name"` // 学生为学号，工作人员为署名
+	Content    string    `json:"content"`
+	Source     string    `json:"source"` // api / lark
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type FeedbackMessages struct {
+	Messages  []FeedbackMessage
+	HasMore   *bool   // 是否有更多
+	PageToken *string // 分页参数
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// WebhookSubscription 表格的 webhook 订阅，Secret 只在创建时返回
+type WebhookSubscription struct {
+	ID            uint64    `json:"id"`
+	TableIdentify string    `json:"table_identify"`
+	URL           string    `json:"url"`
+	Secret        string    `json:"secret,omitempty"`
+	Events        []string  `json:"events"`
+	Enabled       bool      `json:"enabled"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// WebhookDelivery 一次事件投递的结果
+type WebhookDelivery struct {
+	ID             uint64     `json:"id"`
+	SubscriptionID uint64     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	Event          string     `json:"event"`
+	RecordID       string     `json:"record_id"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastStatusCode int        `json:"last_status_code"`
+	LastError      *string    `json:"last_error"`
+	NextRetryAt    time.Time  `json:"next_retry_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type WebhookDeliveries struct {
+	Deliveries []WebhookDelivery
+	HasMore    *bool   // 是否有更多
+	PageToken  *string // 分页参数
+}
+
+// WebhookRecord 触发事件的记录
+type WebhookRecord struct {
+	RecordID string
+	Record   map[string]any
+	ShareURL string
+}
+
+// WebhookEvent 推送给订阅方的请求体
+type WebhookEvent struct {
+	ID            string           `json:"id"` // 事件 ID，重试与重新投递时不变，可用于幂等
+	Event         string           `json:"event"`
+	TableIdentify string           `json:"table_identify"`
+	RecordID      string           `json:"record_id"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+	Data          WebhookEventData `json:"data"`
+}
+
+type WebhookEventData struct {
+	Record   map[string]any `json:"record"`
+	ShareURL string         `json:"share_url"`
+}
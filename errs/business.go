@@ -37,6 +37,52 @@ const (
 	MarkRecordNoticedErrorCode                              // 标记表格记录已通知错误
 	GetFAQRecordByTableErrorCode                            // 根据表格标识获取 FAQ 记录错误
 	SyncFAQRecordPartialFailedCode                          // 同步 FAQ 记录部分失败
+	SyncQueueEnqueueErrorCode                               // 同步任务入队失败
+	SyncQueueFullErrorCode                                  // 同步队列已满
+	LarkEventNotConfiguredErrorCode                         // 飞书事件回调未配置
+	CreateRecordOutboxErrorCode                             // 新增记录后续处理任务登记失败
+	CreateSyncJobErrorCode                                  // 创建同步任务失败
+	SyncJobNotFoundErrorCode                                // 同步任务不存在
+	GetSyncJobErrorCode                                     // 查询同步任务失败
+	GetSyncWatermarkErrorCode                               // 查询增量同步高水位失败
+	SyncFailureNotFoundErrorCode                            // 同步失败记录不存在
+	GetSyncFailureErrorCode                                 // 查询同步失败记录失败
+	UpdateSyncFailureErrorCode                              // 更新同步失败记录失败
+	GetRecordIDsByTableErrorCode                            // 根据表格标识获取记录 ID 错误
+	DeleteRecordDBErrorCode                                 // 删除数据库记录失败
+	ReconcileAbortedErrorCode                               // 已删除记录对账中止
+	MailAddressNotFoundErrorCode                            // 记录中未找到有效的邮箱地址
+	MailRenderErrorCode                                     // 邮件模板渲染失败
+	MailSendErrorCode                                       // 邮件发送失败
+	WebhookURLInvalidErrorCode                              // webhook 地址无效
+	WebhookNotFoundErrorCode                                // webhook 订阅不存在
+	WebhookDeliveryNotFoundErrorCode                        // webhook 投递记录不存在
+	CreateWebhookErrorCode                                  // 创建 webhook 订阅或投递任务失败
+	GetWebhookErrorCode                                     // 查询 webhook 订阅或投递记录失败
+	UpdateWebhookErrorCode                                  // 更新 webhook 订阅或投递记录失败
+	GetNotificationDeliveryErrorCode                        // 查询通知投递记录失败
+	GetDigestErrorCode                                      // 查询摘要统计失败
+	NotificationPreferenceInvalidErrorCode                  // 通知偏好设置无效
+	GetNotificationPreferenceErrorCode                      // 查询通知偏好失败
+	UpdateNotificationPreferenceErrorCode                   // 保存通知偏好失败
+	GetNotificationErrorCode                                // 查询站内通知失败
+	UpdateNotificationErrorCode                             // 更新站内通知失败
+	FeedbackRecordNotFoundErrorCode                         // 反馈记录不存在
+	FeedbackMessageInvalidErrorCode                         // 留言内容无效
+	CreateFeedbackMessageErrorCode                          // 保存留言失败
+	GetFeedbackMessageErrorCode                             // 查询留言失败
+	RecordNotEditableErrorCode                              // 记录当前不可修改或撤回
+	RecordEditInvalidErrorCode                              // 记录修改内容无效
+	RecordNotRatableErrorCode                               // 记录未完成，暂不能评价
+	RecordAlreadyRatedErrorCode                             // 记录已评价
+	CreateRatingErrorCode                                   // 保存评价失败
+	GetRatingStatsErrorCode                                 // 查询评价统计失败
+	DuplicateSubmissionErrorCode                            // 重复提交
+	SubmissionQuotaExceededErrorCode                        // 提交过于频繁
+	ContentRejectedErrorCode                                // 反馈内容未通过审核
+	CreateRecordRevisionErrorCode                           // 保存修改记录失败
+	ModerationUnavailableErrorCode                          // 内容审核暂不可用
+	TriggerNotificationErrorCode                            // 登记通知任务失败
 )
 
 var (
@@ -118,4 +164,142 @@ var (
 	SyncFAQRecordPartialFailedError = func(err error) error {
 		return errorx.New(http.StatusInternalServerError, SyncFAQRecordPartialFailedCode, "同步 FAQ 记录部分失败", err)
 	}
+	SyncQueueEnqueueError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, SyncQueueEnqueueErrorCode, "同步任务入队失败", err)
+	}
+	SyncQueueFullError = func(err error) error {
+		return errorx.New(http.StatusServiceUnavailable, SyncQueueFullErrorCode, "同步队列已满", err)
+	}
+	LarkEventNotConfiguredError = func(err error) error {
+		return errorx.New(http.StatusNotFound, LarkEventNotConfiguredErrorCode, "飞书事件回调未配置", err)
+	}
+	CreateRecordOutboxError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, CreateRecordOutboxErrorCode, "新增记录后续处理任务登记失败", err)
+	}
+	CreateSyncJobError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, CreateSyncJobErrorCode, "创建同步任务失败", err)
+	}
+	SyncJobNotFoundError = func(err error) error {
+		return errorx.New(http.StatusNotFound, SyncJobNotFoundErrorCode, "同步任务不存在", err)
+	}
+	GetSyncJobError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetSyncJobErrorCode, "查询同步任务失败", err)
+	}
+	GetSyncWatermarkError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetSyncWatermarkErrorCode, "查询增量同步高水位失败", err)
+	}
+	SyncFailureNotFoundError = func(err error) error {
+		return errorx.New(http.StatusNotFound, SyncFailureNotFoundErrorCode, "同步失败记录不存在", err)
+	}
+	GetSyncFailureError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetSyncFailureErrorCode, "查询同步失败记录失败", err)
+	}
+	UpdateSyncFailureError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, UpdateSyncFailureErrorCode, "更新同步失败记录失败", err)
+	}
+	GetRecordIDsByTableError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetRecordIDsByTableErrorCode, "根据表格标识获取记录 ID 错误", err)
+	}
+	DeleteRecordDBError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, DeleteRecordDBErrorCode, "删除数据库记录失败", err)
+	}
+	ReconcileAbortedError = func(err error) error {
+		return errorx.New(http.StatusConflict, ReconcileAbortedErrorCode, "待删除记录异常，已中止已删除记录对账", err)
+	}
+	MailAddressNotFoundError = func(err error) error {
+		return errorx.New(http.StatusBadRequest, MailAddressNotFoundErrorCode, "记录中未找到有效的邮箱地址", err)
+	}
+	MailRenderError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, MailRenderErrorCode, "邮件模板渲染失败", err)
+	}
+	MailSendError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, MailSendErrorCode, "邮件发送失败", err)
+	}
+	WebhookURLInvalidError = func(err error) error {
+		return errorx.New(http.StatusBadRequest, WebhookURLInvalidErrorCode, "webhook 地址无效", err)
+	}
+	WebhookNotFoundError = func(err error) error {
+		return errorx.New(http.StatusNotFound, WebhookNotFoundErrorCode, "webhook 订阅不存在", err)
+	}
+	WebhookDeliveryNotFoundError = func(err error) error {
+		return errorx.New(http.StatusNotFound, WebhookDeliveryNotFoundErrorCode, "webhook 投递记录不存在", err)
+	}
+	CreateWebhookError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, CreateWebhookErrorCode, "创建 webhook 订阅或投递任务失败", err)
+	}
+	GetWebhookError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetWebhookErrorCode, "查询 webhook 订阅或投递记录失败", err)
+	}
+	UpdateWebhookError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, UpdateWebhookErrorCode, "更新 webhook 订阅或投递记录失败", err)
+	}
+	GetNotificationDeliveryError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetNotificationDeliveryErrorCode, "查询通知投递记录失败", err)
+	}
+	GetDigestError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetDigestErrorCode, "查询摘要统计失败", err)
+	}
+	NotificationPreferenceInvalidError = func(err error) error {
+		return errorx.New(http.StatusBadRequest, NotificationPreferenceInvalidErrorCode, "通知偏好设置无效", err)
+	}
+	GetNotificationPreferenceError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetNotificationPreferenceErrorCode, "查询通知偏好失败", err)
+	}
+	UpdateNotificationPreferenceError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, UpdateNotificationPreferenceErrorCode, "保存通知偏好失败", err)
+	}
+	GetNotificationError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetNotificationErrorCode, "查询站内通知失败", err)
+	}
+	UpdateNotificationError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, UpdateNotificationErrorCode, "更新站内通知失败", err)
+	}
+	FeedbackRecordNotFoundError = func(err error) error {
+		return errorx.New(http.StatusNotFound, FeedbackRecordNotFoundErrorCode, "反馈记录不存在", err)
+	}
+	FeedbackMessageInvalidError = func(err error) error {
+		return errorx.New(http.StatusBadRequest, FeedbackMessageInvalidErrorCode, "留言内容无效", err)
+	}
+	CreateFeedbackMessageError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, CreateFeedbackMessageErrorCode, "保存留言失败", err)
+	}
+	GetFeedbackMessageError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetFeedbackMessageErrorCode, "查询留言失败", err)
+	}
+	RecordNotEditableError = func(err error) error {
+		return errorx.New(http.StatusForbidden, RecordNotEditableErrorCode, "记录当前不可修改或撤回", err)
+	}
+	RecordEditInvalidError = func(err error) error {
+		return errorx.New(http.StatusBadRequest, RecordEditInvalidErrorCode, "记录修改内容无效", err)
+	}
+	RecordNotRatableError = func(err error) error {
+		return errorx.New(http.StatusForbidden, RecordNotRatableErrorCode, "记录未完成，暂不能评价", err)
+	}
+	RecordAlreadyRatedError = func(err error) error {
+		return errorx.New(http.StatusConflict, RecordAlreadyRatedErrorCode, "记录已评价", err)
+	}
+	CreateRatingError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, CreateRatingErrorCode, "保存评价失败", err)
+	}
+	GetRatingStatsError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, GetRatingStatsErrorCode, "查询评价统计失败", err)
+	}
+	DuplicateSubmissionError = func(err error) error {
+		return errorx.New(http.StatusConflict, DuplicateSubmissionErrorCode, "与近期提交的反馈重复", err)
+	}
+	SubmissionQuotaExceededError = func(err error) error {
+		return errorx.New(http.StatusTooManyRequests, SubmissionQuotaExceededErrorCode, "提交过于频繁，请稍后再试", err)
+	}
+	ContentRejectedError = func(err error) error {
+		return errorx.New(http.StatusBadRequest, ContentRejectedErrorCode, "反馈内容包含不允许提交的信息，请修改后重试", err)
+	}
+	CreateRecordRevisionError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, CreateRecordRevisionErrorCode, "保存修改记录失败", err)
+	}
+	ModerationUnavailableError = func(err error) error {
+		return errorx.New(http.StatusServiceUnavailable, ModerationUnavailableErrorCode, "内容审核暂不可用，请稍后重试", err)
+	}
+	TriggerNotificationError = func(err error) error {
+		return errorx.New(http.StatusInternalServerError, TriggerNotificationErrorCode, "登记通知任务失败", err)
+	}
 )
@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
+// shutdownTimeout 收到退出信号后等待 HTTP 请求与后台任务结束的最长时间
+const shutdownTimeout = 30 * time.Second
+
 // @title		木犀反馈系统 API
 // @version	1.0
 // @host		localhost:8080
@@ -20,11 +32,50 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	app.r.Run(":8080")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    ":8080",
+		Handler: app.r,
+	}
+	// HTTP 服务（包括 /metrics）启动失败时记录日志并走正常的退出流程，让后台任务有机会结束
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			app.log.Error("http server stopped",
+				logger.String("error", err.Error()),
+				logger.String("addr", srv.Addr),
+			)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	app.log.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// 先停止接收新请求并等待处理中的请求完成，再通知后台任务退出
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		app.log.Error("http server shutdown failed",
+			logger.String("error", err.Error()),
+		)
+	}
+	if err := app.lc.Shutdown(shutdownCtx); err != nil {
+		app.log.Error("background tasks shutdown failed",
+			logger.String("error", err.Error()),
+		)
+	}
+	_ = app.log.Sync()
 }
 
 type App struct {
-	r *gin.Engine
+	r   *gin.Engine
+	lc  *lifecycle.Lifecycle
+	log logger.Logger
 }
 
 func initViper() {
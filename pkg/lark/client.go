@@ -23,6 +23,7 @@ type Client interface {
 	SendNotice(ctx context.Context, req *larkim.CreateMessageReq, options ...larkcore.RequestOptionFunc) (*larkim.CreateMessageResp, error)
 	GetRecordByRecordId(ctx context.Context, req *larkbitable.BatchGetAppTableRecordReq, options ...larkcore.RequestOptionFunc) (*larkbitable.BatchGetAppTableRecordResp, error)
 	UpdateRecord(ctx context.Context, req *larkbitable.UpdateAppTableRecordReq, options ...larkcore.RequestOptionFunc) (*larkbitable.UpdateAppTableRecordResp, error)
+	SubscribeFile(ctx context.Context, req *larkdrive.SubscribeFileReq, options ...larkcore.RequestOptionFunc) (*larkdrive.SubscribeFileResp, error)
 }
 
 type ClientImpl struct {
@@ -74,3 +75,8 @@ func (c *ClientImpl) GetRecordByRecordId(ctx context.Context, req *larkbitable.B
 func (c *ClientImpl) UpdateRecord(ctx context.Context, req *larkbitable.UpdateAppTableRecordReq, options ...larkcore.RequestOptionFunc) (*larkbitable.UpdateAppTableRecordResp, error) {
 	return c.c.Bitable.V1.AppTableRecord.Update(ctx, req, options...)
 }
+
+// SubscribeFile 订阅云文档事件，多维表格需要订阅后才能收到记录变更事件
+func (c *ClientImpl) SubscribeFile(ctx context.Context, req *larkdrive.SubscribeFileReq, options ...larkcore.RequestOptionFunc) (*larkdrive.SubscribeFileResp, error) {
+	return c.c.Drive.V1.File.Subscribe(ctx, req, options...)
+}
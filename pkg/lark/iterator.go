@@ -0,0 +1,147 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+// MaxSearchPageSize 多维表格查询记录接口允许的最大分页大小
+const MaxSearchPageSize = 500
+
+// ResponseError 飞书接口返回的业务错误（code != 0）
+type ResponseError struct {
+	RequestID string
+	CodeError larkcore.CodeError
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("lark response error, request_id: %s, %s", e.RequestID, larkcore.Prettify(e.CodeError))
+}
+
+// SearchRequestBuilder 根据分页 token 构造查询请求，第一页的 pageToken 为空字符串
+type SearchRequestBuilder func(pageToken string) *larkbitable.SearchAppTableRecordReq
+
+// SearchRecordIterator 按 page_token / has_more 逐页遍历多维表格记录
+//
+//	it := lark.NewSearchRecordIterator(ctx, c, build)
+//	for it.Next() {
+//		record := it.Record()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type SearchRecordIterator struct {
+	ctx   context.Context
+	c     Client
+	build SearchRequestBuilder
+
+	page      []*larkbitable.AppTableRecord
+	idx       int
+	pageToken string
+	hasMore   bool
+	total     *int
+	err       error
+}
+
+func NewSearchRecordIterator(ctx context.Context, c Client, build SearchRequestBuilder) *SearchRecordIterator {
+	return &SearchRecordIterator{
+		ctx:     ctx,
+		c:       c,
+		build:   build,
+		idx:     -1,
+		hasMore: true,
+	}
+}
+
+// Next 移动到下一条记录，当前页读完时自动拉取下一页；没有更多记录或出错时返回 false
+func (it *SearchRecordIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.idx++
+	for it.idx >= len(it.page) {
+		if !it.hasMore {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Record 返回当前记录，只能在 Next 返回 true 之后调用
+func (it *SearchRecordIterator) Record() *larkbitable.AppTableRecord {
+	return it.page[it.idx]
+}
+
+// Total 返回飞书返回的记录总数，拉取第一页之前为 nil
+func (it *SearchRecordIterator) Total() *int {
+	return it.total
+}
+
+// Err 返回遍历过程中遇到的错误，context 取消时返回 context 的错误
+func (it *SearchRecordIterator) Err() error {
+	return it.err
+}
+
+func (it *SearchRecordIterator) fetch() bool {
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	resp, err := it.c.GetAppTableRecord(it.ctx, it.build(it.pageToken))
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !resp.Success() {
+		respErr := &ResponseError{CodeError: resp.CodeError}
+		if resp.ApiResp != nil {
+			respErr.RequestID = resp.RequestId()
+		}
+		it.err = respErr
+		return false
+	}
+
+	it.page = nil
+	it.idx = 0
+	it.hasMore = false
+	if resp.Data == nil {
+		return true
+	}
+
+	it.page = resp.Data.Items
+	it.total = resp.Data.Total
+	if resp.Data.HasMore != nil && *resp.Data.HasMore && resp.Data.PageToken != nil && *resp.Data.PageToken != "" {
+		// 防止接口返回相同的 page_token 导致死循环
+		if *resp.Data.PageToken == it.pageToken {
+			it.err = fmt.Errorf("lark search returned repeated page_token: %s", it.pageToken)
+			return false
+		}
+		it.hasMore = true
+		it.pageToken = *resp.Data.PageToken
+	}
+
+	return true
+}
+
+// CollectSearchRecords 拉取所有分页的记录
+func CollectSearchRecords(ctx context.Context, c Client, build SearchRequestBuilder) ([]*larkbitable.AppTableRecord, error) {
+	var records []*larkbitable.AppTableRecord
+
+	it := NewSearchRecordIterator(ctx, c, build)
+	for it.Next() {
+		records = append(records, it.Record())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
@@ -0,0 +1,189 @@
+package lark_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	mocks "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildSearchReq(pageToken string) *larkbitable.SearchAppTableRecordReq {
+	return larkbitable.NewSearchAppTableRecordReqBuilder().
+		AppToken("mock-app-token").
+		TableId("mock-table-id").
+		PageToken(pageToken).
+		PageSize(lark.MaxSearchPageSize).
+		Build()
+}
+
+func searchPage(hasMore bool, pageToken string, total int, recordIDs ...string) *larkbitable.SearchAppTableRecordResp {
+	items := make([]*larkbitable.AppTableRecord, 0, len(recordIDs))
+	for _, id := range recordIDs {
+		items = append(items, &larkbitable.AppTableRecord{RecordId: &id})
+	}
+
+	return &larkbitable.SearchAppTableRecordResp{
+		Data: &larkbitable.SearchAppTableRecordRespData{
+			Items:     items,
+			HasMore:   &hasMore,
+			PageToken: &pageToken,
+			Total:     &total,
+		},
+	}
+}
+
+func TestCollectSearchRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        func() context.Context
+		setupMocks func(c *mocks.MockClient)
+		wantIDs    []string
+		wantTokens []string // 依次请求的 page_token
+		wantErr    func(t *testing.T, err error)
+	}{
+		{
+			name: "collect all pages",
+			ctx:  context.Background,
+			setupMocks: func(c *mocks.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(true, "p2", 5, "rec1", "rec2"), nil),
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(true, "p3", 5, "rec3", "rec4"), nil),
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(false, "", 5, "rec5"), nil),
+				)
+			},
+			wantIDs:    []string{"rec1", "rec2", "rec3", "rec4", "rec5"},
+			wantTokens: []string{"", "p2", "p3"},
+		},
+		{
+			name: "skip empty page",
+			ctx:  context.Background,
+			setupMocks: func(c *mocks.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(true, "p2", 1), nil),
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(false, "", 1, "rec1"), nil),
+				)
+			},
+			wantIDs:    []string{"rec1"},
+			wantTokens: []string{"", "p2"},
+		},
+		{
+			name: "lark response error on second page",
+			ctx:  context.Background,
+			setupMocks: func(c *mocks.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(true, "p2", 3, "rec1"), nil),
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(&larkbitable.SearchAppTableRecordResp{
+							CodeError: larkcore.CodeError{Code: 1254000, Msg: "mock error"},
+						}, nil),
+				)
+			},
+			wantErr: func(t *testing.T, err error) {
+				var respErr *lark.ResponseError
+				assert.ErrorAs(t, err, &respErr)
+				assert.Equal(t, 1254000, respErr.CodeError.Code)
+			},
+		},
+		{
+			name: "lark request error",
+			ctx:  context.Background,
+			setupMocks: func(c *mocks.MockClient) {
+				c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock request error"))
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "mock request error")
+			},
+		},
+		{
+			name: "repeated page token",
+			ctx:  context.Background,
+			setupMocks: func(c *mocks.MockClient) {
+				gomock.InOrder(
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(true, "p2", 3, "rec1"), nil),
+					c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+						Return(searchPage(true, "p2", 3, "rec2"), nil),
+				)
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "context canceled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			setupMocks: func(c *mocks.MockClient) {},
+			wantErr: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, context.Canceled)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := mocks.NewMockClient(ctrl)
+			tt.setupMocks(c)
+
+			var tokens []string
+			records, err := lark.CollectSearchRecords(tt.ctx(), c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+				tokens = append(tokens, pageToken)
+				return buildSearchReq(pageToken)
+			})
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+				assert.Nil(t, records)
+				return
+			}
+
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(records))
+			for _, r := range records {
+				ids = append(ids, *r.RecordId)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantTokens, tokens)
+		})
+	}
+}
+
+func TestSearchRecordIteratorTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+		Return(searchPage(false, "", 2, "rec1", "rec2"), nil)
+
+	it := lark.NewSearchRecordIterator(context.Background(), c, buildSearchReq)
+	assert.Nil(t, it.Total())
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 2, count)
+	if assert.NotNil(t, it.Total()) {
+		assert.Equal(t, 2, *it.Total())
+	}
+}
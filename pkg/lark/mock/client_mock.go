@@ -178,6 +178,26 @@ func (mr *MockClientMockRecorder) SendNotice(arg0, arg1 interface{}, arg2 ...int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNotice", reflect.TypeOf((*MockClient)(nil).SendNotice), varargs...)
 }
 
+// SubscribeFile mocks base method.
+func (m *MockClient) SubscribeFile(arg0 context.Context, arg1 *larkdrive.SubscribeFileReq, arg2 ...larkcore.RequestOptionFunc) (*larkdrive.SubscribeFileResp, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubscribeFile", varargs...)
+	ret0, _ := ret[0].(*larkdrive.SubscribeFileResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeFile indicates an expected call of SubscribeFile.
+func (mr *MockClientMockRecorder) SubscribeFile(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFile", reflect.TypeOf((*MockClient)(nil).SubscribeFile), varargs...)
+}
+
 // UpdateRecord mocks base method.
 func (m *MockClient) UpdateRecord(arg0 context.Context, arg1 *larkbitable.UpdateAppTableRecordReq, arg2 ...larkcore.RequestOptionFunc) (*larkbitable.UpdateAppTableRecordResp, error) {
 	m.ctrl.T.Helper()
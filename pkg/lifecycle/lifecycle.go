@@ -0,0 +1,85 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Lifecycle 管理应用后台任务的生命周期
+// 后台 worker 通过 Context 感知退出信号，Shutdown 时取消根 context 并在截止时间内等待所有任务结束
+type Lifecycle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	running map[string]int // 正在运行的任务，按名称计数，用于超时时排查
+	stopped bool
+}
+
+func New() *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Lifecycle{
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]int),
+	}
+}
+
+// Context 返回根 context，Shutdown 时被取消
+func (l *Lifecycle) Context() context.Context {
+	return l.ctx
+}
+
+// Go 启动一个受管理的后台任务，fn 应在 ctx 取消后尽快返回
+// Shutdown 开始后提交的任务直接在当前 goroutine 中执行，保证不会丢失
+func (l *Lifecycle) Go(name string, fn func(ctx context.Context)) {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		fn(l.ctx)
+		return
+	}
+	l.wg.Add(1)
+	l.running[name]++
+	l.mu.Unlock()
+
+	go func() {
+		defer func() {
+			l.mu.Lock()
+			l.running[name]--
+			if l.running[name] == 0 {
+				delete(l.running, name)
+			}
+			l.mu.Unlock()
+			l.wg.Done()
+		}()
+
+		fn(l.ctx)
+	}()
+}
+
+// Shutdown 取消根 context 并等待所有任务结束，ctx 到期时返回仍在运行的任务
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	l.stopped = true
+	l.mu.Unlock()
+
+	l.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return fmt.Errorf("lifecycle shutdown timeout, running tasks: %v: %w", l.running, ctx.Err())
+	}
+}
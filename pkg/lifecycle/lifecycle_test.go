@@ -0,0 +1,69 @@
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Shutdown 取消根 context，并等待已启动的任务处理完收尾工作后才返回
+func TestShutdownWaitsForRunningTasks(t *testing.T) {
+	life := lifecycle.New()
+
+	finished := make(chan struct{})
+	life.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		close(finished)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, life.Shutdown(ctx))
+	assert.Error(t, life.Context().Err())
+
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Shutdown returned before the task finished")
+	}
+}
+
+// 截止时间到达时返回错误，错误中只列出仍在运行的任务
+func TestShutdownTimeoutReportsRunningTasks(t *testing.T) {
+	life := lifecycle.New()
+
+	release := make(chan struct{})
+	defer close(release)
+	life.Go("stuck", func(ctx context.Context) {
+		<-release
+	})
+	life.Go("graceful", func(ctx context.Context) {
+		<-ctx.Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := life.Shutdown(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "stuck")
+	assert.NotContains(t, err.Error(), "graceful")
+}
+
+// Shutdown 开始后提交的任务在当前 goroutine 中执行，拿到的是已取消的 context
+func TestGoAfterShutdownRunsSynchronously(t *testing.T) {
+	life := lifecycle.New()
+	require.NoError(t, life.Shutdown(context.Background()))
+
+	ran := false
+	life.Go("late", func(ctx context.Context) {
+		ran = true
+		assert.Error(t, ctx.Err())
+	})
+	assert.True(t, ran)
+}
@@ -0,0 +1,148 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrSTARTTLSUnsupported SMTP 服务器不支持 STARTTLS，为避免明文传输密码与邮件内容，不降级发送
+var ErrSTARTTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+
+type Config struct {
+	Host     string
+	Port     int
+	Username string // 为空时不进行 AUTH
+	Password string
+	From     string
+	Timeout  time.Duration // 单封邮件从建立连接到发送完成的超时时间，默认 10 秒
+
+	// TLSConfig 自定义 STARTTLS 使用的 TLS 配置，为空时按 Host 校验服务器证书
+	TLSConfig *tls.Config
+}
+
+type Message struct {
+	To      []string
+	Subject string
+	Body    string // 纯文本正文
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type smtpMailer struct {
+	cfg Config
+}
+
+func New(cfg Config) Mailer {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &smtpMailer{
+		cfg: cfg,
+	}
+}
+
+// Send 连接 SMTP 服务器，升级为 STARTTLS 后发送一封邮件
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("mail has no recipient")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	// 整个会话共用一个截止时间，避免服务器无响应时阻塞
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("create smtp client: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); !ok {
+		return ErrSTARTTLSUnsupported
+	}
+	tlsConfig := m.cfg.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{ServerName: m.cfg.Host}
+	}
+	if err = c.StartTLS(tlsConfig); err != nil {
+		return fmt.Errorf("starttls: %w", err)
+	}
+
+	if m.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err = c.Mail(m.cfg.From); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, to := range msg.To {
+		if err = c.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp rcpt to %s: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err = w.Write(m.buildMessage(msg)); err != nil {
+		w.Close()
+		return fmt.Errorf("write mail body: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	return c.Quit()
+}
+
+// buildMessage 生成邮件内容，标题与正文均使用 UTF-8 + base64 编码，兼容中文
+func (m *smtpMailer) buildMessage(msg Message) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("From: " + m.cfg.From + "\r\n")
+	buf.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	// 按 RFC 2045 每行不超过 76 个字符
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,296 @@
+package mailer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// session 记录本地 SMTP 桩服务器收到的一次会话
+type session struct {
+	tls      bool   // 是否升级为 TLS
+	authTLS  bool   // AUTH 是否在 TLS 之后
+	auth     string // AUTH PLAIN 解码后的内容
+	from     string
+	rcpt     []string
+	data     []byte
+	finished bool
+}
+
+type smtpStub struct {
+	ln        net.Listener
+	tlsConfig *tls.Config
+	starttls  bool            // 是否宣告 STARTTLS
+	reject    map[string]bool // RCPT 时拒绝的地址
+	sessions  chan *session
+}
+
+func newSMTPStub(t *testing.T, cert tls.Certificate, starttls bool, reject ...string) *smtpStub {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpStub{
+		ln:        ln,
+		tlsConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+		starttls:  starttls,
+		reject:    make(map[string]bool),
+		sessions:  make(chan *session, 1),
+	}
+	for _, r := range reject {
+		s.reject[r] = true
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpStub) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	sess := &session{}
+	defer func() {
+		conn.Close()
+		s.sessions <- sess
+	}()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 stub ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO":
+			lines := []string{"250-stub"}
+			if s.starttls && !sess.tls {
+				lines = append(lines, "250-STARTTLS")
+			}
+			if sess.tls {
+				lines = append(lines, "250-AUTH PLAIN")
+			}
+			lines = append(lines, "250 OK")
+			for _, l := range lines {
+				_ = tp.PrintfLine("%s", l)
+			}
+		case "STARTTLS":
+			_ = tp.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			tp = textproto.NewConn(conn)
+			sess.tls = true
+		case "AUTH":
+			_, payload, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(payload)
+			sess.auth = string(decoded)
+			sess.authTLS = sess.tls
+			_ = tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			sess.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			to := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if s.reject[to] {
+				_ = tp.PrintfLine("550 mailbox unavailable")
+				continue
+			}
+			sess.rcpt = append(sess.rcpt, to)
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 end with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			sess.data = data
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			sess.finished = true
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+// selfSignedCert 生成 127.0.0.1 的自签名证书
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "smtp stub"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+
+	tests := []struct {
+		name     string
+		starttls bool
+		reject   []string
+		msg      mailer.Message
+		wantErr  func(t *testing.T, err error)
+		check    func(t *testing.T, sess *session)
+	}{
+		{
+			name:     "send over starttls",
+			starttls: true,
+			msg: mailer.Message{
+				To:      []string{"student@example.com"},
+				Subject: "反馈处理完成提醒",
+				Body:    "您的问题已经处理完成，点击查看详情：https://example.com/share",
+			},
+			check: func(t *testing.T, sess *session) {
+				assert.True(t, sess.tls)
+				assert.True(t, sess.authTLS, "AUTH must happen after STARTTLS")
+				assert.Equal(t, "\x00feedback@example.com\x00secret", sess.auth)
+				assert.Equal(t, "feedback@example.com", sess.from)
+				assert.Equal(t, []string{"student@example.com"}, sess.rcpt)
+				assert.True(t, sess.finished)
+
+				m, err := mail.ReadMessage(strings.NewReader(string(sess.data)))
+				require.NoError(t, err)
+				subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+				require.NoError(t, err)
+				assert.Equal(t, "反馈处理完成提醒", subject)
+				assert.Equal(t, "student@example.com", m.Header.Get("To"))
+
+				body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, m.Body))
+				require.NoError(t, err)
+				assert.Equal(t, "您的问题已经处理完成，点击查看详情：https://example.com/share", string(body))
+			},
+		},
+		{
+			name:     "server without starttls",
+			starttls: false,
+			msg: mailer.Message{
+				To:      []string{"student@example.com"},
+				Subject: "subject",
+				Body:    "body",
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, mailer.ErrSTARTTLSUnsupported))
+			},
+			check: func(t *testing.T, sess *session) {
+				assert.False(t, sess.tls)
+				assert.Empty(t, sess.auth, "credentials must not be sent in plaintext")
+				assert.Nil(t, sess.data)
+			},
+		},
+		{
+			name:     "recipient rejected",
+			starttls: true,
+			reject:   []string{"nobody@example.com"},
+			msg: mailer.Message{
+				To:      []string{"nobody@example.com"},
+				Subject: "subject",
+				Body:    "body",
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.ErrorContains(t, err, "nobody@example.com")
+			},
+			check: func(t *testing.T, sess *session) {
+				assert.Empty(t, sess.rcpt)
+				assert.Nil(t, sess.data)
+			},
+		},
+		{
+			name:     "no recipient",
+			starttls: true,
+			msg: mailer.Message{
+				Subject: "subject",
+				Body:    "body",
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newSMTPStub(t, cert, tt.starttls, tt.reject...)
+
+			m := mailer.New(mailer.Config{
+				Host:      "127.0.0.1",
+				Port:      stub.port(),
+				Username:  "feedback@example.com",
+				Password:  "secret",
+				From:      "feedback@example.com",
+				Timeout:   5 * time.Second,
+				TLSConfig: &tls.Config{RootCAs: pool, ServerName: "127.0.0.1"},
+			})
+
+			err := m.Send(context.Background(), tt.msg)
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.check != nil {
+				select {
+				case sess := <-stub.sessions:
+					tt.check(t, sess)
+				case <-time.After(5 * time.Second):
+					t.Fatal("smtp session not finished")
+				}
+			}
+		})
+	}
+}
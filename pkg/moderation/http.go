@@ -0,0 +1,110 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DetectorHTTP = "http"
+
+	defaultHookTimeout = 3 * time.Second
+	maxHookResponse    = 1 << 20 // 审核服务响应体的大小上限
+)
+
+// HookRequest 发送给外部审核服务的请求体
+type HookRequest struct {
+	Text string `json:"text"`
+}
+
+// HookResponse 外部审核服务的响应体，matches 为命中的内容，text 为空表示整段文本都不通过
+type HookResponse struct {
+	Matches []HookMatch `json:"matches"`
+}
+
+type HookMatch struct {
+	Label string `json:"label"`
+	Text  string `json:"text"`
+}
+
+// httpDetector 调用外部审核服务检测文本，服务返回 200 以外的状态码时视为检测失败
+type httpDetector struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPDetector 创建调用外部审核服务的检测器，timeout 为 0 时使用默认的 3 秒
+func NewHTTPDetector(url string, timeout time.Duration) Detector {
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	return &httpDetector{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (d *httpDetector) Name() string {
+	return DetectorHTTP
+}
+
+func (d *httpDetector) Detect(ctx context.Context, text string) ([]Match, error) {
+	body, err := json.Marshal(HookRequest{Text: text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create moderation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send moderation request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHookResponse))
+	if err != nil {
+		return nil, fmt.Errorf("read moderation response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation hook returned %d: %s", resp.StatusCode, string(data))
+	}
+
+	var hookResp HookResponse
+	if err := json.Unmarshal(data, &hookResp); err != nil {
+		return nil, fmt.Errorf("decode moderation response: %w", err)
+	}
+
+	var matches []Match
+	for _, m := range hookResp.Matches {
+		label := m.Label
+		if label == "" {
+			label = DetectorHTTP
+		}
+		if m.Text == "" {
+			matches = append(matches, Match{Detector: DetectorHTTP, Label: label, Start: 0, End: len(text)})
+			continue
+		}
+		// 审核服务只返回命中的内容，在原文中查找全部出现的位置；找不到时忽略
+		for pos := 0; pos < len(text); {
+			i := strings.Index(text[pos:], m.Text)
+			if i < 0 {
+				break
+			}
+			matches = append(matches, Match{Detector: DetectorHTTP, Label: label, Start: pos + i, End: pos + i + len(m.Text)})
+			pos += i + len(m.Text)
+		}
+	}
+
+	return matches, nil
+}
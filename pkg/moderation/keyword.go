@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const DetectorKeyword = "keyword"
+
+// acNode Aho-Corasick 自动机的节点
+type acNode struct {
+	next map[rune]int
+	fail int
+	out  []int // 以该节点结尾的敏感词长度（字符数），包含沿失败指针可达的节点
+}
+
+// keywordDetector 基于 Aho-Corasick 自动机的敏感词检测，一次扫描即可匹配全部敏感词，忽略大小写
+type keywordDetector struct {
+	nodes []acNode
+}
+
+// NewKeywordDetector 根据敏感词词典构建检测器，空白的词会被忽略
+func NewKeywordDetector(words []string) Detector {
+	d := &keywordDetector{
+		nodes: []acNode{{next: make(map[rune]int)}},
+	}
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w != "" {
+			d.insert(w)
+		}
+	}
+	d.build()
+
+	return d
+}
+
+func (d *keywordDetector) insert(word string) {
+	cur := 0
+	n := 0
+	for _, r := range word {
+		r = unicode.ToLower(r)
+		nxt, ok := d.nodes[cur].next[r]
+		if !ok {
+			d.nodes = append(d.nodes, acNode{next: make(map[rune]int)})
+			nxt = len(d.nodes) - 1
+			d.nodes[cur].next[r] = nxt
+		}
+		cur = nxt
+		n++
+	}
+	d.nodes[cur].out = append(d.nodes[cur].out, n)
+}
+
+// build 按层次遍历计算失败指针，并把失败指针指向节点的输出合并到当前节点
+func (d *keywordDetector) build() {
+	queue := make([]int, 0, len(d.nodes))
+	for _, child := range d.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range d.nodes[cur].next {
+			f := d.nodes[cur].fail
+			for f != 0 {
+				if _, ok := d.nodes[f].next[r]; ok {
+					break
+				}
+				f = d.nodes[f].fail
+			}
+			if nxt, ok := d.nodes[f].next[r]; ok && nxt != child {
+				d.nodes[child].fail = nxt
+			}
+			d.nodes[child].out = append(d.nodes[child].out, d.nodes[d.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (d *keywordDetector) Name() string {
+	return DetectorKeyword
+}
+
+func (d *keywordDetector) Detect(_ context.Context, text string) ([]Match, error) {
+	var (
+		matches []Match
+		starts  []int // 已扫描的每个字符的起始字节偏移
+		cur     int
+	)
+	for i, r := range text {
+		starts = append(starts, i)
+		end := i + utf8.RuneLen(r)
+		r = unicode.ToLower(r)
+		for cur != 0 {
+			if _, ok := d.nodes[cur].next[r]; ok {
+				break
+			}
+			cur = d.nodes[cur].fail
+		}
+		if nxt, ok := d.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for _, n := range d.nodes[cur].out {
+			matches = append(matches, Match{
+				Detector: DetectorKeyword,
+				Label:    DetectorKeyword,
+				Start:    starts[len(starts)-n],
+				End:      end,
+			})
+		}
+	}
+
+	return matches, nil
+}
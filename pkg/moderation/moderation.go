@@ -0,0 +1,87 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Match 检测器在文本中命中的一段内容，Start、End 为字节偏移，文本[Start:End] 即命中的内容
+type Match struct {
+	Detector string
+	Label    string
+	Start    int
+	End      int
+}
+
+// Detector 内容检测器，返回文本中命中的全部位置
+type Detector interface {
+	Name() string
+	Detect(ctx context.Context, text string) ([]Match, error)
+}
+
+// Pipeline 依次执行多个检测器并汇总命中结果
+type Pipeline struct {
+	detectors []Detector
+}
+
+func NewPipeline(detectors ...Detector) *Pipeline {
+	return &Pipeline{
+		detectors: detectors,
+	}
+}
+
+// Len 检测器的数量
+func (p *Pipeline) Len() int {
+	return len(p.detectors)
+}
+
+// Detect 执行全部检测器，某个检测器出错时继续执行其余检测器，返回已得到的命中结果与合并后的错误
+func (p *Pipeline) Detect(ctx context.Context, text string) ([]Match, error) {
+	var (
+		matches []Match
+		errList []error
+	)
+	for _, d := range p.detectors {
+		m, err := d.Detect(ctx, text)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		matches = append(matches, m...)
+	}
+
+	return matches, errors.Join(errList...)
+}
+
+// Mask 将命中的内容逐字符替换为 mask，重叠的命中只替换一次
+func Mask(text string, matches []Match, mask string) string {
+	if len(matches) == 0 {
+		return text
+	}
+
+	sorted := make([]Match, len(matches))
+	copy(sorted, matches)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var (
+		b   strings.Builder
+		idx int
+	)
+	b.Grow(len(text))
+	for i, r := range text {
+		for idx < len(sorted) && sorted[idx].End <= i {
+			idx++
+		}
+		if idx < len(sorted) && sorted[idx].Start <= i {
+			b.WriteString(mask)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
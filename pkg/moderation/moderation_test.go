@@ -0,0 +1,122 @@
+package moderation_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hits 返回命中的内容，便于断言
+func hits(text string, matches []moderation.Match) []string {
+	ans := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ans = append(ans, text[m.Start:m.End])
+	}
+	return ans
+}
+
+func TestKeywordDetector(t *testing.T) {
+	d := moderation.NewKeywordDetector([]string{"he", "she", "hers", "his", "垃圾", "垃圾软件", " ", "SB"})
+
+	text := "ushers"
+	matches, err := d.Detect(context.Background(), text)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"she", "he", "hers"}, hits(text, matches))
+
+	// 中文与大小写混合，重叠的词都会命中
+	text = "这个垃圾软件真是sb"
+	matches, err = d.Detect(context.Background(), text)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"垃圾", "垃圾软件", "sb"}, hits(text, matches))
+	assert.Equal(t, "这个****真是**", moderation.Mask(text, matches, "*"))
+
+	matches, err = d.Detect(context.Background(), "一切正常")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestRegexDetectors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		detector moderation.Detector
+		text     string
+		expected []string
+	}{
+		{
+			name:     "phone",
+			detector: moderation.NewPhoneDetector(),
+			text:     "电话13800138000 或 +86 13912345678,13700000000",
+			expected: []string{"13800138000", "+86 13912345678", "13700000000"},
+		},
+		{
+			name:     "phone inside longer number",
+			detector: moderation.NewPhoneDetector(),
+			text:     "订单号 2023138001380001，学号 2021001234",
+			expected: []string{},
+		},
+		{
+			name:     "id card",
+			detector: moderation.NewIDCardDetector(),
+			text:     "身份证420102200001011234，另一个42010219991231567X。",
+			expected: []string{"420102200001011234", "42010219991231567X"},
+		},
+		{
+			name:     "invalid id card date",
+			detector: moderation.NewIDCardDetector(),
+			text:     "420102200013011234",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := tc.detector.Detect(context.Background(), tc.text)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, hits(tc.text, matches))
+		})
+	}
+}
+
+func TestHTTPDetector(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req moderation.HookRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		resp := moderation.HookResponse{}
+		switch req.Text {
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case "整段违规":
+			resp.Matches = []moderation.HookMatch{{Label: "abuse"}}
+		default:
+			resp.Matches = []moderation.HookMatch{{Label: "abuse", Text: "坏词"}, {Text: "不存在"}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer stub.Close()
+
+	d := moderation.NewHTTPDetector(stub.URL, 0)
+
+	text := "坏词和坏词"
+	matches, err := d.Detect(context.Background(), text)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"坏词", "坏词"}, hits(text, matches))
+	assert.Equal(t, "abuse", matches[0].Label)
+
+	matches, err = d.Detect(context.Background(), "整段违规")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"整段违规"}, hits("整段违规", matches))
+
+	// 审核服务出错时流水线继续执行其余检测器，并返回错误
+	p := moderation.NewPipeline(d, moderation.NewKeywordDetector([]string{"error"}))
+	matches, err = p.Detect(context.Background(), "error")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"error"}, hits("error", matches))
+}
@@ -0,0 +1,71 @@
+package moderation
+
+import (
+	"context"
+	"regexp"
+)
+
+const (
+	DetectorPhone  = "phone"   // 手机号
+	DetectorIDCard = "id_card" // 身份证号
+)
+
+var (
+	// 前后不能紧邻数字，避免把更长的数字串（如订单号）中的一段当作手机号
+	phoneRegexp  = regexp.MustCompile(`(?:^|[^0-9])((?:\+?86[- ]?)?1[3-9][0-9]{9})(?:[^0-9]|$)`)
+	idCardRegexp = regexp.MustCompile(`(?:^|[^0-9])([1-9][0-9]{5}(?:18|19|20)[0-9]{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12][0-9]|3[01])[0-9]{3}[0-9Xx])(?:[^0-9A-Za-z]|$)`)
+)
+
+// regexDetector 正则检测器，正则包含分组时以第一个分组作为命中的内容
+type regexDetector struct {
+	name string
+	re   *regexp.Regexp
+}
+
+func NewRegexDetector(name string, re *regexp.Regexp) Detector {
+	return &regexDetector{
+		name: name,
+		re:   re,
+	}
+}
+
+// NewPhoneDetector 检测中国大陆手机号
+func NewPhoneDetector() Detector {
+	return NewRegexDetector(DetectorPhone, phoneRegexp)
+}
+
+// NewIDCardDetector 检测 18 位居民身份证号
+func NewIDCardDetector() Detector {
+	return NewRegexDetector(DetectorIDCard, idCardRegexp)
+}
+
+func (d *regexDetector) Name() string {
+	return d.name
+}
+
+func (d *regexDetector) Detect(_ context.Context, text string) ([]Match, error) {
+	var matches []Match
+	// 逐次从上一个命中内容的结尾继续查找，边界字符不会被相邻的两次命中重复占用
+	for pos := 0; pos < len(text); {
+		loc := d.re.FindStringSubmatchIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		start, end := loc[0], loc[1]
+		if len(loc) >= 4 && loc[2] >= 0 {
+			start, end = loc[2], loc[3]
+		}
+		if end == start {
+			break
+		}
+		matches = append(matches, Match{
+			Detector: d.name,
+			Label:    d.name,
+			Start:    pos + start,
+			End:      pos + end,
+		})
+		pos += end
+	}
+
+	return matches, nil
+}
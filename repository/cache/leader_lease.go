@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const leaderLeaseKeyPrefix = "feedback:leader:" // 租约 key 前缀，后接租约名称
+
+var (
+	//go:embed scripts/acquire_lease.lua
+	acquireLeaseScriptSrc string
+
+	//go:embed scripts/release_lease.lua
+	releaseLeaseScriptSrc string
+)
+
+// LeaderLease 基于 Redis 的主节点租约，同一时间只有一个 holder 持有
+// 持有者需要在 ttl 内续期，否则租约过期后由其他实例抢占
+type LeaderLease interface {
+	// Acquire 抢占或续期租约，返回当前实例是否持有租约
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release 释放自己持有的租约，便于其他实例立即接管
+	Release(ctx context.Context, name, holder string) error
+}
+
+type leaderLease struct {
+	cache         redis.Cmdable
+	acquireScript *redis.Script
+	releaseScript *redis.Script
+}
+
+func NewLeaderLease(cache *redis.Client) LeaderLease {
+	return &leaderLease{
+		cache:         cache,
+		acquireScript: redis.NewScript(acquireLeaseScriptSrc),
+		releaseScript: redis.NewScript(releaseLeaseScriptSrc),
+	}
+}
+
+func (l *leaderLease) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	n, err := l.acquireScript.Run(ctx, l.cache, []string{leaderLeaseKeyPrefix + name}, holder, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (l *leaderLease) Release(ctx context.Context, name, holder string) error {
+	return l.releaseScript.Run(ctx, l.cache, []string{leaderLeaseKeyPrefix + name}, holder).Err()
+}
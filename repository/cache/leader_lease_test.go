@@ -0,0 +1,83 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLeaseTTL = 30 * time.Second
+
+// 同一时间只有一个持有者，持有者可以续期，其他实例在租约过期后才能抢占
+func TestLeaderLeaseAcquireAndRenew(t *testing.T) {
+	s, client := newRedis(t)
+	lease := cache.NewLeaderLease(client)
+	ctx := context.Background()
+
+	ok, err := lease.Acquire(ctx, "scheduler", "pod-a", testLeaseTTL)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-b", testLeaseTTL)
+	require.NoError(t, err)
+	assert.False(t, ok, "lease held by pod-a")
+
+	// 续期后重新计算有效期，原有效期过后仍由 pod-a 持有
+	s.FastForward(testLeaseTTL - time.Second)
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-a", testLeaseTTL)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	s.FastForward(2 * time.Second)
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-b", testLeaseTTL)
+	require.NoError(t, err)
+	assert.False(t, ok, "renewed lease should not expire yet")
+
+	// pod-a 停止续期，租约过期后由 pod-b 接管
+	s.FastForward(testLeaseTTL)
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-b", testLeaseTTL)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-a", testLeaseTTL)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// 只有持有者能释放租约，释放后其他实例无需等待过期
+func TestLeaderLeaseRelease(t *testing.T) {
+	_, client := newRedis(t)
+	lease := cache.NewLeaderLease(client)
+	ctx := context.Background()
+
+	ok, err := lease.Acquire(ctx, "scheduler", "pod-a", testLeaseTTL)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, lease.Release(ctx, "scheduler", "pod-b"))
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-b", testLeaseTTL)
+	require.NoError(t, err)
+	assert.False(t, ok, "release by non-holder must not free the lease")
+
+	require.NoError(t, lease.Release(ctx, "scheduler", "pod-a"))
+	ok, err = lease.Acquire(ctx, "scheduler", "pod-b", testLeaseTTL)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// 不同名称的租约互不影响
+func TestLeaderLeaseNames(t *testing.T) {
+	_, client := newRedis(t)
+	lease := cache.NewLeaderLease(client)
+	ctx := context.Background()
+
+	ok, err := lease.Acquire(ctx, "scheduler", "pod-a", testLeaseTTL)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = lease.Acquire(ctx, "other", "pod-b", testLeaseTTL)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/cache (interfaces: NoticeTrigger)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNoticeTrigger is a mock of NoticeTrigger interface.
+type MockNoticeTrigger struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoticeTriggerMockRecorder
+}
+
+// MockNoticeTriggerMockRecorder is the mock recorder for MockNoticeTrigger.
+type MockNoticeTriggerMockRecorder struct {
+	mock *MockNoticeTrigger
+}
+
+// NewMockNoticeTrigger creates a new mock instance.
+func NewMockNoticeTrigger(ctrl *gomock.Controller) *MockNoticeTrigger {
+	mock := &MockNoticeTrigger{ctrl: ctrl}
+	mock.recorder = &MockNoticeTriggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoticeTrigger) EXPECT() *MockNoticeTriggerMockRecorder {
+	return m.recorder
+}
+
+// PopTriggers mocks base method.
+func (m *MockNoticeTrigger) PopTriggers(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopTriggers", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopTriggers indicates an expected call of PopTriggers.
+func (mr *MockNoticeTriggerMockRecorder) PopTriggers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopTriggers", reflect.TypeOf((*MockNoticeTrigger)(nil).PopTriggers), arg0, arg1)
+}
+
+// Trigger mocks base method.
+func (m *MockNoticeTrigger) Trigger(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trigger indicates an expected call of Trigger.
+func (mr *MockNoticeTriggerMockRecorder) Trigger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockNoticeTrigger)(nil).Trigger), arg0, arg1)
+}
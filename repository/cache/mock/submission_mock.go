@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/cache (interfaces: SubmissionCache)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSubmissionCache is a mock of SubmissionCache interface.
+type MockSubmissionCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubmissionCacheMockRecorder
+}
+
+// MockSubmissionCacheMockRecorder is the mock recorder for MockSubmissionCache.
+type MockSubmissionCacheMockRecorder struct {
+	mock *MockSubmissionCache
+}
+
+// NewMockSubmissionCache creates a new mock instance.
+func NewMockSubmissionCache(ctrl *gomock.Controller) *MockSubmissionCache {
+	mock := &MockSubmissionCache{ctrl: ctrl}
+	mock.recorder = &MockSubmissionCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubmissionCache) EXPECT() *MockSubmissionCacheMockRecorder {
+	return m.recorder
+}
+
+// AddRecentSubmission mocks base method.
+func (m *MockSubmissionCache) AddRecentSubmission(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecentSubmission", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecentSubmission indicates an expected call of AddRecentSubmission.
+func (mr *MockSubmissionCacheMockRecorder) AddRecentSubmission(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecentSubmission", reflect.TypeOf((*MockSubmissionCache)(nil).AddRecentSubmission), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// GetRecentSubmissions mocks base method.
+func (m *MockSubmissionCache) GetRecentSubmissions(arg0 context.Context, arg1, arg2 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentSubmissions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentSubmissions indicates an expected call of GetRecentSubmissions.
+func (mr *MockSubmissionCacheMockRecorder) GetRecentSubmissions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentSubmissions", reflect.TypeOf((*MockSubmissionCache)(nil).GetRecentSubmissions), arg0, arg1, arg2)
+}
+
+// IncrSubmissionCount mocks base method.
+func (m *MockSubmissionCache) IncrSubmissionCount(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrSubmissionCount", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrSubmissionCount indicates an expected call of IncrSubmissionCount.
+func (mr *MockSubmissionCacheMockRecorder) IncrSubmissionCount(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrSubmissionCount", reflect.TypeOf((*MockSubmissionCache)(nil).IncrSubmissionCount), arg0, arg1, arg2, arg3)
+}
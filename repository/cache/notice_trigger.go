@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const noticeTriggerKey = "feedback:notice:trigger" // 待立即通知的表格标识集合
+
+// NoticeTrigger 手动触发的通知任务，任一实例收到触发请求时写入，由主节点上的通知消费者取出处理
+// 使用集合保存，同一表格在被取出前重复触发只处理一次
+//
+//go:generate mockgen -destination=./mock/notice_trigger_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/cache NoticeTrigger
+type NoticeTrigger interface {
+	// Trigger 登记需要立即通知的表格
+	Trigger(ctx context.Context, tableIdentify string) error
+	// PopTriggers 取出最多 count 个待通知的表格，取出后即从集合中移除
+	PopTriggers(ctx context.Context, count int64) ([]string, error)
+}
+
+type noticeTrigger struct {
+	cache redis.Cmdable
+}
+
+func NewNoticeTrigger(cache *redis.Client) NoticeTrigger {
+	return &noticeTrigger{
+		cache: cache,
+	}
+}
+
+func (n *noticeTrigger) Trigger(ctx context.Context, tableIdentify string) error {
+	return n.cache.SAdd(ctx, noticeTriggerKey, tableIdentify).Err()
+}
+
+func (n *noticeTrigger) PopTriggers(ctx context.Context, count int64) ([]string, error) {
+	return n.cache.SPopN(ctx, noticeTriggerKey, count).Result()
+}
@@ -0,0 +1,29 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 同一表格在取出前重复触发只保留一次，取出后从集合中移除
+func TestNoticeTrigger(t *testing.T) {
+	_, client := newRedis(t)
+	trigger := cache.NewNoticeTrigger(client)
+	ctx := context.Background()
+
+	require.NoError(t, trigger.Trigger(ctx, "table-a"))
+	require.NoError(t, trigger.Trigger(ctx, "table-a"))
+	require.NoError(t, trigger.Trigger(ctx, "table-b"))
+
+	ids, err := trigger.PopTriggers(ctx, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"table-a", "table-b"}, ids)
+
+	ids, err = trigger.PopTriggers(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
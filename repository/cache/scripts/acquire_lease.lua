@@ -0,0 +1,16 @@
+-- KEYS[1] = lease key
+-- ARGV[1] = holder
+-- ARGV[2] = ttl (ms)
+
+-- 已持有租约则续期
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+
+-- 租约空闲则抢占
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+
+return 0
@@ -0,0 +1,10 @@
+-- KEYS[1] = counter key
+-- ARGV[1] = ttl (ms)
+
+-- 首次计数时设置过期时间，形成固定窗口
+local n = redis.call("INCR", KEYS[1])
+if n == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+
+return n
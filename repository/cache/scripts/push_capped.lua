@@ -0,0 +1,12 @@
+-- KEYS[1] = stream key
+-- ARGV[1] = capacity
+-- ARGV[2] = payload field
+-- ARGV[3] = payload
+
+-- 在同一个脚本中检查容量并写入，并发写入时也不会超过上限
+if redis.call("XLEN", KEYS[1]) >= tonumber(ARGV[1]) then
+	return 0
+end
+
+redis.call("XADD", KEYS[1], "*", ARGV[2], ARGV[3])
+return 1
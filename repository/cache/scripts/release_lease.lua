@@ -0,0 +1,9 @@
+-- KEYS[1] = lease key
+-- ARGV[1] = holder
+
+-- 只释放自己持有的租约
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+
+return 0
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	submissionQuotaKeyPrefix  = "feedback:submission:quota:"  // 提交次数计数 key 前缀，后接 表格标识:学号
+	submissionRecentKeyPrefix = "feedback:submission:recent:" // 最近提交内容 key 前缀，后接 表格标识:学号
+)
+
+//go:embed scripts/incr_with_expire.lua
+var incrWithExpireScriptSrc string
+
+// SubmissionCache 记录学生的提交次数与最近提交的内容，用于限制提交频率和识别重复提交
+// 最近提交的内容在飞书记录创建后立即写入，不必等待 outbox 落库，可以拦截短时间内的连续提交
+//
+//go:generate mockgen -destination=./mock/submission_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/cache SubmissionCache
+type SubmissionCache interface {
+	// IncrSubmissionCount 提交次数加一并返回窗口内的累计次数，窗口从第一次提交开始计算
+	IncrSubmissionCount(ctx context.Context, tableIdentify, studentID string, window time.Duration) (int64, error)
+	// AddRecentSubmission 记录一次提交的内容，ttl 内没有新的提交时整体过期
+	AddRecentSubmission(ctx context.Context, tableIdentify, studentID, recordID, content string, ttl time.Duration) error
+	// GetRecentSubmissions 获取最近提交的内容，key 为记录 ID
+	GetRecentSubmissions(ctx context.Context, tableIdentify, studentID string) (map[string]string, error)
+}
+
+type submissionCache struct {
+	cache      redis.Cmdable
+	incrScript *redis.Script
+}
+
+func NewSubmissionCache(cache *redis.Client) SubmissionCache {
+	return &submissionCache{
+		cache:      cache,
+		incrScript: redis.NewScript(incrWithExpireScriptSrc),
+	}
+}
+
+func (s *submissionCache) IncrSubmissionCount(ctx context.Context, tableIdentify, studentID string, window time.Duration) (int64, error) {
+	return s.incrScript.Run(ctx, s.cache, []string{submissionQuotaKeyPrefix + tableIdentify + ":" + studentID}, window.Milliseconds()).Int64()
+}
+
+func (s *submissionCache) AddRecentSubmission(ctx context.Context, tableIdentify, studentID, recordID, content string, ttl time.Duration) error {
+	key := submissionRecentKeyPrefix + tableIdentify + ":" + studentID
+	_, err := s.cache.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, recordID, content)
+		pipe.PExpire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (s *submissionCache) GetRecentSubmissions(ctx context.Context, tableIdentify, studentID string) (map[string]string, error) {
+	return s.cache.HGetAll(ctx, submissionRecentKeyPrefix+tableIdentify+":"+studentID).Result()
+}
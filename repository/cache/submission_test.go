@@ -0,0 +1,64 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 提交次数在固定窗口内累计，过期时间只在第一次计数时设置，窗口结束后重新计数
+func TestSubmissionCountFixedWindow(t *testing.T) {
+	s, client := newRedis(t)
+	c := cache.NewSubmissionCache(client)
+	ctx := context.Background()
+	key := "feedback:submission:quota:mock-table:2023001"
+
+	for i := int64(1); i <= 3; i++ {
+		n, err := c.IncrSubmissionCount(ctx, "mock-table", "2023001", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, i, n)
+		// 后续计数不会延长窗口
+		s.FastForward(10 * time.Second)
+	}
+	assert.Equal(t, 30*time.Second, s.TTL(key))
+
+	// 其他学生、其他表格分别计数
+	n, err := c.IncrSubmissionCount(ctx, "mock-table", "2023002", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = c.IncrSubmissionCount(ctx, "other-table", "2023001", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	s.FastForward(30 * time.Second)
+	assert.False(t, s.Exists(key))
+	n, err = c.IncrSubmissionCount(ctx, "mock-table", "2023001", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, time.Minute, s.TTL(key))
+}
+
+// 最近提交的内容按记录 ID 保存，每次写入都会刷新整体的过期时间
+func TestRecentSubmissions(t *testing.T) {
+	s, client := newRedis(t)
+	c := cache.NewSubmissionCache(client)
+	ctx := context.Background()
+
+	require.NoError(t, c.AddRecentSubmission(ctx, "mock-table", "2023001", "rec-1", "课表打不开", time.Minute))
+	s.FastForward(50 * time.Second)
+	require.NoError(t, c.AddRecentSubmission(ctx, "mock-table", "2023001", "rec-2", "成绩查询失败", time.Minute))
+	s.FastForward(50 * time.Second)
+
+	got, err := c.GetRecentSubmissions(ctx, "mock-table", "2023001")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rec-1": "课表打不开", "rec-2": "成绩查询失败"}, got)
+
+	s.FastForward(time.Minute)
+	got, err = c.GetRecentSubmissions(ctx, "mock-table", "2023001")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	syncStreamKey     = "feedback:sync:stream" // 同步任务 Stream
+	syncGroupName     = "feedback-sync"        // 同步任务消费者组
+	syncPayloadField  = "payload"
+	syncQueueCapacity = 1000 // 队列中最多积压的消息数（含未确认消息）
+)
+
+var ErrSyncQueueFull = errors.New("sync queue is full")
+
+//go:embed scripts/push_capped.lua
+var pushCappedScriptSrc string
+
+// SyncQueueMessage 队列中的一条同步消息
+type SyncQueueMessage struct {
+	ID         string
+	Payload    []byte
+	RetryCount int64 // 已投递次数，仅重新认领的消息有值
+}
+
+// SyncQueue 持久化的同步任务队列，基于 Redis Stream 实现
+// 消息在 Ack 之前会一直保留在消费者组的 pending 列表中，进程崩溃后由其他消费者重新认领，保证至少一次投递
+type SyncQueue interface {
+	Push(ctx context.Context, payload []byte) error
+	Pop(ctx context.Context, consumer string, count int64, block time.Duration) ([]SyncQueueMessage, error)
+	Claim(ctx context.Context, consumer string, minIdle time.Duration, count int64) ([]SyncQueueMessage, error)
+	Ack(ctx context.Context, ids ...string) error
+}
+
+type syncQueue struct {
+	cache      *redis.Client
+	pushScript *redis.Script
+}
+
+// NewSyncQueue 创建同步队列并确保消费者组存在，Redis 不可用时返回错误
+func NewSyncQueue(cache *redis.Client) (SyncQueue, error) {
+	q := &syncQueue{
+		cache:      cache,
+		pushScript: redis.NewScript(pushCappedScriptSrc),
+	}
+	if err := q.ensureGroup(context.Background()); err != nil {
+		return nil, fmt.Errorf("create sync queue group: %w", err)
+	}
+	return q, nil
+}
+
+// Push 将消息写入队列，队列积压达到上限时返回 ErrSyncQueueFull
+// 不使用 XADD MAXLEN，裁剪会丢弃尚未确认的消息
+func (q *syncQueue) Push(ctx context.Context, payload []byte) error {
+	n, err := q.pushScript.Run(ctx, q.cache, []string{syncStreamKey}, syncQueueCapacity, syncPayloadField, payload).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSyncQueueFull
+	}
+	return nil
+}
+
+// Pop 读取尚未投递给任何消费者的新消息，block 时间内没有消息时返回空列表
+func (q *syncQueue) Pop(ctx context.Context, consumer string, count int64, block time.Duration) ([]SyncQueueMessage, error) {
+	res, err := q.cache.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    syncGroupName,
+		Consumer: consumer,
+		Streams:  []string{syncStreamKey, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		// Stream 被意外删除时重建消费者组
+		if strings.HasPrefix(err.Error(), "NOGROUP") {
+			return nil, q.ensureGroup(ctx)
+		}
+		return nil, err
+	}
+
+	var msgs []SyncQueueMessage
+	for _, stream := range res {
+		for _, m := range stream.Messages {
+			msgs = append(msgs, toSyncQueueMessage(m, 0))
+		}
+	}
+	return msgs, nil
+}
+
+// Claim 认领空闲超过 minIdle 仍未确认的消息（通常是消费者崩溃或处理失败留下的）
+func (q *syncQueue) Claim(ctx context.Context, consumer string, minIdle time.Duration, count int64) ([]SyncQueueMessage, error) {
+	pending, err := q.cache.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: syncStreamKey,
+		Group:  syncGroupName,
+		Idle:   minIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(pending))
+	retries := make(map[string]int64, len(pending))
+	for _, p := range pending {
+		ids = append(ids, p.ID)
+		retries[p.ID] = p.RetryCount
+	}
+
+	claimed, err := q.cache.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   syncStreamKey,
+		Group:    syncGroupName,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]SyncQueueMessage, 0, len(claimed))
+	for _, m := range claimed {
+		msgs = append(msgs, toSyncQueueMessage(m, retries[m.ID]))
+	}
+	return msgs, nil
+}
+
+// Ack 确认消息处理完成，并从 Stream 中删除，避免占用队列容量
+func (q *syncQueue) Ack(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	pipe := q.cache.TxPipeline()
+	pipe.XAck(ctx, syncStreamKey, syncGroupName, ids...)
+	pipe.XDel(ctx, syncStreamKey, ids...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (q *syncQueue) ensureGroup(ctx context.Context) error {
+	err := q.cache.XGroupCreateMkStream(ctx, syncStreamKey, syncGroupName, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+func toSyncQueueMessage(m redis.XMessage, retryCount int64) SyncQueueMessage {
+	var payload []byte
+	switch v := m.Values[syncPayloadField].(type) {
+	case string:
+		payload = []byte(v)
+	case []byte:
+		payload = v
+	}
+
+	return SyncQueueMessage{
+		ID:         m.ID,
+		Payload:    payload,
+		RetryCount: retryCount,
+	}
+}
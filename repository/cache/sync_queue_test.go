@@ -0,0 +1,79 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return s, client
+}
+
+func TestNewSyncQueueRedisUnavailable(t *testing.T) {
+	s, client := newRedis(t)
+	s.Close()
+
+	q, err := cache.NewSyncQueue(client)
+	assert.Error(t, err)
+	assert.Nil(t, q)
+}
+
+func TestSyncQueuePushCapacity(t *testing.T) {
+	_, client := newRedis(t)
+	q, err := cache.NewSyncQueue(client)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// 并发写入超过容量的消息，写入成功的数量不能超过上限
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		pushed  int
+		full    int
+		unknown []error
+	)
+	for i := 0; i < 1100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := q.Push(ctx, []byte(fmt.Sprintf("msg-%d", i)))
+			mu.Lock()
+			defer mu.Unlock()
+			switch err {
+			case nil:
+				pushed++
+			case cache.ErrSyncQueueFull:
+				full++
+			default:
+				unknown = append(unknown, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Empty(t, unknown)
+	assert.Equal(t, 1000, pushed)
+	assert.Equal(t, 100, full)
+
+	// 确认并删除后腾出容量
+	msgs, err := q.Pop(ctx, "consumer", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, msgs, 10)
+	ids := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+	require.NoError(t, q.Ack(ctx, ids...))
+	assert.NoError(t, q.Push(ctx, []byte("after-ack")))
+}
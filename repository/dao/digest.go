@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// closedProgresses 不再需要处理的进度，不计入待处理的统计
+var closedProgresses = []string{"已完成", "已撤回"}
+
+// ProgressCount 某个进度下的记录数
+type ProgressCount struct {
+	Progress string
+	Count    int64
+}
+
+//go:generate mockgen -destination=./mock/digest_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao DigestDAO
+type DigestDAO interface {
+	ClaimDigestRun(tableIdentify string, scheduledAt time.Time) (bool, error)
+	CountNewRecords(tableIdentify string, since, until time.Time) (int64, error)
+	CountPendingByProgress(tableIdentify string) ([]ProgressCount, error)
+	ListOldestPending(tableIdentify string, limit int) ([]*model.Sheet, error)
+	CountUpdatedVotes(tableIdentify string, since, until time.Time) (int64, int64, error)
+}
+
+type digestDAO struct {
+	db *gorm.DB
+}
+
+func NewDigestDAO(gorm *gorm.DB) DigestDAO {
+	return &digestDAO{
+		db: gorm,
+	}
+}
+
+// ClaimDigestRun 认领表格在 scheduledAt 这一次的摘要发送，已发送过该次或更晚一次时返回 false
+func (d *digestDAO) ClaimDigestRun(tableIdentify string, scheduledAt time.Time) (bool, error) {
+	m := &model.DigestRun{
+		TableIdentify:   &tableIdentify,
+		LastScheduledAt: scheduledAt,
+	}
+
+	// 新建时影响 1 行，推进时影响 2 行，未变化时为 0
+	res := d.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "table_identify"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_scheduled_at": gorm.Expr("GREATEST(last_scheduled_at, VALUES(last_scheduled_at))"),
+		}),
+	}).Create(m)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// CountNewRecords 统计表格在 [since, until) 内新增的记录数
+func (d *digestDAO) CountNewRecords(tableIdentify string, since, until time.Time) (int64, error) {
+	var total int64
+
+	err := d.db.
+		Model(&model.Sheet{}).
+		Where("table_identify = ? AND created_at >= ? AND created_at < ?", tableIdentify, since, until).
+		Count(&total).Error
+
+	return total, err
+}
+
+// CountPendingByProgress 按进度统计表格下未完成的记录数，已撤回的记录不计入
+func (d *digestDAO) CountPendingByProgress(tableIdentify string) ([]ProgressCount, error) {
+	var list []ProgressCount
+
+	err := d.db.
+		Model(&model.Sheet{}).
+		Select(progressExpr+" AS progress, COUNT(*) AS count").
+		Where("table_identify = ? AND "+progressExpr+" NOT IN ?", tableIdentify, closedProgresses).
+		Group("progress").
+		Order("count DESC").
+		Scan(&list).Error
+
+	return list, err
+}
+
+// ListOldestPending 获取表格下最早提交且未完成的记录，已撤回的记录不计入
+func (d *digestDAO) ListOldestPending(tableIdentify string, limit int) ([]*model.Sheet, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	var list []*model.Sheet
+
+	err := d.db.
+		Model(&model.Sheet{}).
+		Select([]string{"record_id", "record", "share_url", progressExpr + " AS progress", "created_at"}).
+		Where("table_identify = ? AND "+progressExpr+" NOT IN ?", tableIdentify, closedProgresses).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&list).Error
+
+	return list, err
+}
+
+// CountUpdatedVotes 统计 FAQ 表格在 [since, until) 内投出或改投的投票中，最新结果为已解决、未解决的票数
+func (d *digestDAO) CountUpdatedVotes(tableIdentify string, since, until time.Time) (int64, int64, error) {
+	var rows []struct {
+		IsResolved bool
+		Count      int64
+	}
+
+	err := d.db.
+		Model(&model.FAQResolution{}).
+		Select("is_resolved, COUNT(*) AS count").
+		Where("table_identify = ? AND is_resolved IS NOT NULL AND updated_at >= ? AND updated_at < ?", tableIdentify, since, until).
+		Group("is_resolved").
+		Scan(&rows).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var resolved, unresolved int64
+	for _, r := range rows {
+		if r.IsResolved {
+			resolved = r.Count
+		} else {
+			unresolved = r.Count
+		}
+	}
+	return resolved, unresolved, nil
+}
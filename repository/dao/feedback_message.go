@@ -0,0 +1,139 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=./mock/feedback_message_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao FeedbackMessageDAO
+type FeedbackMessageDAO interface {
+	CreateMessage(m *model.FeedbackMessage) (bool, error)
+	ListMessages(tableIdentify, recordID string, lastID *uint64, limit int) ([]*model.FeedbackMessage, bool, error)
+	GetThread(tableIdentify, recordID string) ([]*model.FeedbackMessage, error)
+	GetLatestMessage(tableIdentify, recordID, source string) (*model.FeedbackMessage, error)
+	ListUnNoticedMessages(tableIdentify, sender string, maxAttempts int) ([]*model.FeedbackMessage, error)
+	MarkMessageNoticed(id uint64) error
+	IncrMessageNoticeAttempts(id uint64) error
+}
+
+type feedbackMessageDAO struct {
+	db *gorm.DB
+}
+
+func NewFeedbackMessageDAO(gorm *gorm.DB) FeedbackMessageDAO {
+	return &feedbackMessageDAO{
+		db: gorm,
+	}
+}
+
+// CreateMessage 保存留言，source_key 相同的留言已存在时忽略，返回是否新写入
+func (f *feedbackMessageDAO) CreateMessage(m *model.FeedbackMessage) (bool, error) {
+	if m == nil {
+		return false, errors.New("message is nil")
+	}
+
+	if m.TableIdentify == nil || m.RecordID == nil || m.UserID == nil {
+		return false, errors.New("missing key fields")
+	}
+
+	res := f.db.Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// ListMessages 获取记录下的留言，按 ID 正序，支持分页（lastID + limit）
+func (f *feedbackMessageDAO) ListMessages(tableIdentify, recordID string, lastID *uint64, limit int) ([]*model.FeedbackMessage, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []*model.FeedbackMessage
+
+	query := f.db.
+		Model(&model.FeedbackMessage{}).
+		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID)
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id > ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id ASC").
+		Limit(limit + 1).
+		Find(&list).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(list) > limit {
+		hasMore = true
+		list = list[:limit]
+	}
+
+	return list, hasMore, nil
+}
+
+// GetThread 获取记录下的全部留言，按 ID 正序
+func (f *feedbackMessageDAO) GetThread(tableIdentify, recordID string) ([]*model.FeedbackMessage, error) {
+	var list []*model.FeedbackMessage
+
+	err := f.db.
+		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+		Order("id ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+// GetLatestMessage 获取记录下指定来源的最新一条留言，不存在时返回 gorm.ErrRecordNotFound
+func (f *feedbackMessageDAO) GetLatestMessage(tableIdentify, recordID, source string) (*model.FeedbackMessage, error) {
+	var m model.FeedbackMessage
+
+	err := f.db.
+		Where("table_identify = ? AND record_id = ? AND source = ?", tableIdentify, recordID, source).
+		Order("id DESC").
+		First(&m).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ListUnNoticedMessages 获取表格下尚未通知的留言，尝试次数达到 maxAttempts 的不再返回
+func (f *feedbackMessageDAO) ListUnNoticedMessages(tableIdentify, sender string, maxAttempts int) ([]*model.FeedbackMessage, error) {
+	var list []*model.FeedbackMessage
+
+	err := f.db.
+		Where("table_identify = ? AND is_noticed = 0 AND sender = ? AND notice_attempts < ?", tableIdentify, sender, maxAttempts).
+		Order("id ASC").
+		Limit(MaxLimit).
+		Find(&list).Error
+
+	return list, err
+}
+
+func (f *feedbackMessageDAO) MarkMessageNoticed(id uint64) error {
+	return f.db.
+		Model(&model.FeedbackMessage{}).
+		Where("id = ?", id).
+		Update("is_noticed", true).Error
+}
+
+func (f *feedbackMessageDAO) IncrMessageNoticeAttempts(id uint64) error {
+	return f.db.
+		Model(&model.FeedbackMessage{}).
+		Where("id = ?", id).
+		Update("notice_attempts", gorm.Expr("notice_attempts + 1")).Error
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: DigestDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dao "github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockDigestDAO is a mock of DigestDAO interface.
+type MockDigestDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestDAOMockRecorder
+}
+
+// MockDigestDAOMockRecorder is the mock recorder for MockDigestDAO.
+type MockDigestDAOMockRecorder struct {
+	mock *MockDigestDAO
+}
+
+// NewMockDigestDAO creates a new mock instance.
+func NewMockDigestDAO(ctrl *gomock.Controller) *MockDigestDAO {
+	mock := &MockDigestDAO{ctrl: ctrl}
+	mock.recorder = &MockDigestDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestDAO) EXPECT() *MockDigestDAOMockRecorder {
+	return m.recorder
+}
+
+// ClaimDigestRun mocks base method.
+func (m *MockDigestDAO) ClaimDigestRun(arg0 string, arg1 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDigestRun", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDigestRun indicates an expected call of ClaimDigestRun.
+func (mr *MockDigestDAOMockRecorder) ClaimDigestRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDigestRun", reflect.TypeOf((*MockDigestDAO)(nil).ClaimDigestRun), arg0, arg1)
+}
+
+// CountNewRecords mocks base method.
+func (m *MockDigestDAO) CountNewRecords(arg0 string, arg1, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNewRecords", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNewRecords indicates an expected call of CountNewRecords.
+func (mr *MockDigestDAOMockRecorder) CountNewRecords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNewRecords", reflect.TypeOf((*MockDigestDAO)(nil).CountNewRecords), arg0, arg1, arg2)
+}
+
+// CountPendingByProgress mocks base method.
+func (m *MockDigestDAO) CountPendingByProgress(arg0 string) ([]dao.ProgressCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingByProgress", arg0)
+	ret0, _ := ret[0].([]dao.ProgressCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingByProgress indicates an expected call of CountPendingByProgress.
+func (mr *MockDigestDAOMockRecorder) CountPendingByProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingByProgress", reflect.TypeOf((*MockDigestDAO)(nil).CountPendingByProgress), arg0)
+}
+
+// CountUpdatedVotes mocks base method.
+func (m *MockDigestDAO) CountUpdatedVotes(arg0 string, arg1, arg2 time.Time) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUpdatedVotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountUpdatedVotes indicates an expected call of CountUpdatedVotes.
+func (mr *MockDigestDAOMockRecorder) CountUpdatedVotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUpdatedVotes", reflect.TypeOf((*MockDigestDAO)(nil).CountUpdatedVotes), arg0, arg1, arg2)
+}
+
+// ListOldestPending mocks base method.
+func (m *MockDigestDAO) ListOldestPending(arg0 string, arg1 int) ([]*model.Sheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOldestPending", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Sheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOldestPending indicates an expected call of ListOldestPending.
+func (mr *MockDigestDAOMockRecorder) ListOldestPending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOldestPending", reflect.TypeOf((*MockDigestDAO)(nil).ListOldestPending), arg0, arg1)
+}
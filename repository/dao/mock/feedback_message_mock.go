@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: FeedbackMessageDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockFeedbackMessageDAO is a mock of FeedbackMessageDAO interface.
+type MockFeedbackMessageDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedbackMessageDAOMockRecorder
+}
+
+// MockFeedbackMessageDAOMockRecorder is the mock recorder for MockFeedbackMessageDAO.
+type MockFeedbackMessageDAOMockRecorder struct {
+	mock *MockFeedbackMessageDAO
+}
+
+// NewMockFeedbackMessageDAO creates a new mock instance.
+func NewMockFeedbackMessageDAO(ctrl *gomock.Controller) *MockFeedbackMessageDAO {
+	mock := &MockFeedbackMessageDAO{ctrl: ctrl}
+	mock.recorder = &MockFeedbackMessageDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedbackMessageDAO) EXPECT() *MockFeedbackMessageDAOMockRecorder {
+	return m.recorder
+}
+
+// CreateMessage mocks base method.
+func (m *MockFeedbackMessageDAO) CreateMessage(arg0 *model.FeedbackMessage) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockFeedbackMessageDAOMockRecorder) CreateMessage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).CreateMessage), arg0)
+}
+
+// GetLatestMessage mocks base method.
+func (m *MockFeedbackMessageDAO) GetLatestMessage(arg0, arg1, arg2 string) (*model.FeedbackMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.FeedbackMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestMessage indicates an expected call of GetLatestMessage.
+func (mr *MockFeedbackMessageDAOMockRecorder) GetLatestMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestMessage", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).GetLatestMessage), arg0, arg1, arg2)
+}
+
+// GetThread mocks base method.
+func (m *MockFeedbackMessageDAO) GetThread(arg0, arg1 string) ([]*model.FeedbackMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThread", arg0, arg1)
+	ret0, _ := ret[0].([]*model.FeedbackMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThread indicates an expected call of GetThread.
+func (mr *MockFeedbackMessageDAOMockRecorder) GetThread(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThread", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).GetThread), arg0, arg1)
+}
+
+// IncrMessageNoticeAttempts mocks base method.
+func (m *MockFeedbackMessageDAO) IncrMessageNoticeAttempts(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrMessageNoticeAttempts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrMessageNoticeAttempts indicates an expected call of IncrMessageNoticeAttempts.
+func (mr *MockFeedbackMessageDAOMockRecorder) IncrMessageNoticeAttempts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrMessageNoticeAttempts", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).IncrMessageNoticeAttempts), arg0)
+}
+
+// ListMessages mocks base method.
+func (m *MockFeedbackMessageDAO) ListMessages(arg0, arg1 string, arg2 *uint64, arg3 int) ([]*model.FeedbackMessage, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.FeedbackMessage)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockFeedbackMessageDAOMockRecorder) ListMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).ListMessages), arg0, arg1, arg2, arg3)
+}
+
+// ListUnNoticedMessages mocks base method.
+func (m *MockFeedbackMessageDAO) ListUnNoticedMessages(arg0, arg1 string, arg2 int) ([]*model.FeedbackMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnNoticedMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.FeedbackMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnNoticedMessages indicates an expected call of ListUnNoticedMessages.
+func (mr *MockFeedbackMessageDAOMockRecorder) ListUnNoticedMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnNoticedMessages", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).ListUnNoticedMessages), arg0, arg1, arg2)
+}
+
+// MarkMessageNoticed mocks base method.
+func (m *MockFeedbackMessageDAO) MarkMessageNoticed(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMessageNoticed", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMessageNoticed indicates an expected call of MarkMessageNoticed.
+func (mr *MockFeedbackMessageDAOMockRecorder) MarkMessageNoticed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMessageNoticed", reflect.TypeOf((*MockFeedbackMessageDAO)(nil).MarkMessageNoticed), arg0)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: NotificationDeliveryDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockNotificationDeliveryDAO is a mock of NotificationDeliveryDAO interface.
+type MockNotificationDeliveryDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationDeliveryDAOMockRecorder
+}
+
+// MockNotificationDeliveryDAOMockRecorder is the mock recorder for MockNotificationDeliveryDAO.
+type MockNotificationDeliveryDAOMockRecorder struct {
+	mock *MockNotificationDeliveryDAO
+}
+
+// NewMockNotificationDeliveryDAO creates a new mock instance.
+func NewMockNotificationDeliveryDAO(ctrl *gomock.Controller) *MockNotificationDeliveryDAO {
+	mock := &MockNotificationDeliveryDAO{ctrl: ctrl}
+	mock.recorder = &MockNotificationDeliveryDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationDeliveryDAO) EXPECT() *MockNotificationDeliveryDAOMockRecorder {
+	return m.recorder
+}
+
+// CreateDelivery mocks base method.
+func (m *MockNotificationDeliveryDAO) CreateDelivery(arg0 *model.NotificationDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockNotificationDeliveryDAOMockRecorder) CreateDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockNotificationDeliveryDAO)(nil).CreateDelivery), arg0)
+}
+
+// GetLatestDeliveries mocks base method.
+func (m *MockNotificationDeliveryDAO) GetLatestDeliveries(arg0 string, arg1 []string) ([]*model.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestDeliveries indicates an expected call of GetLatestDeliveries.
+func (mr *MockNotificationDeliveryDAOMockRecorder) GetLatestDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDeliveries", reflect.TypeOf((*MockNotificationDeliveryDAO)(nil).GetLatestDeliveries), arg0, arg1)
+}
+
+// GetLatestReplyDeliveries mocks base method.
+func (m *MockNotificationDeliveryDAO) GetLatestReplyDeliveries(arg0 []uint64) ([]*model.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestReplyDeliveries", arg0)
+	ret0, _ := ret[0].([]*model.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestReplyDeliveries indicates an expected call of GetLatestReplyDeliveries.
+func (mr *MockNotificationDeliveryDAOMockRecorder) GetLatestReplyDeliveries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestReplyDeliveries", reflect.TypeOf((*MockNotificationDeliveryDAO)(nil).GetLatestReplyDeliveries), arg0)
+}
+
+// ListFailedDeliveries mocks base method.
+func (m *MockNotificationDeliveryDAO) ListFailedDeliveries(arg0, arg1 string, arg2 *uint64, arg3 int) ([]*model.NotificationDelivery, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.NotificationDelivery)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFailedDeliveries indicates an expected call of ListFailedDeliveries.
+func (mr *MockNotificationDeliveryDAOMockRecorder) ListFailedDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedDeliveries", reflect.TypeOf((*MockNotificationDeliveryDAO)(nil).ListFailedDeliveries), arg0, arg1, arg2, arg3)
+}
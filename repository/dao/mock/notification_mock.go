@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: NotificationDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockNotificationDAO is a mock of NotificationDAO interface.
+type MockNotificationDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationDAOMockRecorder
+}
+
+// MockNotificationDAOMockRecorder is the mock recorder for MockNotificationDAO.
+type MockNotificationDAOMockRecorder struct {
+	mock *MockNotificationDAO
+}
+
+// NewMockNotificationDAO creates a new mock instance.
+func NewMockNotificationDAO(ctrl *gomock.Controller) *MockNotificationDAO {
+	mock := &MockNotificationDAO{ctrl: ctrl}
+	mock.recorder = &MockNotificationDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationDAO) EXPECT() *MockNotificationDAOMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockNotificationDAO) CountUnreadNotifications(arg0, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockNotificationDAOMockRecorder) CountUnreadNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockNotificationDAO)(nil).CountUnreadNotifications), arg0, arg1)
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationDAO) CreateNotification(arg0 *model.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationDAOMockRecorder) CreateNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationDAO)(nil).CreateNotification), arg0)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotificationDAO) ListNotifications(arg0, arg1 string, arg2 bool, arg3 *uint64, arg4 int) ([]*model.Notification, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationDAOMockRecorder) ListNotifications(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotificationDAO)(nil).ListNotifications), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MarkNotificationsRead mocks base method.
+func (m *MockNotificationDAO) MarkNotificationsRead(arg0, arg1 string, arg2 []uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockNotificationDAOMockRecorder) MarkNotificationsRead(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockNotificationDAO)(nil).MarkNotificationsRead), arg0, arg1, arg2)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: NotificationPreferenceDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockNotificationPreferenceDAO is a mock of NotificationPreferenceDAO interface.
+type MockNotificationPreferenceDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceDAOMockRecorder
+}
+
+// MockNotificationPreferenceDAOMockRecorder is the mock recorder for MockNotificationPreferenceDAO.
+type MockNotificationPreferenceDAOMockRecorder struct {
+	mock *MockNotificationPreferenceDAO
+}
+
+// NewMockNotificationPreferenceDAO creates a new mock instance.
+func NewMockNotificationPreferenceDAO(ctrl *gomock.Controller) *MockNotificationPreferenceDAO {
+	mock := &MockNotificationPreferenceDAO{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceDAO) EXPECT() *MockNotificationPreferenceDAOMockRecorder {
+	return m.recorder
+}
+
+// GetPreference mocks base method.
+func (m *MockNotificationPreferenceDAO) GetPreference(arg0, arg1 string) (*model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", arg0, arg1)
+	ret0, _ := ret[0].(*model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockNotificationPreferenceDAOMockRecorder) GetPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockNotificationPreferenceDAO)(nil).GetPreference), arg0, arg1)
+}
+
+// ListPreferences mocks base method.
+func (m *MockNotificationPreferenceDAO) ListPreferences(arg0 string, arg1 []string) ([]*model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPreferences", arg0, arg1)
+	ret0, _ := ret[0].([]*model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPreferences indicates an expected call of ListPreferences.
+func (mr *MockNotificationPreferenceDAOMockRecorder) ListPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPreferences", reflect.TypeOf((*MockNotificationPreferenceDAO)(nil).ListPreferences), arg0, arg1)
+}
+
+// UpsertPreference mocks base method.
+func (m *MockNotificationPreferenceDAO) UpsertPreference(arg0 *model.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPreference", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPreference indicates an expected call of UpsertPreference.
+func (mr *MockNotificationPreferenceDAOMockRecorder) UpsertPreference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPreference", reflect.TypeOf((*MockNotificationPreferenceDAO)(nil).UpsertPreference), arg0)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: OutboxDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockOutboxDAO is a mock of OutboxDAO interface.
+type MockOutboxDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxDAOMockRecorder
+}
+
+// MockOutboxDAOMockRecorder is the mock recorder for MockOutboxDAO.
+type MockOutboxDAOMockRecorder struct {
+	mock *MockOutboxDAO
+}
+
+// NewMockOutboxDAO creates a new mock instance.
+func NewMockOutboxDAO(ctrl *gomock.Controller) *MockOutboxDAO {
+	mock := &MockOutboxDAO{ctrl: ctrl}
+	mock.recorder = &MockOutboxDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxDAO) EXPECT() *MockOutboxDAOMockRecorder {
+	return m.recorder
+}
+
+// ClaimOutbox mocks base method.
+func (m *MockOutboxDAO) ClaimOutbox(arg0 uint64, arg1, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutbox", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutbox indicates an expected call of ClaimOutbox.
+func (mr *MockOutboxDAOMockRecorder) ClaimOutbox(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutbox", reflect.TypeOf((*MockOutboxDAO)(nil).ClaimOutbox), arg0, arg1, arg2)
+}
+
+// ConfirmOutbox mocks base method.
+func (m *MockOutboxDAO) ConfirmOutbox(arg0 uint64, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmOutbox", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmOutbox indicates an expected call of ConfirmOutbox.
+func (mr *MockOutboxDAOMockRecorder) ConfirmOutbox(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmOutbox", reflect.TypeOf((*MockOutboxDAO)(nil).ConfirmOutbox), arg0, arg1, arg2)
+}
+
+// CreateOutbox mocks base method.
+func (m *MockOutboxDAO) CreateOutbox(arg0 *model.RecordOutbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutbox", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOutbox indicates an expected call of CreateOutbox.
+func (mr *MockOutboxDAOMockRecorder) CreateOutbox(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutbox", reflect.TypeOf((*MockOutboxDAO)(nil).CreateOutbox), arg0)
+}
+
+// DeleteOutbox mocks base method.
+func (m *MockOutboxDAO) DeleteOutbox(arg0 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutbox", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOutbox indicates an expected call of DeleteOutbox.
+func (mr *MockOutboxDAOMockRecorder) DeleteOutbox(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutbox", reflect.TypeOf((*MockOutboxDAO)(nil).DeleteOutbox), arg0)
+}
+
+// GetDueOutboxes mocks base method.
+func (m *MockOutboxDAO) GetDueOutboxes(arg0 time.Time, arg1 int) ([]model.RecordOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueOutboxes", arg0, arg1)
+	ret0, _ := ret[0].([]model.RecordOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueOutboxes indicates an expected call of GetDueOutboxes.
+func (mr *MockOutboxDAOMockRecorder) GetDueOutboxes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueOutboxes", reflect.TypeOf((*MockOutboxDAO)(nil).GetDueOutboxes), arg0, arg1)
+}
+
+// UpdateOutbox mocks base method.
+func (m *MockOutboxDAO) UpdateOutbox(arg0 *model.RecordOutbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOutbox", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOutbox indicates an expected call of UpdateOutbox.
+func (mr *MockOutboxDAOMockRecorder) UpdateOutbox(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOutbox", reflect.TypeOf((*MockOutboxDAO)(nil).UpdateOutbox), arg0)
+}
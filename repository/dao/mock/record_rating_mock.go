@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: RecordRatingDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dao "github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockRecordRatingDAO is a mock of RecordRatingDAO interface.
+type MockRecordRatingDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordRatingDAOMockRecorder
+}
+
+// MockRecordRatingDAOMockRecorder is the mock recorder for MockRecordRatingDAO.
+type MockRecordRatingDAOMockRecorder struct {
+	mock *MockRecordRatingDAO
+}
+
+// NewMockRecordRatingDAO creates a new mock instance.
+func NewMockRecordRatingDAO(ctrl *gomock.Controller) *MockRecordRatingDAO {
+	mock := &MockRecordRatingDAO{ctrl: ctrl}
+	mock.recorder = &MockRecordRatingDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordRatingDAO) EXPECT() *MockRecordRatingDAOMockRecorder {
+	return m.recorder
+}
+
+// ClaimWriteBack mocks base method.
+func (m *MockRecordRatingDAO) ClaimWriteBack(arg0 uint64, arg1, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWriteBack", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWriteBack indicates an expected call of ClaimWriteBack.
+func (mr *MockRecordRatingDAOMockRecorder) ClaimWriteBack(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWriteBack", reflect.TypeOf((*MockRecordRatingDAO)(nil).ClaimWriteBack), arg0, arg1, arg2)
+}
+
+// CreateRating mocks base method.
+func (m *MockRecordRatingDAO) CreateRating(arg0 *model.RecordRating, arg1 []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRating", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRating indicates an expected call of CreateRating.
+func (mr *MockRecordRatingDAOMockRecorder) CreateRating(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRating", reflect.TypeOf((*MockRecordRatingDAO)(nil).CreateRating), arg0, arg1)
+}
+
+// GetDueWriteBacks mocks base method.
+func (m *MockRecordRatingDAO) GetDueWriteBacks(arg0 time.Time, arg1 int) ([]model.RecordRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueWriteBacks", arg0, arg1)
+	ret0, _ := ret[0].([]model.RecordRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueWriteBacks indicates an expected call of GetDueWriteBacks.
+func (mr *MockRecordRatingDAOMockRecorder) GetDueWriteBacks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueWriteBacks", reflect.TypeOf((*MockRecordRatingDAO)(nil).GetDueWriteBacks), arg0, arg1)
+}
+
+// GetRatingStats mocks base method.
+func (m *MockRecordRatingDAO) GetRatingStats(arg0 string) (dao.RatingStat, []dao.RatingStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingStats", arg0)
+	ret0, _ := ret[0].(dao.RatingStat)
+	ret1, _ := ret[1].([]dao.RatingStat)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRatingStats indicates an expected call of GetRatingStats.
+func (mr *MockRecordRatingDAOMockRecorder) GetRatingStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingStats", reflect.TypeOf((*MockRecordRatingDAO)(nil).GetRatingStats), arg0)
+}
+
+// UpdateWriteBack mocks base method.
+func (m *MockRecordRatingDAO) UpdateWriteBack(arg0 *model.RecordRating) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWriteBack", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWriteBack indicates an expected call of UpdateWriteBack.
+func (mr *MockRecordRatingDAOMockRecorder) UpdateWriteBack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWriteBack", reflect.TypeOf((*MockRecordRatingDAO)(nil).UpdateWriteBack), arg0)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: RecordRevisionDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockRecordRevisionDAO is a mock of RecordRevisionDAO interface.
+type MockRecordRevisionDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordRevisionDAOMockRecorder
+}
+
+// MockRecordRevisionDAOMockRecorder is the mock recorder for MockRecordRevisionDAO.
+type MockRecordRevisionDAOMockRecorder struct {
+	mock *MockRecordRevisionDAO
+}
+
+// NewMockRecordRevisionDAO creates a new mock instance.
+func NewMockRecordRevisionDAO(ctrl *gomock.Controller) *MockRecordRevisionDAO {
+	mock := &MockRecordRevisionDAO{ctrl: ctrl}
+	mock.recorder = &MockRecordRevisionDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordRevisionDAO) EXPECT() *MockRecordRevisionDAOMockRecorder {
+	return m.recorder
+}
+
+// CreateRevision mocks base method.
+func (m *MockRecordRevisionDAO) CreateRevision(arg0 *model.RecordRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevision", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRevision indicates an expected call of CreateRevision.
+func (mr *MockRecordRevisionDAOMockRecorder) CreateRevision(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevision", reflect.TypeOf((*MockRecordRevisionDAO)(nil).CreateRevision), arg0)
+}
+
+// UpdateRevisionStatus mocks base method.
+func (m *MockRecordRevisionDAO) UpdateRevisionStatus(arg0 uint64, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRevisionStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRevisionStatus indicates an expected call of UpdateRevisionStatus.
+func (mr *MockRecordRevisionDAOMockRecorder) UpdateRevisionStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRevisionStatus", reflect.TypeOf((*MockRecordRevisionDAO)(nil).UpdateRevisionStatus), arg0, arg1)
+}
@@ -0,0 +1,244 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: SheetDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockSheetDAO is a mock of SheetDAO interface.
+type MockSheetDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockSheetDAOMockRecorder
+}
+
+// MockSheetDAOMockRecorder is the mock recorder for MockSheetDAO.
+type MockSheetDAOMockRecorder struct {
+	mock *MockSheetDAO
+}
+
+// NewMockSheetDAO creates a new mock instance.
+func NewMockSheetDAO(ctrl *gomock.Controller) *MockSheetDAO {
+	mock := &MockSheetDAO{ctrl: ctrl}
+	mock.recorder = &MockSheetDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSheetDAO) EXPECT() *MockSheetDAOMockRecorder {
+	return m.recorder
+}
+
+// CountSheetRecordByUser mocks base method.
+func (m *MockSheetDAO) CountSheetRecordByUser(arg0, arg1 string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSheetRecordByUser", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSheetRecordByUser indicates an expected call of CountSheetRecordByUser.
+func (mr *MockSheetDAOMockRecorder) CountSheetRecordByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSheetRecordByUser", reflect.TypeOf((*MockSheetDAO)(nil).CountSheetRecordByUser), arg0, arg1)
+}
+
+// CreateOrUpdateSheetRecord mocks base method.
+func (m *MockSheetDAO) CreateOrUpdateSheetRecord(arg0 *model.Sheet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateSheetRecord", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateSheetRecord indicates an expected call of CreateOrUpdateSheetRecord.
+func (mr *MockSheetDAOMockRecorder) CreateOrUpdateSheetRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateSheetRecord", reflect.TypeOf((*MockSheetDAO)(nil).CreateOrUpdateSheetRecord), arg0)
+}
+
+// CreateSheetRecord mocks base method.
+func (m *MockSheetDAO) CreateSheetRecord(arg0 *model.Sheet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSheetRecord", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSheetRecord indicates an expected call of CreateSheetRecord.
+func (mr *MockSheetDAOMockRecorder) CreateSheetRecord(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSheetRecord", reflect.TypeOf((*MockSheetDAO)(nil).CreateSheetRecord), arg0)
+}
+
+// GetRecordSnapshots mocks base method.
+func (m *MockSheetDAO) GetRecordSnapshots(arg0 string, arg1 []string) (map[string]*model.Sheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecordSnapshots", arg0, arg1)
+	ret0, _ := ret[0].(map[string]*model.Sheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecordSnapshots indicates an expected call of GetRecordSnapshots.
+func (mr *MockSheetDAOMockRecorder) GetRecordSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecordSnapshots", reflect.TypeOf((*MockSheetDAO)(nil).GetRecordSnapshots), arg0, arg1)
+}
+
+// GetSheetRecord mocks base method.
+func (m *MockSheetDAO) GetSheetRecord(arg0, arg1 string) (*model.Sheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSheetRecord", arg0, arg1)
+	ret0, _ := ret[0].(*model.Sheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSheetRecord indicates an expected call of GetSheetRecord.
+func (mr *MockSheetDAOMockRecorder) GetSheetRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSheetRecord", reflect.TypeOf((*MockSheetDAO)(nil).GetSheetRecord), arg0, arg1)
+}
+
+// GetSheetRecordByRecordID mocks base method.
+func (m *MockSheetDAO) GetSheetRecordByRecordID(arg0, arg1, arg2 string) (*model.Sheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSheetRecordByRecordID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Sheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSheetRecordByRecordID indicates an expected call of GetSheetRecordByRecordID.
+func (mr *MockSheetDAOMockRecorder) GetSheetRecordByRecordID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSheetRecordByRecordID", reflect.TypeOf((*MockSheetDAO)(nil).GetSheetRecordByRecordID), arg0, arg1, arg2)
+}
+
+// GetSheetRecordByUser mocks base method.
+func (m *MockSheetDAO) GetSheetRecordByUser(arg0, arg1 string, arg2 *uint64, arg3 int) ([]*model.Sheet, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSheetRecordByUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Sheet)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSheetRecordByUser indicates an expected call of GetSheetRecordByUser.
+func (mr *MockSheetDAOMockRecorder) GetSheetRecordByUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSheetRecordByUser", reflect.TypeOf((*MockSheetDAO)(nil).GetSheetRecordByUser), arg0, arg1, arg2, arg3)
+}
+
+// GetUnNoticedRecordsByTable mocks base method.
+func (m *MockSheetDAO) GetUnNoticedRecordsByTable(arg0 string, arg1 []string) ([]model.Sheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnNoticedRecordsByTable", arg0, arg1)
+	ret0, _ := ret[0].([]model.Sheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnNoticedRecordsByTable indicates an expected call of GetUnNoticedRecordsByTable.
+func (mr *MockSheetDAOMockRecorder) GetUnNoticedRecordsByTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnNoticedRecordsByTable", reflect.TypeOf((*MockSheetDAO)(nil).GetUnNoticedRecordsByTable), arg0, arg1)
+}
+
+// GetUnsyncedRecordsByTable mocks base method.
+func (m *MockSheetDAO) GetUnsyncedRecordsByTable(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsyncedRecordsByTable", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnsyncedRecordsByTable indicates an expected call of GetUnsyncedRecordsByTable.
+func (mr *MockSheetDAOMockRecorder) GetUnsyncedRecordsByTable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsyncedRecordsByTable", reflect.TypeOf((*MockSheetDAO)(nil).GetUnsyncedRecordsByTable), arg0)
+}
+
+// ListRecentRecordsByUser mocks base method.
+func (m *MockSheetDAO) ListRecentRecordsByUser(arg0, arg1 string, arg2 time.Time, arg3 int) ([]*model.Sheet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentRecordsByUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Sheet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentRecordsByUser indicates an expected call of ListRecentRecordsByUser.
+func (mr *MockSheetDAOMockRecorder) ListRecentRecordsByUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentRecordsByUser", reflect.TypeOf((*MockSheetDAO)(nil).ListRecentRecordsByUser), arg0, arg1, arg2, arg3)
+}
+
+// ListRecordIDsByTable mocks base method.
+func (m *MockSheetDAO) ListRecordIDsByTable(arg0 string, arg1 time.Time, arg2 *uint64, arg3 int) ([]*model.Sheet, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecordIDsByTable", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Sheet)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRecordIDsByTable indicates an expected call of ListRecordIDsByTable.
+func (mr *MockSheetDAOMockRecorder) ListRecordIDsByTable(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecordIDsByTable", reflect.TypeOf((*MockSheetDAO)(nil).ListRecordIDsByTable), arg0, arg1, arg2, arg3)
+}
+
+// MarkRecordNoticed mocks base method.
+func (m *MockSheetDAO) MarkRecordNoticed(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRecordNoticed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRecordNoticed indicates an expected call of MarkRecordNoticed.
+func (mr *MockSheetDAOMockRecorder) MarkRecordNoticed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRecordNoticed", reflect.TypeOf((*MockSheetDAO)(nil).MarkRecordNoticed), arg0, arg1, arg2)
+}
+
+// ResetIsSyncedByUser mocks base method.
+func (m *MockSheetDAO) ResetIsSyncedByUser(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetIsSyncedByUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetIsSyncedByUser indicates an expected call of ResetIsSyncedByUser.
+func (mr *MockSheetDAOMockRecorder) ResetIsSyncedByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetIsSyncedByUser", reflect.TypeOf((*MockSheetDAO)(nil).ResetIsSyncedByUser), arg0, arg1)
+}
+
+// SoftDeleteSheetRecords mocks base method.
+func (m *MockSheetDAO) SoftDeleteSheetRecords(arg0 string, arg1 []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteSheetRecords", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteSheetRecords indicates an expected call of SoftDeleteSheetRecords.
+func (mr *MockSheetDAOMockRecorder) SoftDeleteSheetRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteSheetRecords", reflect.TypeOf((*MockSheetDAO)(nil).SoftDeleteSheetRecords), arg0, arg1)
+}
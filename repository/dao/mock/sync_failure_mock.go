@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: SyncFailureDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockSyncFailureDAO is a mock of SyncFailureDAO interface.
+type MockSyncFailureDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncFailureDAOMockRecorder
+}
+
+// MockSyncFailureDAOMockRecorder is the mock recorder for MockSyncFailureDAO.
+type MockSyncFailureDAOMockRecorder struct {
+	mock *MockSyncFailureDAO
+}
+
+// NewMockSyncFailureDAO creates a new mock instance.
+func NewMockSyncFailureDAO(ctrl *gomock.Controller) *MockSyncFailureDAO {
+	mock := &MockSyncFailureDAO{ctrl: ctrl}
+	mock.recorder = &MockSyncFailureDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncFailureDAO) EXPECT() *MockSyncFailureDAOMockRecorder {
+	return m.recorder
+}
+
+// ClearSyncFailures mocks base method.
+func (m *MockSyncFailureDAO) ClearSyncFailures(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSyncFailures", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearSyncFailures indicates an expected call of ClearSyncFailures.
+func (mr *MockSyncFailureDAOMockRecorder) ClearSyncFailures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSyncFailures", reflect.TypeOf((*MockSyncFailureDAO)(nil).ClearSyncFailures), arg0, arg1)
+}
+
+// DiscardSyncFailure mocks base method.
+func (m *MockSyncFailureDAO) DiscardSyncFailure(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardSyncFailure", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardSyncFailure indicates an expected call of DiscardSyncFailure.
+func (mr *MockSyncFailureDAOMockRecorder) DiscardSyncFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardSyncFailure", reflect.TypeOf((*MockSyncFailureDAO)(nil).DiscardSyncFailure), arg0, arg1)
+}
+
+// GetExcludedRecordIDs mocks base method.
+func (m *MockSyncFailureDAO) GetExcludedRecordIDs(arg0 string, arg1 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedRecordIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExcludedRecordIDs indicates an expected call of GetExcludedRecordIDs.
+func (mr *MockSyncFailureDAOMockRecorder) GetExcludedRecordIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedRecordIDs", reflect.TypeOf((*MockSyncFailureDAO)(nil).GetExcludedRecordIDs), arg0, arg1)
+}
+
+// GetSyncFailure mocks base method.
+func (m *MockSyncFailureDAO) GetSyncFailure(arg0, arg1 string) (*model.SyncFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncFailure", arg0, arg1)
+	ret0, _ := ret[0].(*model.SyncFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncFailure indicates an expected call of GetSyncFailure.
+func (mr *MockSyncFailureDAOMockRecorder) GetSyncFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncFailure", reflect.TypeOf((*MockSyncFailureDAO)(nil).GetSyncFailure), arg0, arg1)
+}
+
+// ListSyncFailures mocks base method.
+func (m *MockSyncFailureDAO) ListSyncFailures(arg0, arg1 string, arg2 *uint64, arg3 int) ([]*model.SyncFailure, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncFailures", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.SyncFailure)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSyncFailures indicates an expected call of ListSyncFailures.
+func (mr *MockSyncFailureDAOMockRecorder) ListSyncFailures(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncFailures", reflect.TypeOf((*MockSyncFailureDAO)(nil).ListSyncFailures), arg0, arg1, arg2, arg3)
+}
+
+// RecordSyncFailure mocks base method.
+func (m *MockSyncFailureDAO) RecordSyncFailure(arg0, arg1, arg2 string, arg3 int) (*model.SyncFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSyncFailure", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.SyncFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSyncFailure indicates an expected call of RecordSyncFailure.
+func (mr *MockSyncFailureDAOMockRecorder) RecordSyncFailure(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSyncFailure", reflect.TypeOf((*MockSyncFailureDAO)(nil).RecordSyncFailure), arg0, arg1, arg2, arg3)
+}
+
+// ResetSyncFailure mocks base method.
+func (m *MockSyncFailureDAO) ResetSyncFailure(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSyncFailure", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetSyncFailure indicates an expected call of ResetSyncFailure.
+func (mr *MockSyncFailureDAOMockRecorder) ResetSyncFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSyncFailure", reflect.TypeOf((*MockSyncFailureDAO)(nil).ResetSyncFailure), arg0, arg1)
+}
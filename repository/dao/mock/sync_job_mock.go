@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: SyncJobDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockSyncJobDAO is a mock of SyncJobDAO interface.
+type MockSyncJobDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncJobDAOMockRecorder
+}
+
+// MockSyncJobDAOMockRecorder is the mock recorder for MockSyncJobDAO.
+type MockSyncJobDAOMockRecorder struct {
+	mock *MockSyncJobDAO
+}
+
+// NewMockSyncJobDAO creates a new mock instance.
+func NewMockSyncJobDAO(ctrl *gomock.Controller) *MockSyncJobDAO {
+	mock := &MockSyncJobDAO{ctrl: ctrl}
+	mock.recorder = &MockSyncJobDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncJobDAO) EXPECT() *MockSyncJobDAOMockRecorder {
+	return m.recorder
+}
+
+// AddSyncJobBatchResult mocks base method.
+func (m *MockSyncJobDAO) AddSyncJobBatchResult(arg0 string, arg1, arg2, arg3, arg4 int, arg5 *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSyncJobBatchResult", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSyncJobBatchResult indicates an expected call of AddSyncJobBatchResult.
+func (mr *MockSyncJobDAOMockRecorder) AddSyncJobBatchResult(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSyncJobBatchResult", reflect.TypeOf((*MockSyncJobDAO)(nil).AddSyncJobBatchResult), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateSyncJob mocks base method.
+func (m *MockSyncJobDAO) CreateSyncJob(arg0 *model.SyncJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSyncJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSyncJob indicates an expected call of CreateSyncJob.
+func (mr *MockSyncJobDAOMockRecorder) CreateSyncJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSyncJob", reflect.TypeOf((*MockSyncJobDAO)(nil).CreateSyncJob), arg0)
+}
+
+// GetSyncJob mocks base method.
+func (m *MockSyncJobDAO) GetSyncJob(arg0, arg1 string) (*model.SyncJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncJob", arg0, arg1)
+	ret0, _ := ret[0].(*model.SyncJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncJob indicates an expected call of GetSyncJob.
+func (mr *MockSyncJobDAOMockRecorder) GetSyncJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncJob", reflect.TypeOf((*MockSyncJobDAO)(nil).GetSyncJob), arg0, arg1)
+}
+
+// ListSyncJobsByTable mocks base method.
+func (m *MockSyncJobDAO) ListSyncJobsByTable(arg0 string, arg1 *uint64, arg2 int) ([]*model.SyncJob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncJobsByTable", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.SyncJob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSyncJobsByTable indicates an expected call of ListSyncJobsByTable.
+func (mr *MockSyncJobDAOMockRecorder) ListSyncJobsByTable(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncJobsByTable", reflect.TypeOf((*MockSyncJobDAO)(nil).ListSyncJobsByTable), arg0, arg1, arg2)
+}
+
+// MarkSyncJobRunning mocks base method.
+func (m *MockSyncJobDAO) MarkSyncJobRunning(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSyncJobRunning", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSyncJobRunning indicates an expected call of MarkSyncJobRunning.
+func (mr *MockSyncJobDAOMockRecorder) MarkSyncJobRunning(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSyncJobRunning", reflect.TypeOf((*MockSyncJobDAO)(nil).MarkSyncJobRunning), arg0)
+}
+
+// SetSyncJobBatches mocks base method.
+func (m *MockSyncJobDAO) SetSyncJobBatches(arg0 string, arg1, arg2 int, arg3 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSyncJobBatches", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSyncJobBatches indicates an expected call of SetSyncJobBatches.
+func (mr *MockSyncJobDAOMockRecorder) SetSyncJobBatches(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncJobBatches", reflect.TypeOf((*MockSyncJobDAO)(nil).SetSyncJobBatches), arg0, arg1, arg2, arg3)
+}
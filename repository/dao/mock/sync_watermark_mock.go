@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: SyncWatermarkDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockSyncWatermarkDAO is a mock of SyncWatermarkDAO interface.
+type MockSyncWatermarkDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncWatermarkDAOMockRecorder
+}
+
+// MockSyncWatermarkDAOMockRecorder is the mock recorder for MockSyncWatermarkDAO.
+type MockSyncWatermarkDAOMockRecorder struct {
+	mock *MockSyncWatermarkDAO
+}
+
+// NewMockSyncWatermarkDAO creates a new mock instance.
+func NewMockSyncWatermarkDAO(ctrl *gomock.Controller) *MockSyncWatermarkDAO {
+	mock := &MockSyncWatermarkDAO{ctrl: ctrl}
+	mock.recorder = &MockSyncWatermarkDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncWatermarkDAO) EXPECT() *MockSyncWatermarkDAOMockRecorder {
+	return m.recorder
+}
+
+// GetWatermark mocks base method.
+func (m *MockSyncWatermarkDAO) GetWatermark(arg0 string) (*model.SyncWatermark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatermark", arg0)
+	ret0, _ := ret[0].(*model.SyncWatermark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatermark indicates an expected call of GetWatermark.
+func (mr *MockSyncWatermarkDAOMockRecorder) GetWatermark(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatermark", reflect.TypeOf((*MockSyncWatermarkDAO)(nil).GetWatermark), arg0)
+}
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/repository/dao (interfaces: WebhookDAO)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// MockWebhookDAO is a mock of WebhookDAO interface.
+type MockWebhookDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDAOMockRecorder
+}
+
+// MockWebhookDAOMockRecorder is the mock recorder for MockWebhookDAO.
+type MockWebhookDAOMockRecorder struct {
+	mock *MockWebhookDAO
+}
+
+// NewMockWebhookDAO creates a new mock instance.
+func NewMockWebhookDAO(ctrl *gomock.Controller) *MockWebhookDAO {
+	mock := &MockWebhookDAO{ctrl: ctrl}
+	mock.recorder = &MockWebhookDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDAO) EXPECT() *MockWebhookDAOMockRecorder {
+	return m.recorder
+}
+
+// ClaimDelivery mocks base method.
+func (m *MockWebhookDAO) ClaimDelivery(arg0 uint64, arg1, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDelivery indicates an expected call of ClaimDelivery.
+func (mr *MockWebhookDAOMockRecorder) ClaimDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDelivery", reflect.TypeOf((*MockWebhookDAO)(nil).ClaimDelivery), arg0, arg1, arg2)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockWebhookDAO) CreateDeliveries(arg0 []*model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockWebhookDAOMockRecorder) CreateDeliveries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockWebhookDAO)(nil).CreateDeliveries), arg0)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookDAO) CreateSubscription(arg0 *model.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookDAOMockRecorder) CreateSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookDAO)(nil).CreateSubscription), arg0)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookDAO) DeleteSubscription(arg0 string, arg1 uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookDAOMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookDAO)(nil).DeleteSubscription), arg0, arg1)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookDAO) GetDelivery(arg0 string, arg1 uint64) (*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", arg0, arg1)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookDAOMockRecorder) GetDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookDAO)(nil).GetDelivery), arg0, arg1)
+}
+
+// GetDueDeliveries mocks base method.
+func (m *MockWebhookDAO) GetDueDeliveries(arg0 time.Time, arg1 int) ([]model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueDeliveries indicates an expected call of GetDueDeliveries.
+func (mr *MockWebhookDAOMockRecorder) GetDueDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDeliveries", reflect.TypeOf((*MockWebhookDAO)(nil).GetDueDeliveries), arg0, arg1)
+}
+
+// GetSubscription mocks base method.
+func (m *MockWebhookDAO) GetSubscription(arg0 string, arg1 uint64) (*model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", arg0, arg1)
+	ret0, _ := ret[0].(*model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWebhookDAOMockRecorder) GetSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWebhookDAO)(nil).GetSubscription), arg0, arg1)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookDAO) ListDeliveries(arg0 string, arg1 uint64, arg2 string, arg3 *uint64, arg4 int) ([]*model.WebhookDelivery, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookDAOMockRecorder) ListDeliveries(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookDAO)(nil).ListDeliveries), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockWebhookDAO) ListSubscriptions(arg0 string) ([]*model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", arg0)
+	ret0, _ := ret[0].([]*model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockWebhookDAOMockRecorder) ListSubscriptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockWebhookDAO)(nil).ListSubscriptions), arg0)
+}
+
+// ResetDelivery mocks base method.
+func (m *MockWebhookDAO) ResetDelivery(arg0 string, arg1 uint64, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDelivery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetDelivery indicates an expected call of ResetDelivery.
+func (mr *MockWebhookDAOMockRecorder) ResetDelivery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDelivery", reflect.TypeOf((*MockWebhookDAO)(nil).ResetDelivery), arg0, arg1, arg2)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookDAO) UpdateDelivery(arg0 *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookDAOMockRecorder) UpdateDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookDAO)(nil).UpdateDelivery), arg0)
+}
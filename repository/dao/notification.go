@@ -0,0 +1,111 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=./mock/notification_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao NotificationDAO
+type NotificationDAO interface {
+	CreateNotification(m *model.Notification) error
+	ListNotifications(tableIdentify, userID string, unreadOnly bool, lastID *uint64, limit int) ([]*model.Notification, bool, error)
+	MarkNotificationsRead(tableIdentify, userID string, ids []uint64) (int64, error)
+	CountUnreadNotifications(tableIdentify, userID string) (int64, error)
+}
+
+type notificationDAO struct {
+	db *gorm.DB
+}
+
+func NewNotificationDAO(gorm *gorm.DB) NotificationDAO {
+	return &notificationDAO{
+		db: gorm,
+	}
+}
+
+// CreateNotification 写入站内通知，同一记录的同一次进度变化已存在时忽略
+func (n *notificationDAO) CreateNotification(m *model.Notification) error {
+	if m == nil {
+		return errors.New("notification is nil")
+	}
+
+	if m.TableIdentify == nil || m.UserID == nil || m.RecordID == nil || m.Progress == nil {
+		return errors.New("missing key fields")
+	}
+
+	return n.db.Clauses(clause.OnConflict{DoNothing: true}).Create(m).Error
+}
+
+// ListNotifications 获取学生在表格下的站内通知，按 ID 倒序，支持分页（lastID + limit）
+func (n *notificationDAO) ListNotifications(tableIdentify, userID string, unreadOnly bool, lastID *uint64, limit int) ([]*model.Notification, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []*model.Notification
+
+	query := n.db.
+		Model(&model.Notification{}).
+		Where("table_identify = ? AND user_id = ?", tableIdentify, userID)
+
+	if unreadOnly {
+		query = query.Where("is_read = 0")
+	}
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id < ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id DESC").
+		Limit(limit + 1).
+		Find(&list).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(list) > limit {
+		hasMore = true
+		list = list[:limit]
+	}
+
+	return list, hasMore, nil
+}
+
+// MarkNotificationsRead 将学生的站内通知标记为已读，ids 为空时标记全部，返回本次标记的条数
+func (n *notificationDAO) MarkNotificationsRead(tableIdentify, userID string, ids []uint64) (int64, error) {
+	query := n.db.
+		Model(&model.Notification{}).
+		Where("table_identify = ? AND user_id = ? AND is_read = 0", tableIdentify, userID)
+
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	res := query.Updates(map[string]interface{}{
+		"is_read": true,
+		"read_at": gorm.Expr("NOW(3)"),
+	})
+
+	return res.RowsAffected, res.Error
+}
+
+// CountUnreadNotifications 统计学生在表格下的未读站内通知数
+func (n *notificationDAO) CountUnreadNotifications(tableIdentify, userID string) (int64, error) {
+	var count int64
+
+	err := n.db.
+		Model(&model.Notification{}).
+		Where("table_identify = ? AND user_id = ? AND is_read = 0", tableIdentify, userID).
+		Count(&count).Error
+
+	return count, err
+}
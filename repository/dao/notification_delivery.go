@@ -0,0 +1,127 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=./mock/notification_delivery_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao NotificationDeliveryDAO
+type NotificationDeliveryDAO interface {
+	CreateDelivery(m *model.NotificationDelivery) error
+	GetLatestDeliveries(tableIdentify string, recordIDs []string) ([]*model.NotificationDelivery, error)
+	GetLatestReplyDeliveries(messageIDs []uint64) ([]*model.NotificationDelivery, error)
+	ListFailedDeliveries(tableIdentify, recordID string, lastID *uint64, limit int) ([]*model.NotificationDelivery, bool, error)
+}
+
+type notificationDeliveryDAO struct {
+	db *gorm.DB
+}
+
+func NewNotificationDeliveryDAO(gorm *gorm.DB) NotificationDeliveryDAO {
+	return &notificationDeliveryDAO{
+		db: gorm,
+	}
+}
+
+func (n *notificationDeliveryDAO) CreateDelivery(m *model.NotificationDelivery) error {
+	if m == nil {
+		return errors.New("delivery is nil")
+	}
+
+	if m.TableIdentify == nil || m.RecordID == nil || m.UserID == nil {
+		return errors.New("missing key fields")
+	}
+
+	return n.db.Create(m).Error
+}
+
+// GetLatestDeliveries 获取指定记录最近一次的进度通知投递记录（不区分渠道），从未投递过的记录不返回
+func (n *notificationDeliveryDAO) GetLatestDeliveries(tableIdentify string, recordIDs []string) ([]*model.NotificationDelivery, error) {
+	if len(recordIDs) == 0 {
+		return nil, nil
+	}
+
+	var list []*model.NotificationDelivery
+
+	latest := n.db.
+		Model(&model.NotificationDelivery{}).
+		Select("MAX(id)").
+		Where("table_identify = ? AND record_id IN ? AND message_id IS NULL", tableIdentify, recordIDs).
+		Group("record_id")
+
+	err := n.db.
+		Where("id IN (?)", latest).
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetLatestReplyDeliveries 获取指定留言最近一次的回复通知投递记录，从未投递过的留言不返回
+func (n *notificationDeliveryDAO) GetLatestReplyDeliveries(messageIDs []uint64) ([]*model.NotificationDelivery, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	var list []*model.NotificationDelivery
+
+	latest := n.db.
+		Model(&model.NotificationDelivery{}).
+		Select("MAX(id)").
+		Where("message_id IN ?", messageIDs).
+		Group("message_id")
+
+	err := n.db.
+		Where("id IN (?)", latest).
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ListFailedDeliveries 获取表格下投递失败的记录，recordID 为空时不过滤，按 ID 倒序，支持分页（lastID + limit）
+func (n *notificationDeliveryDAO) ListFailedDeliveries(tableIdentify, recordID string, lastID *uint64, limit int) ([]*model.NotificationDelivery, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []*model.NotificationDelivery
+
+	query := n.db.
+		Model(&model.NotificationDelivery{}).
+		Where("table_identify = ? AND success = 0", tableIdentify)
+
+	if recordID != "" {
+		query = query.Where("record_id = ?", recordID)
+	}
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id < ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id DESC").
+		Limit(limit + 1).
+		Find(&list).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(list) > limit {
+		hasMore = true
+		list = list[:limit]
+	}
+
+	return list, hasMore, nil
+}
@@ -0,0 +1,81 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=./mock/notification_preference_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao NotificationPreferenceDAO
+type NotificationPreferenceDAO interface {
+	GetPreference(tableIdentify, userID string) (*model.NotificationPreference, error)
+	ListPreferences(tableIdentify string, userIDs []string) ([]*model.NotificationPreference, error)
+	UpsertPreference(m *model.NotificationPreference) error
+}
+
+type notificationPreferenceDAO struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceDAO(gorm *gorm.DB) NotificationPreferenceDAO {
+	return &notificationPreferenceDAO{
+		db: gorm,
+	}
+}
+
+// GetPreference 获取学生在表格下的通知偏好，未设置时返回 nil
+func (n *notificationPreferenceDAO) GetPreference(tableIdentify, userID string) (*model.NotificationPreference, error) {
+	var m model.NotificationPreference
+
+	err := n.db.
+		Where("table_identify = ? AND user_id = ?", tableIdentify, userID).
+		Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ListPreferences 批量获取学生在表格下的通知偏好，未设置的学生不返回
+func (n *notificationPreferenceDAO) ListPreferences(tableIdentify string, userIDs []string) ([]*model.NotificationPreference, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var list []*model.NotificationPreference
+	err := n.db.
+		Where("table_identify = ? AND user_id IN ?", tableIdentify, userIDs).
+		Find(&list).Error
+
+	return list, err
+}
+
+// UpsertPreference 保存学生的通知偏好，存在时整体覆盖
+func (n *notificationPreferenceDAO) UpsertPreference(m *model.NotificationPreference) error {
+	if m == nil {
+		return errors.New("preference is nil")
+	}
+
+	if m.TableIdentify == nil || m.UserID == nil {
+		return errors.New("missing key fields")
+	}
+
+	return n.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "table_identify"},
+			{Name: "user_id"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"opt_out":     gorm.Expr("VALUES(opt_out)"),
+			"channels":    gorm.Expr("VALUES(channels)"),
+			"quiet_hours": gorm.Expr("VALUES(quiet_hours)"),
+			"updated_at":  gorm.Expr("NOW(3)"),
+		}),
+	}).Create(m).Error
+}
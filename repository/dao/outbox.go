@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=./mock/outbox_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao OutboxDAO
+type OutboxDAO interface {
+	CreateOutbox(m *model.RecordOutbox) error
+	GetDueOutboxes(now time.Time, limit int) ([]model.RecordOutbox, error)
+	ClaimOutbox(id uint64, nextRetryAt, leaseUntil time.Time) (bool, error)
+	UpdateOutbox(m *model.RecordOutbox) error
+	ConfirmOutbox(id uint64, recordID string, nextRetryAt time.Time) error
+	DeleteOutbox(id uint64) error
+}
+
+type outboxDAO struct {
+	db *gorm.DB
+}
+
+func NewOutboxDAO(gorm *gorm.DB) OutboxDAO {
+	return &outboxDAO{
+		db: gorm,
+	}
+}
+
+func (o *outboxDAO) CreateOutbox(m *model.RecordOutbox) error {
+	if m == nil {
+		return errors.New("outbox is nil")
+	}
+
+	if m.TableIdentify == nil || (m.RecordID == nil && m.ClientToken == nil) {
+		return errors.New("missing key fields")
+	}
+
+	return o.db.Create(m).Error
+}
+
+// GetDueOutboxes 获取到达重试时间且尚未完成的任务，按重试时间升序
+func (o *outboxDAO) GetDueOutboxes(now time.Time, limit int) ([]model.RecordOutbox, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []model.RecordOutbox
+	err := o.db.
+		Where("status = ? AND next_retry_at <= ?", model.OutboxStatusPending, now).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&list).Error
+
+	return list, err
+}
+
+// ClaimOutbox 以 next_retry_at 作为乐观锁认领任务，认领成功后在 leaseUntil 之前其他实例不会再处理
+// 处理过程中实例崩溃时，租约到期后任务会被重新认领
+func (o *outboxDAO) ClaimOutbox(id uint64, nextRetryAt, leaseUntil time.Time) (bool, error) {
+	res := o.db.Model(&model.RecordOutbox{}).
+		Where("id = ? AND status = ? AND next_retry_at = ?", id, model.OutboxStatusPending, nextRetryAt).
+		Update("next_retry_at", leaseUntil)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+// UpdateOutbox 保存任务的步骤状态与重试信息
+func (o *outboxDAO) UpdateOutbox(m *model.RecordOutbox) error {
+	if m == nil {
+		return errors.New("outbox is nil")
+	}
+
+	return o.db.Model(&model.RecordOutbox{}).
+		Where("id = ?", m.ID).
+		Select("record_id", "record", "share_url", "pending_receivers", "fetched", "persisted", "notified", "status", "attempts", "next_retry_at", "last_error").
+		Updates(m).Error
+}
+
+// ConfirmOutbox 飞书记录创建成功后写入记录 ID，并将任务提前到 nextRetryAt 处理
+// 后台已经补建过的任务不会被覆盖
+func (o *outboxDAO) ConfirmOutbox(id uint64, recordID string, nextRetryAt time.Time) error {
+	return o.db.Model(&model.RecordOutbox{}).
+		Where("id = ? AND record_id IS NULL", id).
+		Updates(map[string]any{
+			"record_id":     recordID,
+			"next_retry_at": nextRetryAt,
+		}).Error
+}
+
+// DeleteOutbox 删除任务，用于飞书明确拒绝创建记录的情况
+func (o *outboxDAO) DeleteOutbox(id uint64) error {
+	return o.db.Delete(&model.RecordOutbox{}, id).Error
+}
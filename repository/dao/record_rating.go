@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RatingStat 评价统计，Handler 为空时表示整张表格
+type RatingStat struct {
+	Handler string
+	Count   int64
+	Average float64
+}
+
+//go:generate mockgen -destination=./mock/record_rating_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao RecordRatingDAO
+type RecordRatingDAO interface {
+	CreateRating(m *model.RecordRating, handlers []string) (bool, error)
+	GetRatingStats(tableIdentify string) (RatingStat, []RatingStat, error)
+	GetDueWriteBacks(now time.Time, limit int) ([]model.RecordRating, error)
+	ClaimWriteBack(id uint64, nextRetryAt, leaseUntil time.Time) (bool, error)
+	UpdateWriteBack(m *model.RecordRating) error
+}
+
+type recordRatingDAO struct {
+	db *gorm.DB
+}
+
+func NewRecordRatingDAO(gorm *gorm.DB) RecordRatingDAO {
+	return &recordRatingDAO{
+		db: gorm,
+	}
+}
+
+// CreateRating 保存评价及其处理人，记录已评价过时不覆盖，返回是否新写入
+func (r *recordRatingDAO) CreateRating(m *model.RecordRating, handlers []string) (bool, error) {
+	if m == nil {
+		return false, errors.New("rating is nil")
+	}
+
+	if m.TableIdentify == nil || m.RecordID == nil || m.UserID == nil {
+		return false, errors.New("missing key fields")
+	}
+
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+
+		if len(handlers) == 0 {
+			return nil
+		}
+		rows := make([]model.RecordRatingHandler, 0, len(handlers))
+		for _, h := range handlers {
+			rows = append(rows, model.RecordRatingHandler{
+				RatingID:      m.ID,
+				TableIdentify: m.TableIdentify,
+				Handler:       h,
+			})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+
+	return created, err
+}
+
+// GetRatingStats 统计表格的评价数与平均分，同时按处理人分组统计
+// 有多个处理人的评价计入每个处理人，没有处理人的评价只计入表格统计
+func (r *recordRatingDAO) GetRatingStats(tableIdentify string) (RatingStat, []RatingStat, error) {
+	var total RatingStat
+	err := r.db.
+		Model(&model.RecordRating{}).
+		Select("COUNT(*) AS count, COALESCE(AVG(score), 0) AS average").
+		Where("table_identify = ?", tableIdentify).
+		Scan(&total).Error
+	if err != nil {
+		return total, nil, err
+	}
+
+	var handlers []RatingStat
+	err = r.db.
+		Table("record_rating_handler AS h").
+		Select("h.handler AS handler, COUNT(*) AS count, AVG(r.score) AS average").
+		Joins("JOIN record_rating AS r ON r.id = h.rating_id").
+		Where("h.table_identify = ?", tableIdentify).
+		Group("h.handler").
+		Order("count DESC").
+		Scan(&handlers).Error
+
+	return total, handlers, err
+}
+
+// GetDueWriteBacks 获取到达重试时间且尚未回写到飞书的评价，按重试时间升序
+func (r *recordRatingDAO) GetDueWriteBacks(now time.Time, limit int) ([]model.RecordRating, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []model.RecordRating
+	err := r.db.
+		Where("write_back_status = ? AND next_retry_at <= ?", model.OutboxStatusPending, now).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&list).Error
+
+	return list, err
+}
+
+// ClaimWriteBack 以 next_retry_at 作为乐观锁认领回写任务，认领成功后在 leaseUntil 之前其他实例不会再处理
+func (r *recordRatingDAO) ClaimWriteBack(id uint64, nextRetryAt, leaseUntil time.Time) (bool, error) {
+	res := r.db.Model(&model.RecordRating{}).
+		Where("id = ? AND write_back_status = ? AND next_retry_at = ?", id, model.OutboxStatusPending, nextRetryAt).
+		Update("next_retry_at", leaseUntil)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+// UpdateWriteBack 保存评价的回写状态与重试信息
+func (r *recordRatingDAO) UpdateWriteBack(m *model.RecordRating) error {
+	if m == nil {
+		return errors.New("rating is nil")
+	}
+
+	return r.db.Model(&model.RecordRating{}).
+		Where("id = ?", m.ID).
+		Select("write_back_status", "write_back_attempts", "next_retry_at", "last_error").
+		Updates(m).Error
+}
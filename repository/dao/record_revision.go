@@ -0,0 +1,43 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=./mock/record_revision_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao RecordRevisionDAO
+type RecordRevisionDAO interface {
+	CreateRevision(m *model.RecordRevision) error
+	UpdateRevisionStatus(id uint64, status string) error
+}
+
+type recordRevisionDAO struct {
+	db *gorm.DB
+}
+
+func NewRecordRevisionDAO(gorm *gorm.DB) RecordRevisionDAO {
+	return &recordRevisionDAO{
+		db: gorm,
+	}
+}
+
+func (r *recordRevisionDAO) CreateRevision(m *model.RecordRevision) error {
+	if m == nil {
+		return errors.New("revision is nil")
+	}
+
+	if m.TableIdentify == nil || m.RecordID == nil || m.UserID == nil {
+		return errors.New("missing key fields")
+	}
+
+	return r.db.Create(m).Error
+}
+
+func (r *recordRevisionDAO) UpdateRevisionStatus(id uint64, status string) error {
+	return r.db.
+		Model(&model.RecordRevision{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"errors"
+	"time"
 
 	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
 	"gorm.io/gorm"
@@ -13,18 +14,28 @@ const (
 	MaxLimit     = 100
 )
 
+//go:generate mockgen -destination=./mock/sheet_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao SheetDAO
 type SheetDAO interface {
 	CreateSheetRecord(m *model.Sheet) error
 	CreateOrUpdateSheetRecord(m *model.Sheet) error
 	CountSheetRecordByUser(tableIdentify, userID string) (uint64, error)
 	GetSheetRecordByUser(tableIdentify, userID string, lastID *uint64, limit int) ([]*model.Sheet, bool, error)
+	ListRecentRecordsByUser(tableIdentify, userID string, since time.Time, limit int) ([]*model.Sheet, error)
 	GetSheetRecordByRecordID(tableIdentify, userID, recordID string) (*model.Sheet, error)
+	GetSheetRecord(tableIdentify, recordID string) (*model.Sheet, error)
 	ResetIsSyncedByUser(tableIdentify, userID string) error
 	GetUnsyncedRecordsByTable(tableIdentify string) ([]string, error)
-	GetUnNoticedRecordsByTable(tableIdentify string) ([]model.Sheet, error)
-	MarkRecordNoticed(tableIdentify, recordID string) error
+	GetUnNoticedRecordsByTable(tableIdentify string, progresses []string) ([]model.Sheet, error)
+	MarkRecordNoticed(tableIdentify, recordID, progress string) error
+	ListRecordIDsByTable(tableIdentify string, createdBefore time.Time, lastID *uint64, limit int) ([]*model.Sheet, bool, error)
+	SoftDeleteSheetRecords(tableIdentify string, recordIDs []string) (int64, error)
+	GetRecordSnapshots(tableIdentify string, recordIDs []string) (map[string]*model.Sheet, error)
 }
 
+// progressExpr 记录的进度，新增 progress 字段前同步的记录没有进度，已完成同步的视为已完成
+// 启动时 InitTables 会补齐历史记录的进度，这里兜底迁移完成前的查询
+const progressExpr = "COALESCE(progress, IF(is_synced = 1, '已完成', ''))"
+
 type sheetDAO struct {
 	db *gorm.DB
 }
@@ -79,12 +90,17 @@ func (s *sheetDAO) CreateOrUpdateSheetRecord(m *model.Sheet) error {
 			{Name: "user_id"},
 			{Name: "record_id"},
 		},
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"record":     gorm.Expr("VALUES(record)"),
-			"share_url":  gorm.Expr("VALUES(share_url)"),
-			"is_synced":  gorm.Expr("VALUES(is_synced)"),
-			"updated_at": gorm.Expr("NOW(3)"),
-		}),
+		// MySQL 按顺序执行赋值，is_noticed 与 progress_version 需要在 progress 之前比较更新前的进度
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "is_noticed"}, Value: gorm.Expr("IF(" + progressExpr + " <=> VALUES(progress), is_noticed, 0)")}, // 进度变化时重新通知
+			{Column: clause.Column{Name: "progress_version"}, Value: gorm.Expr("IF(" + progressExpr + " <=> VALUES(progress), progress_version, progress_version + 1)")},
+			{Column: clause.Column{Name: "progress"}, Value: gorm.Expr("VALUES(progress)")},
+			{Column: clause.Column{Name: "record"}, Value: gorm.Expr("VALUES(record)")},
+			{Column: clause.Column{Name: "share_url"}, Value: gorm.Expr("VALUES(share_url)")},
+			{Column: clause.Column{Name: "is_synced"}, Value: gorm.Expr("VALUES(is_synced)")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("NOW(3)")},
+			{Column: clause.Column{Name: "deleted_at"}, Value: nil}, // 记录在飞书中恢复后取消软删除
+		},
 	}).Create(m).Error
 
 	return err
@@ -146,6 +162,28 @@ func (s *sheetDAO) GetSheetRecordByUser(tableIdentify, userID string, lastID *ui
 	return records, hasMore, nil
 }
 
+// ListRecentRecordsByUser 获取用户在 since 之后于该表格下创建的记录，按创建时间倒序，最多 limit 条
+func (s *sheetDAO) ListRecentRecordsByUser(tableIdentify, userID string, since time.Time, limit int) ([]*model.Sheet, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var records []*model.Sheet
+	err := s.db.
+		Where("table_identify = ? AND user_id = ? AND created_at >= ?", tableIdentify, userID, since).
+		Order("id DESC").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
 // GetSheetRecordByRecordID 根据 tableIdentify、userID 和 recordID 获取单条记录
 func (s *sheetDAO) GetSheetRecordByRecordID(tableIdentify, userID, recordID string) (*model.Sheet, error) {
 	var record model.Sheet
@@ -164,6 +202,21 @@ func (s *sheetDAO) GetSheetRecordByRecordID(tableIdentify, userID, recordID stri
 	return &record, nil
 }
 
+// GetSheetRecord 根据 tableIdentify 和 recordID 获取单条记录，不区分用户
+func (s *sheetDAO) GetSheetRecord(tableIdentify, recordID string) (*model.Sheet, error) {
+	var record model.Sheet
+
+	err := s.db.
+		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+		Take(&record).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
 // ResetIsSyncedByUser 重置指定用户在指定表格下的所有记录的 is_synced 字段为 0（未同步状态）
 func (s *sheetDAO) ResetIsSyncedByUser(tableIdentify, userID string) error {
 	if tableIdentify == "" || userID == "" {
@@ -192,14 +245,18 @@ func (s *sheetDAO) GetUnsyncedRecordsByTable(tableIdentify string) ([]string, er
 	return recordIDs, nil
 }
 
-// GetUnNoticedRecordsByTable 获取指定表格下所有未通知的记录ID列表
-func (s *sheetDAO) GetUnNoticedRecordsByTable(tableIdentify string) ([]model.Sheet, error) {
+// GetUnNoticedRecordsByTable 获取指定表格下进度在 progresses 中且未通知的记录，包含记录内容、分享链接、进度与进度版本
+func (s *sheetDAO) GetUnNoticedRecordsByTable(tableIdentify string, progresses []string) ([]model.Sheet, error) {
+	if len(progresses) == 0 {
+		return nil, nil
+	}
+
 	var records []model.Sheet
 
 	err := s.db.
 		Model(&model.Sheet{}).
-		Select([]string{"record_id", "user_id"}).
-		Where("table_identify = ? AND is_synced = 1 AND is_noticed = 0", tableIdentify).
+		Select([]string{"record_id", "user_id", "record", "share_url", progressExpr + " AS progress", "progress_version"}).
+		Where("table_identify = ? AND is_noticed = 0 AND "+progressExpr+" IN ?", tableIdentify, progresses).
 		Find(&records).Error
 
 	if err != nil {
@@ -210,8 +267,80 @@ func (s *sheetDAO) GetUnNoticedRecordsByTable(tableIdentify string) ([]model.She
 }
 
 // MarkRecordNoticed 将指定表格下的特定记录的 is_noticed 字段更新为 1（已通知状态）
-func (s *sheetDAO) MarkRecordNoticed(tableIdentify, recordID string) error {
+// 只在记录仍处于已通知的进度时更新，通知期间进度再次变化的记录保持未通知
+func (s *sheetDAO) MarkRecordNoticed(tableIdentify, recordID, progress string) error {
 	return s.db.Model(&model.Sheet{}).
-		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+		Where("table_identify = ? AND record_id = ? AND "+progressExpr+" = ?", tableIdentify, recordID, progress).
 		Update("is_noticed", 1).Error
 }
+
+// ListRecordIDsByTable 获取指定表格下在 createdBefore 之前创建的记录 ID（不区分用户），按 ID 倒序，支持分页（lastID + limit）
+func (s *sheetDAO) ListRecordIDsByTable(tableIdentify string, createdBefore time.Time, lastID *uint64, limit int) ([]*model.Sheet, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	var records []*model.Sheet
+
+	query := s.db.
+		Model(&model.Sheet{}).
+		Select([]string{"id", "record_id"}).
+		Where("table_identify = ? AND created_at < ?", tableIdentify, createdBefore)
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id < ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id DESC").
+		Limit(limit + 1).
+		Find(&records).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(records) > limit {
+		hasMore = true
+		records = records[:limit]
+	}
+
+	return records, hasMore, nil
+}
+
+// SoftDeleteSheetRecords 软删除指定表格下的记录，返回实际删除的记录数
+func (s *sheetDAO) SoftDeleteSheetRecords(tableIdentify string, recordIDs []string) (int64, error) {
+	if len(recordIDs) == 0 {
+		return 0, nil
+	}
+
+	res := s.db.
+		Where("table_identify = ? AND record_id IN ?", tableIdentify, recordIDs).
+		Delete(&model.Sheet{})
+
+	return res.RowsAffected, res.Error
+}
+
+// GetRecordSnapshots 获取指定记录当前保存的内容、分享链接与进度，key 为记录 ID，数据库中不存在的记录不返回
+// 同步时与飞书中的最新内容比较，判断记录是否真的发生了变化
+func (s *sheetDAO) GetRecordSnapshots(tableIdentify string, recordIDs []string) (map[string]*model.Sheet, error) {
+	if len(recordIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []*model.Sheet
+	err := s.db.
+		Select("record_id, record, share_url, "+progressExpr+" AS progress").
+		Where("table_identify = ? AND record_id IN ?", tableIdentify, recordIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*model.Sheet, len(rows))
+	for _, r := range rows {
+		res[*r.RecordID] = r
+	}
+	return res, nil
+}
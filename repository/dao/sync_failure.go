@@ -0,0 +1,190 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=./mock/sync_failure_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao SyncFailureDAO
+type SyncFailureDAO interface {
+	RecordSyncFailure(tableIdentify, recordID, lastError string, maxAttempts int) (*model.SyncFailure, error)
+	ClearSyncFailures(tableIdentify string, recordIDs []string) error
+	GetExcludedRecordIDs(tableIdentify string, recordIDs []string) ([]string, error)
+	GetSyncFailure(tableIdentify, recordID string) (*model.SyncFailure, error)
+	ListSyncFailures(tableIdentify, status string, lastID *uint64, limit int) ([]*model.SyncFailure, bool, error)
+	DiscardSyncFailure(tableIdentify, recordID string) (bool, error)
+	ResetSyncFailure(tableIdentify, recordID string) (bool, error)
+}
+
+type syncFailureDAO struct {
+	db *gorm.DB
+}
+
+func NewSyncFailureDAO(gorm *gorm.DB) SyncFailureDAO {
+	return &syncFailureDAO{
+		db: gorm,
+	}
+}
+
+// RecordSyncFailure 累加一次失败，失败次数达到 maxAttempts 时进入死信状态，返回更新后的记录
+// 已放弃的记录只更新错误信息
+func (s *syncFailureDAO) RecordSyncFailure(tableIdentify, recordID, lastError string, maxAttempts int) (*model.SyncFailure, error) {
+	var res model.SyncFailure
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		m := &model.SyncFailure{
+			TableIdentify: &tableIdentify,
+			RecordID:      &recordID,
+			Status:        model.SyncFailureStatusRetrying,
+			Attempts:      1,
+			LastError:     lastError,
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "table_identify"}, {Name: "record_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": lastError,
+				"updated_at": time.Now(),
+			}),
+		}).Create(m).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.SyncFailure{}).
+			Where("table_identify = ? AND record_id = ? AND status = ? AND attempts >= ?",
+				tableIdentify, recordID, model.SyncFailureStatusRetrying, maxAttempts).
+			Updates(map[string]any{
+				"status":  model.SyncFailureStatusDead,
+				"dead_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+			Take(&res).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ClearSyncFailures 记录同步成功后清除失败记录
+func (s *syncFailureDAO) ClearSyncFailures(tableIdentify string, recordIDs []string) error {
+	if len(recordIDs) == 0 {
+		return nil
+	}
+
+	return s.db.
+		Where("table_identify = ? AND record_id IN ?", tableIdentify, recordIDs).
+		Delete(&model.SyncFailure{}).Error
+}
+
+// GetExcludedRecordIDs 获取 recordIDs 中不再自动重试（死信或已放弃）的记录 ID
+// 所有自动同步路径（未同步、强制同步、增量同步、事件推送）入队前都经过该查询
+func (s *syncFailureDAO) GetExcludedRecordIDs(tableIdentify string, recordIDs []string) ([]string, error) {
+	var ids []string
+	if len(recordIDs) == 0 {
+		return ids, nil
+	}
+
+	err := s.db.Model(&model.SyncFailure{}).
+		Where("table_identify = ? AND record_id IN ? AND status IN ?", tableIdentify, recordIDs,
+			[]string{model.SyncFailureStatusDead, model.SyncFailureStatusDiscarded}).
+		Pluck("record_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GetSyncFailure 根据 tableIdentify 和 recordID 获取失败记录
+func (s *syncFailureDAO) GetSyncFailure(tableIdentify, recordID string) (*model.SyncFailure, error) {
+	var m model.SyncFailure
+
+	err := s.db.
+		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+		Take(&m).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ListSyncFailures 获取表格下指定状态的失败记录，status 为空时不过滤，按 ID 倒序，支持分页（lastID + limit）
+func (s *syncFailureDAO) ListSyncFailures(tableIdentify, status string, lastID *uint64, limit int) ([]*model.SyncFailure, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []*model.SyncFailure
+
+	query := s.db.
+		Model(&model.SyncFailure{}).
+		Where("table_identify = ?", tableIdentify)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id < ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id DESC").
+		Limit(limit + 1).
+		Find(&list).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(list) > limit {
+		hasMore = true
+		list = list[:limit]
+	}
+
+	return list, hasMore, nil
+}
+
+// DiscardSyncFailure 放弃同步该记录，返回记录是否存在
+func (s *syncFailureDAO) DiscardSyncFailure(tableIdentify, recordID string) (bool, error) {
+	res := s.db.Model(&model.SyncFailure{}).
+		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+		Update("status", model.SyncFailureStatusDiscarded)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// ResetSyncFailure 将失败记录恢复为重试中并清零失败次数，用于手动重试，返回记录是否存在
+func (s *syncFailureDAO) ResetSyncFailure(tableIdentify, recordID string) (bool, error) {
+	res := s.db.Model(&model.SyncFailure{}).
+		Where("table_identify = ? AND record_id = ?", tableIdentify, recordID).
+		Updates(map[string]any{
+			"status":     model.SyncFailureStatusRetrying,
+			"attempts":   0,
+			"dead_at":    nil,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
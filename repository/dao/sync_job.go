@@ -0,0 +1,234 @@
+package dao
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=./mock/sync_job_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao SyncJobDAO
+type SyncJobDAO interface {
+	CreateSyncJob(m *model.SyncJob) error
+	SetSyncJobBatches(jobID string, batches, total int, watermark *int64) error
+	MarkSyncJobRunning(jobID string) error
+	AddSyncJobBatchResult(jobID string, batchNo, fetched, updated, failed int, errSample *string) error
+	GetSyncJob(tableIdentify, jobID string) (*model.SyncJob, error)
+	ListSyncJobsByTable(tableIdentify string, lastID *uint64, limit int) ([]*model.SyncJob, bool, error)
+}
+
+type syncJobDAO struct {
+	db *gorm.DB
+}
+
+func NewSyncJobDAO(gorm *gorm.DB) SyncJobDAO {
+	return &syncJobDAO{
+		db: gorm,
+	}
+}
+
+func (s *syncJobDAO) CreateSyncJob(m *model.SyncJob) error {
+	if m == nil {
+		return errors.New("sync job is nil")
+	}
+
+	if m.JobID == nil || m.TableIdentify == nil {
+		return errors.New("missing key fields")
+	}
+
+	if m.ErrorSamples == nil {
+		m.ErrorSamples = []string{}
+	}
+
+	return s.db.Create(m).Error
+}
+
+// SetSyncJobBatches 入队完成后写入批次数、记录总数与待推进的高水位，若批次已全部处理完则直接结束任务
+func (s *syncJobDAO) SetSyncJobBatches(jobID string, batches, total int, watermark *int64) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.SyncJob{}).
+			Where("job_id = ?", jobID).
+			Updates(map[string]any{
+				"enqueued":  true,
+				"batches":   batches,
+				"total":     total,
+				"watermark": watermark,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return finishSyncJob(tx, jobID)
+	})
+}
+
+// MarkSyncJobRunning 开始处理第一个批次时将任务标记为处理中
+func (s *syncJobDAO) MarkSyncJobRunning(jobID string) error {
+	return s.db.Model(&model.SyncJob{}).
+		Where("job_id = ? AND state = ?", jobID, model.SyncJobStateQueued).
+		Updates(map[string]any{
+			"state":      model.SyncJobStateRunning,
+			"started_at": gorm.Expr("NOW(3)"),
+		}).Error
+}
+
+// AddSyncJobBatchResult 保存一个批次的处理结果并重新汇总任务的统计数据，最后一个批次完成时结束任务
+// 批次结果按 (job_id, batch_no) upsert，消息被重复投递时覆盖而不是重复累加
+func (s *syncJobDAO) AddSyncJobBatchResult(jobID string, batchNo, fetched, updated, failed int, errSample *string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// 先锁住任务行，同一任务的批次串行汇总；之后的查询在加锁后才建立快照，能看到其他批次已提交的结果
+		var job model.SyncJob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("job_id = ?", jobID).
+			Take(&job).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "job_id"}, {Name: "batch_no"}},
+			DoUpdates: clause.AssignmentColumns([]string{"fetched", "updated", "failed", "error", "updated_at"}),
+		}).Create(&model.SyncJobBatch{
+			JobID:   &jobID,
+			BatchNo: batchNo,
+			Fetched: fetched,
+			Updated: updated,
+			Failed:  failed,
+			Error:   errSample,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		var sum struct {
+			BatchesDone int
+			Fetched     int
+			Updated     int
+			Failed      int
+		}
+		err = tx.Model(&model.SyncJobBatch{}).
+			Select("COUNT(*) AS batches_done, COALESCE(SUM(fetched), 0) AS fetched, COALESCE(SUM(updated), 0) AS updated, COALESCE(SUM(failed), 0) AS failed").
+			Where("job_id = ?", jobID).
+			Scan(&sum).Error
+		if err != nil {
+			return err
+		}
+
+		samples := make([]string, 0, model.SyncJobMaxErrorSamples)
+		err = tx.Model(&model.SyncJobBatch{}).
+			Where("job_id = ? AND error IS NOT NULL", jobID).
+			Order("batch_no ASC").
+			Limit(model.SyncJobMaxErrorSamples).
+			Pluck("error", &samples).Error
+		if err != nil {
+			return err
+		}
+		samplesJSON, err := json.Marshal(samples)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&model.SyncJob{}).
+			Where("job_id = ?", jobID).
+			Updates(map[string]any{
+				"batches_done":  sum.BatchesDone,
+				"fetched":       sum.Fetched,
+				"updated":       sum.Updated,
+				"failed":        sum.Failed,
+				"error_samples": string(samplesJSON),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return finishSyncJob(tx, jobID)
+	})
+}
+
+// GetSyncJob 根据 tableIdentify 和 jobID 获取任务
+func (s *syncJobDAO) GetSyncJob(tableIdentify, jobID string) (*model.SyncJob, error) {
+	var job model.SyncJob
+
+	err := s.db.
+		Where("table_identify = ? AND job_id = ?", tableIdentify, jobID).
+		Take(&job).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ListSyncJobsByTable 获取指定表格下的任务列表，按创建顺序倒序，支持分页（lastID + limit）
+func (s *syncJobDAO) ListSyncJobsByTable(tableIdentify string, lastID *uint64, limit int) ([]*model.SyncJob, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var jobs []*model.SyncJob
+
+	query := s.db.
+		Model(&model.SyncJob{}).
+		Where("table_identify = ?", tableIdentify)
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id < ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id DESC").
+		Limit(limit + 1).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(jobs) > limit {
+		hasMore = true
+		jobs = jobs[:limit]
+	}
+
+	return jobs, hasMore, nil
+}
+
+// finishSyncJob 所有批次处理完成后，根据成功与失败数量确定任务的最终状态
+// 入队阶段与消费阶段都会调用，通过 state 条件保证只结束一次
+// 任务成功结束且带有高水位时在同一事务中推进表格的高水位，失败的批次不会让这段时间被跳过
+func finishSyncJob(tx *gorm.DB, jobID string) error {
+	res := tx.Model(&model.SyncJob{}).
+		Where("job_id = ? AND enqueued = 1 AND batches_done >= batches AND state IN ?",
+			jobID, []string{model.SyncJobStateQueued, model.SyncJobStateRunning},
+		).
+		Updates(map[string]any{
+			// 没有失败记录也没有错误信息时视为成功，一条都没有写入时视为失败
+			"state": gorm.Expr("CASE WHEN failed = 0 AND JSON_LENGTH(error_samples) = 0 THEN ? WHEN updated = 0 THEN ? ELSE ? END",
+				model.SyncJobStateSucceeded, model.SyncJobStateFailed, model.SyncJobStatePartiallyFailed,
+			),
+			"started_at":  gorm.Expr("COALESCE(started_at, NOW(3))"),
+			"finished_at": gorm.Expr("NOW(3)"),
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.Error
+	}
+
+	var job model.SyncJob
+	err := tx.Select("table_identify", "state", "watermark").
+		Where("job_id = ?", jobID).
+		Take(&job).Error
+	if err != nil {
+		return err
+	}
+	if job.State != model.SyncJobStateSucceeded || job.Watermark == nil {
+		return nil
+	}
+
+	return advanceWatermark(tx, *job.TableIdentify, *job.Watermark)
+}
@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination=./mock/sync_watermark_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao SyncWatermarkDAO
+type SyncWatermarkDAO interface {
+	GetWatermark(tableIdentify string) (*model.SyncWatermark, error)
+}
+
+type syncWatermarkDAO struct {
+	db *gorm.DB
+}
+
+func NewSyncWatermarkDAO(gorm *gorm.DB) SyncWatermarkDAO {
+	return &syncWatermarkDAO{
+		db: gorm,
+	}
+}
+
+// GetWatermark 获取表格的增量同步高水位，不存在时返回 nil
+func (s *syncWatermarkDAO) GetWatermark(tableIdentify string) (*model.SyncWatermark, error) {
+	var wm model.SyncWatermark
+
+	err := s.db.
+		Where("table_identify = ?", tableIdentify).
+		Take(&wm).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wm, nil
+}
+
+// advanceWatermark 推进表格的增量同步高水位，只会变大不会回退
+// 由增量同步任务成功结束时在 finishSyncJob 的事务中调用
+func advanceWatermark(tx *gorm.DB, tableIdentify string, lastModifiedTime int64) error {
+	m := &model.SyncWatermark{
+		TableIdentify:    &tableIdentify,
+		LastModifiedTime: lastModifiedTime,
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "table_identify"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_modified_time": gorm.Expr("GREATEST(last_modified_time, VALUES(last_modified_time))"),
+			"updated_at":         gorm.Expr("NOW(3)"),
+		}),
+	}).Create(m).Error
+}
@@ -0,0 +1,225 @@
+package dao
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -destination=./mock/webhook_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/repository/dao WebhookDAO
+type WebhookDAO interface {
+	CreateSubscription(m *model.WebhookSubscription) error
+	ListSubscriptions(tableIdentify string) ([]*model.WebhookSubscription, error)
+	GetSubscription(tableIdentify string, id uint64) (*model.WebhookSubscription, error)
+	DeleteSubscription(tableIdentify string, id uint64) (bool, error)
+	CreateDeliveries(list []*model.WebhookDelivery) error
+	GetDueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error)
+	ClaimDelivery(id uint64, nextRetryAt, leaseUntil time.Time) (bool, error)
+	UpdateDelivery(m *model.WebhookDelivery) error
+	GetDelivery(tableIdentify string, id uint64) (*model.WebhookDelivery, error)
+	ListDeliveries(tableIdentify string, subscriptionID uint64, status string, lastID *uint64, limit int) ([]*model.WebhookDelivery, bool, error)
+	ResetDelivery(tableIdentify string, id uint64, now time.Time) (bool, error)
+}
+
+type webhookDAO struct {
+	db *gorm.DB
+}
+
+func NewWebhookDAO(gorm *gorm.DB) WebhookDAO {
+	return &webhookDAO{
+		db: gorm,
+	}
+}
+
+func (w *webhookDAO) CreateSubscription(m *model.WebhookSubscription) error {
+	if m == nil {
+		return errors.New("subscription is nil")
+	}
+
+	if m.TableIdentify == nil || m.URL == "" {
+		return errors.New("missing key fields")
+	}
+
+	return w.db.Create(m).Error
+}
+
+// ListSubscriptions 获取表格下的全部订阅，按 ID 升序
+func (w *webhookDAO) ListSubscriptions(tableIdentify string) ([]*model.WebhookSubscription, error) {
+	var list []*model.WebhookSubscription
+
+	err := w.db.
+		Where("table_identify = ?", tableIdentify).
+		Order("id ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetSubscription 根据 tableIdentify 和 ID 获取订阅，订阅不属于该表格时返回 gorm.ErrRecordNotFound
+func (w *webhookDAO) GetSubscription(tableIdentify string, id uint64) (*model.WebhookSubscription, error) {
+	var m model.WebhookSubscription
+
+	err := w.db.
+		Where("table_identify = ? AND id = ?", tableIdentify, id).
+		Take(&m).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// DeleteSubscription 删除订阅，未投递的事件不再投递，返回订阅是否存在
+func (w *webhookDAO) DeleteSubscription(tableIdentify string, id uint64) (bool, error) {
+	var deleted bool
+
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Where("table_identify = ? AND id = ?", tableIdentify, id).
+			Delete(&model.WebhookSubscription{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected > 0
+		if !deleted {
+			return nil
+		}
+
+		// 投递记录保留作为日志
+		return tx.Model(&model.WebhookDelivery{}).
+			Where("subscription_id = ? AND status = ?", id, model.WebhookDeliveryStatusPending).
+			Update("status", model.WebhookDeliveryStatusDead).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+func (w *webhookDAO) CreateDeliveries(list []*model.WebhookDelivery) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return w.db.Create(list).Error
+}
+
+// GetDueDeliveries 获取到达重试时间且尚未投递成功的事件，按重试时间升序
+func (w *webhookDAO) GetDueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []model.WebhookDelivery
+	err := w.db.
+		Where("status = ? AND next_retry_at <= ?", model.WebhookDeliveryStatusPending, now).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&list).Error
+
+	return list, err
+}
+
+// ClaimDelivery 以 next_retry_at 作为乐观锁认领投递任务，与 ClaimOutbox 相同
+func (w *webhookDAO) ClaimDelivery(id uint64, nextRetryAt, leaseUntil time.Time) (bool, error) {
+	res := w.db.Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_retry_at = ?", id, model.WebhookDeliveryStatusPending, nextRetryAt).
+		Update("next_retry_at", leaseUntil)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+// UpdateDelivery 保存投递结果与重试信息
+func (w *webhookDAO) UpdateDelivery(m *model.WebhookDelivery) error {
+	if m == nil {
+		return errors.New("delivery is nil")
+	}
+
+	return w.db.Model(&model.WebhookDelivery{}).
+		Where("id = ?", m.ID).
+		Select("status", "attempts", "next_retry_at", "last_status_code", "last_error", "delivered_at").
+		Updates(m).Error
+}
+
+// GetDelivery 根据 tableIdentify 和 ID 获取投递记录
+func (w *webhookDAO) GetDelivery(tableIdentify string, id uint64) (*model.WebhookDelivery, error) {
+	var m model.WebhookDelivery
+
+	err := w.db.
+		Where("table_identify = ? AND id = ?", tableIdentify, id).
+		Take(&m).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ListDeliveries 获取订阅下指定状态的投递记录，status 为空时不过滤，按 ID 倒序，支持分页（lastID + limit）
+func (w *webhookDAO) ListDeliveries(tableIdentify string, subscriptionID uint64, status string, lastID *uint64, limit int) ([]*model.WebhookDelivery, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	var list []*model.WebhookDelivery
+
+	query := w.db.
+		Model(&model.WebhookDelivery{}).
+		Where("table_identify = ? AND subscription_id = ?", tableIdentify, subscriptionID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if lastID != nil && *lastID > 0 {
+		query = query.Where("id < ?", *lastID)
+	}
+
+	// 多查一条判断是否有下一页
+	err := query.
+		Order("id DESC").
+		Limit(limit + 1).
+		Find(&list).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := false
+	if len(list) > limit {
+		hasMore = true
+		list = list[:limit]
+	}
+
+	return list, hasMore, nil
+}
+
+// ResetDelivery 将投递记录重置为待投递并清零重试次数，用于手动重新投递，返回记录是否存在
+func (w *webhookDAO) ResetDelivery(tableIdentify string, id uint64, now time.Time) (bool, error) {
+	res := w.db.Model(&model.WebhookDelivery{}).
+		Where("table_identify = ? AND id = ?", tableIdentify, id).
+		Updates(map[string]any{
+			"status":        model.WebhookDeliveryStatusPending,
+			"attempts":      0,
+			"next_retry_at": now,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// DigestRun 管理员摘要卡片的发送记录，每张表格一条，记录最近一次发送对应的计划时间，避免重复发送
+type DigestRun struct {
+	ID              uint64    `gorm:"primaryKey;autoIncrement"`
+	TableIdentify   *string   `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_digest_table"`
+	LastScheduledAt time.Time `gorm:"column:last_scheduled_at;not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (DigestRun) TableName() string {
+	return "digest_run"
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// FeedbackMessage 反馈记录下学生与工作人员的留言，按 ID 顺序组成对话
+type FeedbackMessage struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement;index:idx_feedback_message_record,priority:3"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);index:idx_feedback_message_record,priority:1;uniqueIndex:idx_feedback_message_source,priority:1;index:idx_feedback_message_notice,priority:1"`
+	RecordID      *string `gorm:"column:record_id;not null;type:varchar(32);index:idx_feedback_message_record,priority:2;uniqueIndex:idx_feedback_message_source,priority:2"`
+	UserID        *string `gorm:"column:user_id;not null;type:varchar(32)"` // 记录所属学生的学号
+
+	Sender     string  `gorm:"column:sender;not null;type:varchar(16);index:idx_feedback_message_notice,priority:3"` // student / staff
+	SenderName string  `gorm:"column:sender_name;not null;type:varchar(64)"`                                         // 学生为学号，工作人员为署名
+	Content    string  `gorm:"column:content;not null;type:text"`
+	Source     string  `gorm:"column:source;not null;type:varchar(16)"`                                               // api / lark
+	SourceKey  *string `gorm:"column:source_key;type:varchar(64);uniqueIndex:idx_feedback_message_source,priority:3"` // 从飞书回复列导入时为记录的修改版本，用于去重
+
+	IsNoticed      bool `gorm:"type:tinyint(1);column:is_noticed;not null;default:false;index:idx_feedback_message_notice,priority:2"` // 工作人员的回复是否已通知学生
+	NoticeAttempts int  `gorm:"column:notice_attempts;not null;default:0"`
+
+	CreatedAt time.Time
+}
+
+func (FeedbackMessage) TableName() string {
+	return "feedback_message"
+}
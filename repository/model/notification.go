@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Notification 学生站内通知，通知流程处理每个进度变化时写入一条，供客户端拉取
+// 同一记录的每次进度变化只保留一条，重试投递不会重复写入，进度反复变化（如 A→B→A）时按进度版本区分；工作人员的回复按留言 ID 区分
+type Notification struct {
+	ID              uint64  `gorm:"primaryKey;autoIncrement;index:idx_notification_user,priority:3"`
+	TableIdentify   *string `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_notification_transition,priority:1;index:idx_notification_user,priority:1;index:idx_notification_unread,priority:1"`
+	UserID          *string `gorm:"column:user_id;not null;type:varchar(32);index:idx_notification_user,priority:2;index:idx_notification_unread,priority:2"`
+	RecordID        *string `gorm:"column:record_id;not null;type:varchar(32);uniqueIndex:idx_notification_transition,priority:2"`
+	Progress        *string `gorm:"column:progress;not null;type:varchar(32);uniqueIndex:idx_notification_transition,priority:3"`
+	ProgressVersion uint64  `gorm:"column:progress_version;not null;default:0;uniqueIndex:idx_notification_transition,priority:4"` // 记录的进度版本，回复通知为 0
+	MessageID       uint64  `gorm:"column:message_id;not null;default:0;uniqueIndex:idx_notification_transition,priority:5"`       // 工作人员回复的留言 ID，进度通知为 0
+
+	Title    string  `gorm:"column:title;not null;type:varchar(255)"`
+	Content  string  `gorm:"column:content;not null;type:text"`
+	ShareUrl *string `gorm:"column:share_url;type:varchar(255)"`
+
+	IsRead bool       `gorm:"type:tinyint(1);column:is_read;not null;default:false;index:idx_notification_unread,priority:3"`
+	ReadAt *time.Time `gorm:"column:read_at"`
+
+	CreatedAt time.Time
+}
+
+func (Notification) TableName() string {
+	return "notification"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// NotificationDelivery 学生通知的投递日志，每次尝试一条记录
+// 记录只在投递成功后标记为已通知，失败的记录按重试次数退避后重新投递
+type NotificationDelivery struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);index:idx_notice_delivery_record,priority:1;index:idx_notice_delivery_success,priority:1"`
+	RecordID      *string `gorm:"column:record_id;not null;type:varchar(32);index:idx_notice_delivery_record,priority:2"`
+	UserID        *string `gorm:"column:user_id;not null;type:varchar(32)"`
+	Channel       string  `gorm:"column:channel;not null;type:varchar(32)"`
+	Progress      *string `gorm:"column:progress;type:varchar(32)"`                    // 通知的进度，进度变化后重新计算尝试次数
+	MessageID     *uint64 `gorm:"column:message_id;index:idx_notice_delivery_message"` // 回复通知对应的留言 ID，进度通知为空
+
+	Attempt    int     `gorm:"column:attempt;not null"` // 第几次尝试，从 1 开始
+	Success    bool    `gorm:"type:tinyint(1);column:success;not null;default:false;index:idx_notice_delivery_success,priority:2"`
+	StatusCode int     `gorm:"column:status_code;not null;default:0"` // HTTP 状态码，请求未发出或非 HTTP 渠道时为 0
+	Response   *string `gorm:"column:response;type:text"`             // 对方返回的内容，超长时截断
+	Error      *string `gorm:"column:error;type:text"`
+
+	CreatedAt time.Time
+}
+
+func (NotificationDelivery) TableName() string {
+	return "notification_delivery"
+}
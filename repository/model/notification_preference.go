@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+)
+
+// NotificationPreference 学生在某张表格下的通知偏好，未设置的学生按表格配置通知
+type NotificationPreference struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_preference_user,priority:1"`
+	UserID        *string `gorm:"column:user_id;not null;type:varchar(32);uniqueIndex:idx_preference_user,priority:2"`
+
+	OptOut     bool                `gorm:"type:tinyint(1);column:opt_out;not null;default:false"`
+	Channels   []string            `gorm:"column:channels;type:json;serializer:json"`    // 按优先级排列的通知渠道，为空时使用表格的通知渠道
+	QuietHours []domain.QuietHours `gorm:"column:quiet_hours;type:json;serializer:json"` // 免打扰时段，期间的通知推迟到时段结束后发送
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preference"
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+)
+
+const (
+	OutboxStatusPending = "pending" // 待处理或等待重试
+	OutboxStatusDone    = "done"    // 所有步骤均已完成
+	OutboxStatusDead    = "dead"    // 超过最大重试次数，不再处理
+)
+
+// RecordOutbox 新建反馈记录的处理任务（创建飞书记录、获取分享链接、落库、通知管理员）
+// 任务在创建飞书记录之前登记，RecordID 为空表示飞书记录尚未确认创建，后台使用 ClientToken 幂等地补建
+// 每个步骤完成后单独标记，重试时跳过已完成的步骤
+type RecordOutbox struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_outbox_record,priority:1"`
+	RecordID      *string `gorm:"column:record_id;type:varchar(32);uniqueIndex:idx_outbox_record,priority:2"`
+	ClientToken   *string `gorm:"column:client_token;type:varchar(64);uniqueIndex:idx_outbox_client_token"` // 创建飞书记录的幂等标识，同一个 token 重复创建时返回同一条记录
+
+	TableConfig domain.TableConfig `gorm:"column:table_config;not null;type:json;serializer:json"`
+	Fields      map[string]any     `gorm:"column:fields;type:json;serializer:json"` // 创建飞书记录使用的字段
+	Content     *string            `gorm:"column:content;type:text"`
+	Record      map[string]any     `gorm:"column:record;type:json;serializer:json"`
+	ShareUrl    *string            `gorm:"column:share_url;type:varchar(255)"`
+
+	PendingReceivers []domain.LarkReceiver `gorm:"column:pending_receivers;type:json;serializer:json"` // 上次通知失败的接收者，重试时只发送给它们，为空时发送给全部接收者
+
+	Fetched   bool `gorm:"type:tinyint(1);column:fetched;not null;default:false"`
+	Persisted bool `gorm:"type:tinyint(1);column:persisted;not null;default:false"`
+	Notified  bool `gorm:"type:tinyint(1);column:notified;not null;default:false"`
+
+	Status      string    `gorm:"column:status;not null;type:varchar(16);default:pending;index:idx_outbox_due,priority:1"`
+	Attempts    int       `gorm:"column:attempts;not null;default:0"`
+	NextRetryAt time.Time `gorm:"column:next_retry_at;not null;index:idx_outbox_due,priority:2"`
+	LastError   *string   `gorm:"column:last_error;type:text"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (RecordOutbox) TableName() string {
+	return "record_outbox"
+}
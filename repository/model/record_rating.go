@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// RecordRating 学生对已完成反馈记录的满意度评价，每条记录只能评价一次
+// 评价需要回写到飞书的评价列，回写失败时由后台按退避时间重试，状态取值与 RecordOutbox 相同
+type RecordRating struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_rating_record,priority:1"`
+	RecordID      *string `gorm:"column:record_id;not null;type:varchar(32);uniqueIndex:idx_rating_record,priority:2"`
+	UserID        *string `gorm:"column:user_id;not null;type:varchar(32)"`
+	Handler       string  `gorm:"column:handler;not null;type:varchar(64)"` // 评价时记录的处理人，多人时用逗号连接，只用于展示，统计以 RecordRatingHandler 为准
+
+	Score   int     `gorm:"column:score;not null"` // 1-5
+	Comment *string `gorm:"column:comment;type:varchar(500)"`
+
+	WriteBackStatus   string     `gorm:"column:write_back_status;not null;type:varchar(16);default:done;index:idx_rating_write_back,priority:1"` // 未配置评价列时直接为 done
+	WriteBackAttempts int        `gorm:"column:write_back_attempts;not null;default:0"`
+	NextRetryAt       *time.Time `gorm:"column:next_retry_at;index:idx_rating_write_back,priority:2"`
+	LastError         *string    `gorm:"column:last_error;type:text"`
+
+	CreatedAt time.Time
+}
+
+func (RecordRating) TableName() string {
+	return "record_rating"
+}
+
+// RecordRatingHandler 评价对应的处理人，记录有多个处理人时每人一行，按处理人统计时每人各计一次
+type RecordRatingHandler struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	RatingID      uint64  `gorm:"column:rating_id;not null;uniqueIndex:idx_rating_handler_unique,priority:1"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);index:idx_rating_handler_table,priority:1"`
+	Handler       string  `gorm:"column:handler;not null;type:varchar(64);uniqueIndex:idx_rating_handler_unique,priority:2;index:idx_rating_handler_table,priority:2"`
+}
+
+func (RecordRatingHandler) TableName() string {
+	return "record_rating_handler"
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+const (
+	RecordRevisionStatusPending = "pending" // 已记录，飞书尚未确认修改
+	RecordRevisionStatusApplied = "applied" // 飞书已修改
+	RecordRevisionStatusFailed  = "failed"  // 飞书修改失败，记录未变化
+)
+
+// RecordRevision 学生修改或撤回反馈记录的审计记录，保存修改前后的记录内容
+type RecordRevision struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);index:idx_revision_record,priority:1"`
+	RecordID      *string `gorm:"column:record_id;not null;type:varchar(32);index:idx_revision_record,priority:2"`
+	UserID        *string `gorm:"column:user_id;not null;type:varchar(32)"`
+
+	Action string         `gorm:"column:action;not null;type:varchar(16)"` // edit / withdraw
+	Before map[string]any `gorm:"column:before;type:json;serializer:json"`
+	After  map[string]any `gorm:"column:after;type:json;serializer:json"`
+	Reason *string        `gorm:"column:reason;type:varchar(255)"`                         // 撤回原因
+	Status string         `gorm:"column:status;not null;type:varchar(16);default:applied"` // pending / applied / failed，修改前先以 pending 写入
+
+	CreatedAt time.Time
+}
+
+func (RecordRevision) TableName() string {
+	return "record_revision"
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Sheet struct {
@@ -12,12 +14,16 @@ type Sheet struct {
 
 	Record   map[string]any `gorm:"column:record;not null;type:json;serializer:json"`
 	ShareUrl *string        `gorm:"column:share_url;type:varchar(255);"`
+	Progress *string        `gorm:"column:progress;type:varchar(32)"` // 最近一次同步时记录的进度，进度变化时重新通知学生
+
+	ProgressVersion uint64 `gorm:"column:progress_version;not null;default:0"` // 进度变化的次数，区分同一进度的多次通知（如 A→B→A）
 
 	IsNoticed bool `gorm:"type:tinyint(1);column:is_noticed;not null;default:false;index:idx_table_notice,priority:3"`
 	IsSynced  bool `gorm:"type:tinyint(1);column:is_synced;not null;default:false;index:idx_table_sync,priority:2;index:idx_table_notice,priority:2"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"` // 飞书中已删除的记录软删除，查询时自动排除
 }
 
 func (Sheet) TableName() string {
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	SyncFailureStatusRetrying  = "retrying"  // 同步失败，等待下次同步重试
+	SyncFailureStatusDead      = "dead"      // 失败次数达到上限，不再自动重试
+	SyncFailureStatusDiscarded = "discarded" // 管理员确认放弃，不再自动重试
+)
+
+// SyncFailure 记录同步到数据库失败的飞书记录，用于统计重试次数与死信处理
+// 记录同步成功后删除
+type SyncFailure struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_sync_failure_record,priority:1;index:idx_sync_failure_status,priority:1"`
+	RecordID      *string `gorm:"column:record_id;not null;type:varchar(32);uniqueIndex:idx_sync_failure_record,priority:2"`
+	Status        string  `gorm:"column:status;not null;type:varchar(16);index:idx_sync_failure_status,priority:2"`
+	Attempts      int     `gorm:"column:attempts;not null;default:0"` // 连续失败次数
+	LastError     string  `gorm:"column:last_error;not null;type:text"`
+
+	DeadAt    *time.Time `gorm:"column:dead_at"` // 进入死信状态的时间
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SyncFailure) TableName() string {
+	return "sync_failure"
+}
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+const (
+	SyncJobStateQueued          = "queued"           // 已入队，尚未开始处理
+	SyncJobStateRunning         = "running"          // 处理中
+	SyncJobStateSucceeded       = "succeeded"        // 全部记录同步成功
+	SyncJobStatePartiallyFailed = "partially_failed" // 部分记录同步失败
+	SyncJobStateFailed          = "failed"           // 全部记录同步失败
+
+	SyncJobKindUnsynced    = "unsynced"    // 同步未同步的记录
+	SyncJobKindUser        = "user"        // 强制同步某用户的记录
+	SyncJobKindForce       = "force"       // 强制同步整张表格
+	SyncJobKindFAQ         = "faq"         // 同步 FAQ 记录
+	SyncJobKindIncremental = "incremental" // 按修改时间增量同步
+	// 未配置修改时间字段时的增量同步，需要扫描全表再按最后修改时间过滤
+	SyncJobKindIncrementalScan = "incremental_scan"
+)
+
+// SyncJob 一次同步请求对应的任务，记录各批次的处理进度与结果
+// 一个任务会拆分成多个批次写入同步队列，全部批次处理完成后任务结束
+type SyncJob struct {
+	ID            uint64  `gorm:"primaryKey;autoIncrement"`
+	JobID         *string `gorm:"column:job_id;not null;type:varchar(36);uniqueIndex:idx_sync_job_id"`
+	TableIdentify *string `gorm:"column:table_identify;not null;type:varchar(32);index:idx_sync_job_table"`
+	Kind          string  `gorm:"column:kind;not null;type:varchar(16)"`
+	State         string  `gorm:"column:state;not null;type:varchar(16)"`
+
+	Enqueued    bool `gorm:"type:tinyint(1);column:enqueued;not null;default:false"` // 是否已全部入队，入队完成前任务不会结束
+	Total       int  `gorm:"column:total;not null;default:0"`                        // 入队的记录总数
+	Batches     int  `gorm:"column:batches;not null;default:0"`                      // 入队的批次数
+	BatchesDone int  `gorm:"column:batches_done;not null;default:0"`                 // 已处理完成的批次数
+	Fetched     int  `gorm:"column:fetched;not null;default:0"`                      // 从飞书获取到的记录数
+	Updated     int  `gorm:"column:updated;not null;default:0"`                      // 成功写入数据库的记录数
+	Failed      int  `gorm:"column:failed;not null;default:0"`                       // 同步失败的记录数
+
+	ErrorSamples []string `gorm:"column:error_samples;not null;type:json;serializer:json"` // 部分错误信息，最多保留 SyncJobMaxErrorSamples 条
+
+	// 增量同步任务成功结束后要推进到的高水位（毫秒时间戳），任务未成功时不推进，下一轮重新拉取这段时间
+	Watermark *int64 `gorm:"column:watermark"`
+
+	StartedAt  *time.Time `gorm:"column:started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+const SyncJobMaxErrorSamples = 10
+
+func (SyncJob) TableName() string {
+	return "sync_job"
+}
+
+// SyncJobBatch 同步任务中一个批次的处理结果，以 (job_id, batch_no) 唯一
+// 同一批次被重复投递时覆盖上一次的结果，任务的统计数据由全部批次汇总得到，不会重复累加
+type SyncJobBatch struct {
+	ID      uint64  `gorm:"primaryKey;autoIncrement"`
+	JobID   *string `gorm:"column:job_id;not null;type:varchar(36);uniqueIndex:idx_sync_job_batch,priority:1"`
+	BatchNo int     `gorm:"column:batch_no;not null;uniqueIndex:idx_sync_job_batch,priority:2"` // 入队时的批次序号，从 1 开始
+	Fetched int     `gorm:"column:fetched;not null;default:0"`
+	Updated int     `gorm:"column:updated;not null;default:0"`
+	Failed  int     `gorm:"column:failed;not null;default:0"`
+	Error   *string `gorm:"column:error;type:text"` // 失败原因示例
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SyncJobBatch) TableName() string {
+	return "sync_job_batch"
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// SyncWatermark 增量同步的高水位，记录每张表格已同步到的飞书记录最后修改时间
+type SyncWatermark struct {
+	ID               uint64  `gorm:"primaryKey;autoIncrement"`
+	TableIdentify    *string `gorm:"column:table_identify;not null;type:varchar(32);uniqueIndex:idx_watermark_table"`
+	LastModifiedTime int64   `gorm:"column:last_modified_time;not null;default:0"` // 毫秒时间戳
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SyncWatermark) TableName() string {
+	return "sync_watermark"
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+const (
+	WebhookDeliveryStatusPending   = "pending"   // 待投递或等待重试
+	WebhookDeliveryStatusDelivered = "delivered" // 对方返回 2xx
+	WebhookDeliveryStatusDead      = "dead"      // 超过最大重试次数，不再投递
+)
+
+// WebhookSubscription 表格级别的 webhook 订阅，事件发生时向 URL 推送签名后的事件
+type WebhookSubscription struct {
+	ID            uint64   `gorm:"primaryKey;autoIncrement"`
+	TableIdentify *string  `gorm:"column:table_identify;not null;type:varchar(32);index:idx_webhook_table"`
+	URL           string   `gorm:"column:url;not null;type:varchar(512)"`
+	Secret        string   `gorm:"column:secret;not null;type:varchar(128)"` // HMAC-SHA256 签名密钥
+	Events        []string `gorm:"column:events;not null;type:json;serializer:json"`
+	Enabled       bool     `gorm:"type:tinyint(1);column:enabled;not null;default:true"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscription"
+}
+
+// WebhookDelivery 一次事件投递，同时作为投递日志，记录重试次数与最近一次的响应
+type WebhookDelivery struct {
+	ID             uint64  `gorm:"primaryKey;autoIncrement"`
+	SubscriptionID uint64  `gorm:"column:subscription_id;not null;index:idx_webhook_delivery_sub;uniqueIndex:idx_webhook_delivery_event,priority:2"`
+	TableIdentify  *string `gorm:"column:table_identify;not null;type:varchar(32)"`
+	RecordID       *string `gorm:"column:record_id;not null;type:varchar(32)"`
+	EventID        string  `gorm:"column:event_id;not null;type:varchar(36);uniqueIndex:idx_webhook_delivery_event,priority:1"`
+	Event          string  `gorm:"column:event;not null;type:varchar(32)"`
+	Payload        string  `gorm:"column:payload;not null;type:mediumtext"` // 签名时使用的原始请求体，重新投递时保持不变
+
+	Status         string     `gorm:"column:status;not null;type:varchar(16);default:pending;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0"`
+	NextRetryAt    time.Time  `gorm:"column:next_retry_at;not null;index:idx_webhook_delivery_due,priority:2"`
+	LastStatusCode int        `gorm:"column:last_status_code;not null;default:0"` // 最近一次的 HTTP 状态码，请求未发出时为 0
+	LastError      *string    `gorm:"column:last_error;type:text"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/google/wire"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var ProviderSet = wire.NewSet(DaoSet, CacheSet)
@@ -14,10 +17,26 @@ var DaoSet = wire.NewSet(
 	dao.NewFAQResolutionDAO,
 	dao.NewSheetDAO,
 	dao.NewFAQDAO,
+	dao.NewOutboxDAO,
+	dao.NewSyncJobDAO,
+	dao.NewSyncWatermarkDAO,
+	dao.NewSyncFailureDAO,
+	dao.NewWebhookDAO,
+	dao.NewNotificationDeliveryDAO,
+	dao.NewDigestDAO,
+	dao.NewNotificationPreferenceDAO,
+	dao.NewNotificationDAO,
+	dao.NewFeedbackMessageDAO,
+	dao.NewRecordRevisionDAO,
+	dao.NewRecordRatingDAO,
 )
 
 var CacheSet = wire.NewSet(
 	cache.NewFAQResolutionStateCache,
+	cache.NewSyncQueue,
+	cache.NewLeaderLease,
+	cache.NewSubmissionCache,
+	cache.NewNoticeTrigger,
 )
 
 func InitTables(db *gorm.DB) error {
@@ -25,7 +44,80 @@ func InitTables(db *gorm.DB) error {
 		&model.FAQResolution{},
 		&model.Sheet{},
 		&model.FAQRecord{},
+		&model.RecordOutbox{},
+		&model.SyncJob{},
+		&model.SyncJobBatch{},
+		&model.SyncWatermark{},
+		&model.SyncFailure{},
+		&model.WebhookSubscription{},
+		&model.WebhookDelivery{},
+		&model.NotificationDelivery{},
+		&model.DigestRun{},
+		&model.NotificationPreference{},
+		&model.Notification{},
+		&model.FeedbackMessage{},
+		&model.RecordRevision{},
+		&model.RecordRating{},
+		&model.RecordRatingHandler{},
+	}
+
+	if err := db.AutoMigrate(models...); err != nil {
+		return err
+	}
+
+	if err := backfillSheetProgress(db); err != nil {
+		return err
+	}
+
+	if err := dropNotificationEventIndex(db); err != nil {
+		return err
+	}
+
+	return backfillRatingHandlers(db)
+}
+
+// backfillSheetProgress 为新增 progress 字段前写入的记录补齐进度，只处理 progress 为空的记录，重复执行无副作用
+// 已完成同步的记录沿用原有的通知状态；其余记录以上次同步保存的进度为基准并视为已通知，
+// 避免上线后第一次同步把所有历史记录当作进度变化重新通知
+func backfillSheetProgress(db *gorm.DB) error {
+	return db.Exec(`UPDATE sheet SET
+		is_noticed = IF(is_synced = 1, is_noticed, 1),
+		progress = IF(is_synced = 1, '已完成', COALESCE(JSON_UNQUOTE(JSON_EXTRACT(record, '$."进度"')), ''))
+		WHERE progress IS NULL`).Error
+}
+
+// dropNotificationEventIndex 删除不含进度版本的旧唯一索引，否则同一进度的再次通知仍会被旧索引忽略，重复执行无副作用
+// 新索引 idx_notification_transition 由 AutoMigrate 创建，已有的通知进度版本均为 0，不会冲突
+func dropNotificationEventIndex(db *gorm.DB) error {
+	m := db.Migrator()
+	if !m.HasIndex(&model.Notification{}, "idx_notification_event") {
+		return nil
+	}
+	return m.DropIndex(&model.Notification{}, "idx_notification_event")
+}
+
+// backfillRatingHandlers 为新增 record_rating_handler 表前保存的评价补齐处理人，按逗号拆分原有的处理人，重复执行无副作用
+func backfillRatingHandlers(db *gorm.DB) error {
+	var ratings []model.RecordRating
+	err := db.
+		Select("id", "table_identify", "handler").
+		Where("handler <> '' AND NOT EXISTS (SELECT 1 FROM record_rating_handler h WHERE h.rating_id = record_rating.id)").
+		Find(&ratings).Error
+	if err != nil {
+		return err
+	}
+
+	var rows []model.RecordRatingHandler
+	for _, r := range ratings {
+		for _, h := range strings.Split(r.Handler, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				rows = append(rows, model.RecordRatingHandler{RatingID: r.ID, TableIdentify: r.TableIdentify, Handler: h})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
 	}
 
-	return db.AutoMigrate(models...)
+	return db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 500).Error
 }
@@ -7,17 +7,21 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
 	"github.com/muxi-Infra/FeedBack-Backend/config"
 	"github.com/muxi-Infra/FeedBack-Backend/domain"
 	"github.com/muxi-Infra/FeedBack-Backend/errs"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/retry"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
 )
 
 const (
@@ -30,6 +34,7 @@ const (
 type AuthService interface {
 	RefreshTableConfig() ([]domain.TableConfig, error)
 	GetTableConfig(tableIdentity *string) (domain.TableConfig, error)
+	GetTableConfigByToken(tableToken, tableID string) (domain.TableConfig, error)
 	GetTenantToken() string
 }
 
@@ -40,9 +45,15 @@ type AuthServiceImpl struct {
 	mutex        sync.RWMutex
 	c            lark.Client
 	log          logger.Logger
+	queue        cache.SyncQueue
+	eventCfg     *config.LarkEvent
+	syncCfg      *config.SyncConfig
+	life         *lifecycle.Lifecycle
+	elector      *LeaderElector
+	subscribed   map[string]struct{} // 已订阅记录变更事件的多维表格 token
 }
 
-func NewAuthService(baseCfg *config.BaseTable, clientCfg *config.ClientConfig, c lark.Client, log logger.Logger) AuthService {
+func NewAuthService(baseCfg *config.BaseTable, clientCfg *config.ClientConfig, eventCfg *config.LarkEvent, syncCfg *config.SyncConfig, c lark.Client, log logger.Logger, queue cache.SyncQueue, life *lifecycle.Lifecycle, elector *LeaderElector) AuthService {
 	s := &AuthServiceImpl{
 		tenantToken:  "",
 		baseTableCfg: baseCfg,
@@ -50,6 +61,12 @@ func NewAuthService(baseCfg *config.BaseTable, clientCfg *config.ClientConfig, c
 		mutex:        sync.RWMutex{},
 		c:            c,
 		log:          log,
+		queue:        queue,
+		eventCfg:     eventCfg,
+		syncCfg:      syncCfg,
+		life:         life,
+		elector:      elector,
+		subscribed:   make(map[string]struct{}),
 	}
 	// 启动时同步刷新一次表配置，失败只记录日志
 	if _, err := s.RefreshTableConfig(); err != nil {
@@ -60,46 +77,34 @@ func NewAuthService(baseCfg *config.BaseTable, clientCfg *config.ClientConfig, c
 	s.startTenantTokenRefresher()
 	s.startNotifiableTableScanner()
 	s.startSyncTableScanner()
+	s.startIncrementalSyncScanner()
 
 	return s
 }
 
 func (t *AuthServiceImpl) RefreshTableConfig() ([]domain.TableConfig, error) {
-	// 创建请求对象
-	req := larkbitable.NewSearchAppTableRecordReqBuilder().
-		AppToken(t.baseTableCfg.TableToken).
-		TableId(t.baseTableCfg.TableID).
-		PageToken("").
-		PageSize(50). // 分页大小，先给 50， 应该用不到这么多
-		Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
-			ViewId(t.baseTableCfg.ViewID).
-			FieldNames([]string{`table_identity`, `table_name`, `table_token`, `table_id`, `view_id`, `notice`}).
-			Build()).
-		Build()
-
-	// 发起请求
-	ctx := context.Background()
-	resp, err := t.c.GetAppTableRecord(ctx, req)
-
-	// 处理错误
+	// 拉取全部分页
+	items, err := lark.CollectSearchRecords(context.Background(), t.c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+		return larkbitable.NewSearchAppTableRecordReqBuilder().
+			AppToken(t.baseTableCfg.TableToken).
+			TableId(t.baseTableCfg.TableID).
+			PageToken(pageToken).
+			PageSize(lark.MaxSearchPageSize).
+			Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
+				ViewId(t.baseTableCfg.ViewID).
+				FieldNames([]string{`table_identity`, `table_name`, `table_token`, `table_id`, `view_id`, `notice`, `notice_channel`, `lark_template_id`, `lark_receive_ids`, `lark_template_variables`, `digest_schedule`, `digest_receive_ids`, `reply_field`, `thread_field`, `edit_window`, `handler_field`, `rating_field`, `rating_comment_field`, `moderation`}).
+				Build()).
+			Build()
+	})
 	if err != nil {
-		t.log.Error("RefreshTableConfig 调用失败",
+		t.log.Error("RefreshTableConfig 查询飞书记录失败",
 			logger.String("error", err.Error()),
 		)
-		return nil, errs.LarkRequestError(err)
-	}
-
-	// 服务端错误处理
-	if !resp.Success() {
-		t.log.Error("RefreshTableConfig Lark 接口错误",
-			logger.String("request_id", resp.RequestId()),
-			logger.String("error", larkcore.Prettify(resp.CodeError)),
-		)
-		return nil, errs.LarkResponseError(err)
+		return nil, toLarkError(err)
 	}
 
 	var tables []domain.TableConfig
-	for _, item := range resp.Data.Items {
+	for _, item := range items {
 		var table domain.TableConfig
 		if item.Fields != nil {
 			fields := simplifyFields(item.Fields)
@@ -122,6 +127,56 @@ func (t *AuthServiceImpl) RefreshTableConfig() ([]domain.TableConfig, error) {
 			if v, ok := fields["notice"].(string); ok {
 				table.Notice = v == "yes"
 			}
+			if v, ok := fields["notice_channel"].(string); ok {
+				table.NoticeChannel = v
+			}
+			if v, ok := fields["lark_template_id"].(string); ok {
+				table.LarkTemplateID = strings.TrimSpace(v)
+			}
+			if v, ok := fields["lark_receive_ids"].(string); ok {
+				table.LarkReceivers = parseLarkReceivers(v)
+			}
+			if v, ok := fields["digest_schedule"].(string); ok {
+				table.DigestSchedule = strings.TrimSpace(v)
+			}
+			if v, ok := fields["digest_receive_ids"].(string); ok {
+				table.DigestReceivers = parseLarkReceivers(v)
+			}
+			if v, ok := fields["reply_field"].(string); ok {
+				table.ReplyField = strings.TrimSpace(v)
+			}
+			if v, ok := fields["thread_field"].(string); ok {
+				table.ThreadField = strings.TrimSpace(v)
+			}
+			if v, ok := fields["handler_field"].(string); ok {
+				table.HandlerField = strings.TrimSpace(v)
+			}
+			if v, ok := fields["rating_field"].(string); ok {
+				table.RatingField = strings.TrimSpace(v)
+			}
+			if v, ok := fields["rating_comment_field"].(string); ok {
+				table.RatingCommentField = strings.TrimSpace(v)
+			}
+			if v, ok := fields["moderation"].(string); ok {
+				table.Moderation = strings.ToLower(strings.TrimSpace(v))
+			}
+			if v, ok := fields["edit_window"].(string); ok && strings.TrimSpace(v) != "" {
+				window, err := time.ParseDuration(strings.TrimSpace(v))
+				if err != nil || window < 0 {
+					t.log.Warn("edit_window 不是合法的时长，已忽略",
+						logger.String("edit_window", v),
+					)
+				} else {
+					table.EditWindow = window
+				}
+			}
+			if v, ok := fields["lark_template_variables"].(string); ok && strings.TrimSpace(v) != "" {
+				if err := json.Unmarshal([]byte(v), &table.LarkTemplateVariables); err != nil {
+					t.log.Warn("lark_template_variables 不是合法的 JSON 对象，已忽略",
+						logger.String("error", err.Error()),
+					)
+				}
+			}
 		}
 
 		if *table.TableIdentity != "" {
@@ -137,29 +192,116 @@ func (t *AuthServiceImpl) RefreshTableConfig() ([]domain.TableConfig, error) {
 		}
 	}
 
-	t.mutex.Lock()
-	tableCfg = newTables
-	t.mutex.Unlock()
+	setTableConfigs(newTables)
+
+	// 开启事件回调时，订阅各表格的记录变更事件
+	if t.eventCfg.VerificationToken != "" {
+		t.subscribeTables(tables)
+	}
 
 	return tables, nil
 }
 
-func (t *AuthServiceImpl) GetTableConfig(tableIdentity *string) (domain.TableConfig, error) {
-	t.mutex.RLock()
-	defer t.mutex.RUnlock()
+// parseLarkReceivers 解析基础配置表中的飞书卡片接收者，多个接收者以逗号或换行分隔。
+// 每项格式为 type:id，省略 type 时按 ID 前缀推断：ou_ 为 open_id，on_ 为 union_id，包含 @ 为 email，其余为 chat_id
+func parseLarkReceivers(s string) []domain.LarkReceiver {
+	var receivers []domain.LarkReceiver
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '，' || r == '\n' || r == '\r'
+	}) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
 
+		if typ, id, ok := strings.Cut(item, ":"); ok {
+			receivers = append(receivers, domain.LarkReceiver{Type: strings.TrimSpace(typ), ID: strings.TrimSpace(id)})
+			continue
+		}
+
+		typ := "chat_id"
+		switch {
+		case strings.HasPrefix(item, "ou_"):
+			typ = "open_id"
+		case strings.HasPrefix(item, "on_"):
+			typ = "union_id"
+		case strings.Contains(item, "@"):
+			typ = "email"
+		}
+		receivers = append(receivers, domain.LarkReceiver{Type: typ, ID: item})
+	}
+
+	return receivers
+}
+
+// subscribeTables 订阅多维表格的记录变更事件，同一个多维表格只订阅一次
+func (t *AuthServiceImpl) subscribeTables(tables []domain.TableConfig) {
+	for _, table := range tables {
+		if table.TableToken == nil || *table.TableToken == "" {
+			continue
+		}
+
+		t.mutex.RLock()
+		_, ok := t.subscribed[*table.TableToken]
+		t.mutex.RUnlock()
+		if ok {
+			continue
+		}
+
+		req := larkdrive.NewSubscribeFileReqBuilder().
+			FileToken(*table.TableToken).
+			FileType("bitable").
+			Build()
+
+		resp, err := t.c.SubscribeFile(context.Background(), req)
+		if err != nil {
+			t.log.Error("SubscribeFile 调用失败",
+				logger.String("table_identity", *table.TableIdentity),
+				logger.String("error", err.Error()),
+			)
+			continue
+		}
+		if !resp.Success() {
+			t.log.Error("SubscribeFile Lark 接口错误",
+				logger.String("table_identity", *table.TableIdentity),
+				logger.String("request_id", resp.RequestId()),
+				logger.String("error", larkcore.Prettify(resp.CodeError)),
+			)
+			continue
+		}
+
+		t.mutex.Lock()
+		t.subscribed[*table.TableToken] = struct{}{}
+		t.mutex.Unlock()
+	}
+}
+
+func (t *AuthServiceImpl) GetTableConfig(tableIdentity *string) (domain.TableConfig, error) {
 	// 防止传入 nil 指针引起 panic
 	if tableIdentity == nil {
 		return domain.TableConfig{}, errs.TableIdentifyNotFoundError(fmt.Errorf("table identity is nil"))
 	}
 
-	table, exists := tableCfg[*tableIdentity]
+	table, exists := getTableConfig(*tableIdentity)
 	if !exists {
 		return domain.TableConfig{}, errs.TableIdentifyNotFoundError(fmt.Errorf("table identity %s not found", *tableIdentity))
 	}
 	return table, nil
 }
 
+// GetTableConfigByToken 根据多维表格 token 和数据表 ID 查找表格配置，用于处理飞书事件回调
+func (t *AuthServiceImpl) GetTableConfigByToken(tableToken, tableID string) (domain.TableConfig, error) {
+	for _, table := range listTableConfigs() {
+		if table.TableToken == nil || table.TableID == nil {
+			continue
+		}
+		if *table.TableToken == tableToken && *table.TableID == tableID {
+			return table, nil
+		}
+	}
+	return domain.TableConfig{}, errs.TableIdentifyNotFoundError(fmt.Errorf("table not found: %s/%s", tableToken, tableID))
+}
+
 func (t *AuthServiceImpl) GetTenantToken() string {
 	t.mutex.RLock()
 	defer t.mutex.RUnlock()
@@ -239,11 +381,13 @@ func (t *AuthServiceImpl) startTenantTokenRefresher() {
 	// 后台定时刷新
 	ticker := time.NewTicker(TenantRefreshInterval)
 
-	go func() {
+	t.life.Go("tenant token refresher", func(ctx context.Context) {
 		defer ticker.Stop()
 
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case <-ticker.C:
 				if _, err := retry.Retry(t.refreshTenantToken); err != nil {
 					t.log.Error(
@@ -253,19 +397,22 @@ func (t *AuthServiceImpl) startTenantTokenRefresher() {
 				}
 			}
 		}
-	}()
+	})
 }
 
 func (t *AuthServiceImpl) startNotifiableTableScanner() {
-	ticker := time.NewTicker(NoticeRefreshInterval)
 	// 生产者，定时扫描需要发送通知的表，并将其放入 noticeCh 中
-	go func() {
+	// 只在主节点上运行，避免多个副本重复发送通知
+	t.elector.Go("notifiable table scanner", func(ctx context.Context) {
+		ticker := time.NewTicker(NoticeRefreshInterval)
 		defer ticker.Stop()
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case <-ticker.C:
-				t.mutex.RLock()
-				for tableID, table := range tableCfg {
+				for _, table := range listTableConfigs() {
+					tableID := *table.TableIdentity
 					if !table.Notice {
 						continue
 					}
@@ -282,34 +429,95 @@ func (t *AuthServiceImpl) startNotifiableTableScanner() {
 						)
 					}
 				}
-				t.mutex.RUnlock()
 			}
 		}
-	}()
+	})
 }
 
 func (t *AuthServiceImpl) startSyncTableScanner() {
-	ticker := time.NewTicker(SyncRefreshInterval)
-	// 生产者，定时扫描需要同步的表，并将其放入 syncTableCh 中
-	go func() {
+	// 生产者，定时扫描需要同步的表，并将其放入同步队列中，只在主节点上运行
+	t.elector.Go("sync table scanner", func(ctx context.Context) {
+		ticker := time.NewTicker(SyncRefreshInterval)
 		defer ticker.Stop()
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case <-ticker.C:
-				t.mutex.RLock()
-				for tableID, table := range tableCfg {
-					select {
-					case syncTableCh <- table:
+				for _, table := range listTableConfigs() {
+					tableID := *table.TableIdentity
+					err := pushSyncMsg(t.queue, SyncMsg{
+						Kind:        SyncKindTable,
+						TableConfig: table,
+					})
+					switch {
+					case err == nil:
 						t.log.Info("sync table queued",
 							logger.String("table_id", tableID))
-					default:
-						// ⚠️ channel 满了，直接丢，避免阻塞
-						t.log.Warn("sync table channel full, skip table",
+					case isSyncQueueFull(err):
+						// ⚠️ 队列满了，直接丢，等待下一轮扫描
+						t.log.Warn("sync queue full, skip table",
 							logger.String("table_id", tableID))
+					default:
+						t.log.Error("sync table enqueue failed",
+							logger.String("table_id", tableID),
+							logger.String("error", err.Error()))
 					}
+
+					// 反馈记录表格同时对账飞书中已删除的记录
+					if bytes.Contains([]byte(*table.TableIdentity), []byte("-faq")) {
+						continue
+					}
+					err = pushSyncMsg(t.queue, SyncMsg{
+						Kind:        SyncKindReconcile,
+						TableConfig: table,
+					})
+					if err != nil {
+						t.log.Warn("reconcile table enqueue failed",
+							logger.String("table_id", tableID),
+							logger.String("error", err.Error()))
+					}
+				}
+			}
+		}
+	})
+}
+
+// startIncrementalSyncScanner 定时为反馈记录表格投递增量同步任务，FAQ 表格由 startSyncTableScanner 负责，只在主节点上运行
+func (t *AuthServiceImpl) startIncrementalSyncScanner() {
+	t.elector.Go("incremental sync scanner", func(ctx context.Context) {
+		ticker := time.NewTicker(time.Duration(t.syncCfg.IncrementalInterval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			for _, table := range listTableConfigs() {
+				tableID := *table.TableIdentity
+				if bytes.Contains([]byte(*table.TableIdentity), []byte("-faq")) {
+					continue
+				}
+				err := pushSyncMsg(t.queue, SyncMsg{
+					Kind:        SyncKindIncremental,
+					TableConfig: table,
+				})
+				switch {
+				case err == nil:
+					t.log.Info("incremental sync table queued",
+						logger.String("table_id", tableID))
+				case isSyncQueueFull(err):
+					// 队列满了，直接丢，等待下一轮扫描
+					t.log.Warn("sync queue full, skip incremental sync table",
+						logger.String("table_id", tableID))
+				default:
+					t.log.Error("incremental sync table enqueue failed",
+						logger.String("table_id", tableID),
+						logger.String("error", err.Error()))
 				}
-				t.mutex.RUnlock()
 			}
 		}
-	}()
+	})
 }
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+)
+
+const (
+	digestPollInterval = time.Minute
+	digestGracePeriod  = time.Hour // 错过计划时间超过该时长（如服务停机）时跳过本次，等待下一次
+	digestOldestLimit  = 5         // 摘要中展示的最早未完成记录数
+	digestContentLen   = 30        // 摘要中反馈内容的展示长度（字符）
+	digestSendTimeout  = 10 * time.Second
+)
+
+//go:generate mockgen -destination=./mock/digest_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service DigestService
+type DigestService interface {
+	BuildDigest(since, until time.Time, tableConfig *domain.TableConfig) (*domain.Digest, error)
+	SendDigest(tableConfig *domain.TableConfig) error
+}
+
+type DigestServiceImpl struct {
+	c         lark.Client
+	log       logger.Logger
+	lc        *config.LarkMessage
+	digestDAO dao.DigestDAO
+}
+
+func NewDigestService(c lark.Client, log logger.Logger, lc *config.LarkMessage, digestDAO dao.DigestDAO, elector *LeaderElector) DigestService {
+	d := &DigestServiceImpl{
+		c:         c,
+		log:       log,
+		lc:        lc,
+		digestDAO: digestDAO,
+	}
+
+	// 按各表格配置的计划发送摘要卡片，只在主节点上运行
+	elector.Go("digest scheduler", func(ctx context.Context) {
+		ticker := time.NewTicker(digestPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.runDueDigests(time.Now())
+			}
+		}
+	})
+
+	return d
+}
+
+// digestSchedule 摘要的发送计划，格式为 "daily HH:MM" 或 "weekly mon HH:MM"，使用服务器本地时区
+type digestSchedule struct {
+	days    int          // 发送周期的天数，按日历天计算，不受夏令时切换影响
+	weekday time.Weekday // 仅 weekly 使用
+	hour    int
+	minute  int
+}
+
+var digestWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func parseDigestSchedule(s string) (digestSchedule, error) {
+	var sched digestSchedule
+
+	parts := strings.Fields(strings.ToLower(s))
+	var clock string
+	switch {
+	case len(parts) == 2 && parts[0] == "daily":
+		sched.days = 1
+		clock = parts[1]
+	case len(parts) == 3 && parts[0] == "weekly":
+		wd, ok := digestWeekdays[parts[1][:min(3, len(parts[1]))]]
+		if !ok {
+			return sched, fmt.Errorf("invalid weekday: %s", parts[1])
+		}
+		sched.days = 7
+		sched.weekday = wd
+		clock = parts[2]
+	default:
+		return sched, fmt.Errorf("invalid digest schedule: %q", s)
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return sched, fmt.Errorf("invalid digest time: %w", err)
+	}
+	sched.hour, sched.minute = t.Hour(), t.Minute()
+
+	return sched, nil
+}
+
+// last 返回不晚于 now 的最近一次计划时间
+func (s digestSchedule) last(now time.Time) time.Time {
+	t := time.Date(now.Year(), now.Month(), now.Day(), s.hour, s.minute, 0, 0, now.Location())
+	if s.days == 7 {
+		t = t.AddDate(0, 0, -((int(now.Weekday()) - int(s.weekday) + 7) % 7))
+	}
+	if t.After(now) {
+		t = s.prev(t)
+	}
+	return t
+}
+
+// prev 返回 scheduledAt 的上一次计划时间，即本次摘要统计区间的起点
+func (s digestSchedule) prev(scheduledAt time.Time) time.Time {
+	return scheduledAt.AddDate(0, 0, -s.days)
+}
+
+// runDueDigests 为到达计划时间的表格发送摘要，每次计划只发送一次，发送失败不补发
+func (d *DigestServiceImpl) runDueDigests(now time.Time) {
+	for _, table := range listTableConfigs() {
+		if table.DigestSchedule == "" {
+			continue
+		}
+
+		sched, err := parseDigestSchedule(table.DigestSchedule)
+		if err != nil {
+			d.log.Warn("digest schedule invalid, skip table",
+				logger.String("table_identity", *table.TableIdentity),
+				logger.String("error", err.Error()),
+			)
+			continue
+		}
+
+		scheduledAt := sched.last(now)
+		if now.Sub(scheduledAt) > digestGracePeriod {
+			continue
+		}
+
+		claimed, err := d.digestDAO.ClaimDigestRun(*table.TableIdentity, scheduledAt)
+		if err != nil {
+			d.log.Error("ClaimDigestRun 认领摘要发送失败",
+				logger.String("error", err.Error()),
+				logger.String("table_identity", *table.TableIdentity),
+			)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := d.sendDigest(sched.prev(scheduledAt), scheduledAt, &table); err != nil {
+			d.log.Error("send digest failed",
+				logger.String("table_identity", *table.TableIdentity),
+				logger.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// SendDigest 立即发送表格的摘要，统计区间为截至当前的一个计划周期，未配置计划时为最近一天，不影响定时发送
+func (d *DigestServiceImpl) SendDigest(tableConfig *domain.TableConfig) error {
+	sched := digestSchedule{days: 1}
+	if s, err := parseDigestSchedule(tableConfig.DigestSchedule); err == nil {
+		sched = s
+	}
+
+	now := time.Now()
+	return d.sendDigest(sched.prev(now), now, tableConfig)
+}
+
+func (d *DigestServiceImpl) sendDigest(since, until time.Time, tableConfig *domain.TableConfig) error {
+	digest, err := d.BuildDigest(since, until, tableConfig)
+	if err != nil {
+		return err
+	}
+
+	card, err := json.Marshal(digestCard(digest))
+	if err != nil {
+		return errs.SerializationError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), digestSendTimeout)
+	defer cancel()
+
+	var failed int
+	for _, r := range d.digestReceivers(tableConfig) {
+		req := larkim.NewCreateMessageReqBuilder().
+			ReceiveIdType(r.Type).
+			Body(larkim.NewCreateMessageReqBodyBuilder().
+				ReceiveId(r.ID).
+				MsgType("interactive").
+				Content(string(card)).
+				Build()).
+			Build()
+
+		resp, err := d.c.SendNotice(ctx, req)
+		if err != nil {
+			failed++
+			d.log.Error("SendDigest failed",
+				logger.String("receive_id", r.ID),
+				logger.String("error", err.Error()),
+			)
+			continue
+		}
+		if !resp.Success() {
+			failed++
+			d.log.Error("SendDigest Lark API error",
+				logger.String("receive_id", r.ID),
+				logger.String("request_id", resp.RequestId()),
+				logger.String("error", larkcore.Prettify(resp.CodeError)),
+			)
+		}
+	}
+
+	if failed > 0 {
+		return errs.LarkMessagePartialFailureError(fmt.Errorf("send digest failed: %d", failed))
+	}
+	return nil
+}
+
+// BuildDigest 统计表格在 [since, until) 内的摘要，FAQ 表格统计投票，其余表格统计反馈记录
+func (d *DigestServiceImpl) BuildDigest(since, until time.Time, tableConfig *domain.TableConfig) (*domain.Digest, error) {
+	digest := &domain.Digest{
+		TableIdentify: *tableConfig.TableIdentity,
+		Since:         since,
+		Until:         until,
+	}
+	if tableConfig.TableName != nil {
+		digest.TableName = *tableConfig.TableName
+	}
+
+	var err error
+	if strings.Contains(digest.TableIdentify, "-faq") {
+		digest.IsFAQ = true
+		digest.VotedResolved, digest.VotedUnresolved, err = d.digestDAO.CountUpdatedVotes(digest.TableIdentify, since, until)
+		if err != nil {
+			return nil, d.digestQueryError("CountUpdatedVotes", digest.TableIdentify, err)
+		}
+		return digest, nil
+	}
+
+	digest.NewCount, err = d.digestDAO.CountNewRecords(digest.TableIdentify, since, until)
+	if err != nil {
+		return nil, d.digestQueryError("CountNewRecords", digest.TableIdentify, err)
+	}
+
+	pending, err := d.digestDAO.CountPendingByProgress(digest.TableIdentify)
+	if err != nil {
+		return nil, d.digestQueryError("CountPendingByProgress", digest.TableIdentify, err)
+	}
+	for _, p := range pending {
+		digest.PendingCount = append(digest.PendingCount, domain.DigestProgressCount{Progress: p.Progress, Count: p.Count})
+	}
+
+	oldest, err := d.digestDAO.ListOldestPending(digest.TableIdentify, digestOldestLimit)
+	if err != nil {
+		return nil, d.digestQueryError("ListOldestPending", digest.TableIdentify, err)
+	}
+	for _, r := range oldest {
+		item := domain.DigestItem{
+			RecordID:  *r.RecordID,
+			CreatedAt: r.CreatedAt,
+		}
+		item.Content, _ = r.Record["反馈内容"].(string)
+		if r.Progress != nil {
+			item.Progress = *r.Progress
+		}
+		if r.ShareUrl != nil {
+			item.ShareURL = *r.ShareUrl
+		}
+		digest.Oldest = append(digest.Oldest, item)
+	}
+
+	return digest, nil
+}
+
+func (d *DigestServiceImpl) digestQueryError(op, tableIdentify string, err error) error {
+	d.log.Error(op+" 数据库查询失败",
+		logger.String("error", err.Error()),
+		logger.String("table_identity", tableIdentify),
+	)
+	return errs.GetDigestError(err)
+}
+
+// digestReceivers 获取摘要的接收者，依次使用表格的摘要接收者、新反馈卡片接收者和全局配置
+func (d *DigestServiceImpl) digestReceivers(tableConfig *domain.TableConfig) []domain.LarkReceiver {
+	if len(tableConfig.DigestReceivers) > 0 {
+		return tableConfig.DigestReceivers
+	}
+	if len(tableConfig.LarkReceivers) > 0 {
+		return tableConfig.LarkReceivers
+	}
+
+	receivers := make([]domain.LarkReceiver, 0, len(d.lc.ReceiveIDs))
+	for _, r := range d.lc.ReceiveIDs {
+		receivers = append(receivers, domain.LarkReceiver{Type: r.Type, ID: r.ID})
+	}
+	return receivers
+}
+
+// digestCard 生成摘要的飞书消息卡片
+func digestCard(digest *domain.Digest) map[string]any {
+	const layout = "01-02 15:04"
+
+	title := digest.TableName
+	if title == "" {
+		title = digest.TableIdentify
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**统计区间**：%s ~ %s\n", digest.Since.Format(layout), digest.Until.Format(layout))
+
+	if digest.IsFAQ {
+		// 统计的是区间内投出或改投的票数，不是票数的净变化
+		fmt.Fprintf(&b, "**区间内投票（含改投）**：已解决 %d 票，未解决 %d 票\n", digest.VotedResolved, digest.VotedUnresolved)
+	} else {
+		fmt.Fprintf(&b, "**新增反馈**：%d 条\n", digest.NewCount)
+
+		b.WriteString("**待处理**：")
+		if len(digest.PendingCount) == 0 {
+			b.WriteString("无")
+		}
+		for i, p := range digest.PendingCount {
+			if i > 0 {
+				b.WriteString("，")
+			}
+			progress := p.Progress
+			if progress == "" {
+				progress = "未填写"
+			}
+			fmt.Fprintf(&b, "%s %d 条", progress, p.Count)
+		}
+		b.WriteString("\n")
+
+		if len(digest.Oldest) > 0 {
+			b.WriteString("**最早未解决**：\n")
+		}
+		for i, item := range digest.Oldest {
+			content := []rune(item.Content)
+			if len(content) > digestContentLen {
+				content = append(content[:digestContentLen], []rune("……")...)
+			}
+			text := string(content)
+			if text == "" {
+				text = item.RecordID
+			}
+			if item.ShareURL != "" {
+				text = fmt.Sprintf("[%s](%s)", text, item.ShareURL)
+			}
+			fmt.Fprintf(&b, "%d. %s · 提交于 %s\n", i+1, text, item.CreatedAt.Format(layout))
+		}
+	}
+
+	return map[string]any{
+		"config": map[string]any{"wide_screen_mode": true},
+		"header": map[string]any{
+			"template": "blue",
+			"title":    map[string]any{"tag": "plain_text", "content": title + " 反馈摘要"},
+		},
+		"elements": []any{
+			map[string]any{"tag": "markdown", "content": b.String()},
+		},
+	}
+}
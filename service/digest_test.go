@@ -0,0 +1,167 @@
+package service
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/golang/mock/gomock"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDigestSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    digestSchedule
+		wantErr bool
+	}{
+		{name: "daily", s: "daily 09:30", want: digestSchedule{days: 1, hour: 9, minute: 30}},
+		{name: "daily midnight", s: "daily 00:00", want: digestSchedule{days: 1}},
+		{name: "case and spaces", s: "  Daily   23:59 ", want: digestSchedule{days: 1, hour: 23, minute: 59}},
+		{name: "weekly", s: "weekly mon 08:00", want: digestSchedule{days: 7, weekday: time.Monday, hour: 8}},
+		{name: "weekly full weekday name", s: "weekly Sunday 18:15", want: digestSchedule{days: 7, weekday: time.Sunday, hour: 18, minute: 15}},
+		{name: "empty", s: "", wantErr: true},
+		{name: "missing time", s: "daily", wantErr: true},
+		{name: "unknown period", s: "monthly 09:00", wantErr: true},
+		{name: "weekly without weekday", s: "weekly 09:00", wantErr: true},
+		{name: "unknown weekday", s: "weekly xyz 09:00", wantErr: true},
+		{name: "short weekday", s: "weekly mo 09:00", wantErr: true},
+		{name: "hour out of range", s: "daily 24:00", wantErr: true},
+		{name: "minute out of range", s: "daily 09:60", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDigestSchedule(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDigestScheduleLast(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	at := func(day, hour, minute int) time.Time {
+		// 2026-06-01 是星期一
+		return time.Date(2026, time.June, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name     string
+		schedule string
+		now      time.Time
+		want     time.Time
+	}{
+		{name: "daily before time", schedule: "daily 09:00", now: at(3, 8, 59), want: at(2, 9, 0)},
+		{name: "daily exactly at time", schedule: "daily 09:00", now: at(3, 9, 0), want: at(3, 9, 0)},
+		{name: "daily after time", schedule: "daily 09:00", now: at(3, 23, 59), want: at(3, 9, 0)},
+		{name: "daily midnight just after", schedule: "daily 00:00", now: at(3, 0, 1), want: at(3, 0, 0)},
+		{name: "daily midnight end of day", schedule: "daily 00:00", now: at(3, 23, 59), want: at(3, 0, 0)},
+		{name: "daily late schedule after midnight", schedule: "daily 23:30", now: at(3, 0, 10), want: at(2, 23, 30)},
+		{name: "daily across month", schedule: "daily 09:00", now: at(1, 8, 0), want: time.Date(2026, time.May, 31, 9, 0, 0, 0, loc)},
+		{name: "weekly same day before time", schedule: "weekly mon 09:00", now: at(8, 8, 0), want: at(1, 9, 0)},
+		{name: "weekly same day at time", schedule: "weekly mon 09:00", now: at(8, 9, 0), want: at(8, 9, 0)},
+		{name: "weekly next day", schedule: "weekly mon 09:00", now: at(9, 0, 1), want: at(8, 9, 0)},
+		{name: "weekly day before", schedule: "weekly mon 09:00", now: at(7, 23, 59), want: at(1, 9, 0)},
+		{name: "weekly sunday night seen on monday", schedule: "weekly sun 23:00", now: at(8, 0, 30), want: at(7, 23, 0)},
+		{name: "weekly saturday midnight", schedule: "weekly sat 00:00", now: at(6, 0, 0), want: at(6, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := parseDigestSchedule(tt.schedule)
+			require.NoError(t, err)
+			got := sched.last(tt.now)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			assert.False(t, got.After(tt.now))
+		})
+	}
+}
+
+// 夏令时切换当天按日历天回退，计划时间保持在当地的同一时刻
+func TestDigestScheduleAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	sched, err := parseDigestSchedule("daily 09:00")
+	require.NoError(t, err)
+
+	// 2026-03-08 凌晨开始夏令时，当天只有 23 小时
+	now := time.Date(2026, time.March, 8, 8, 0, 0, 0, loc)
+	got := sched.last(now)
+	assert.True(t, time.Date(2026, time.March, 7, 9, 0, 0, 0, loc).Equal(got), "got %s", got)
+
+	scheduledAt := time.Date(2026, time.March, 8, 9, 0, 0, 0, loc)
+	since := sched.prev(scheduledAt)
+	assert.True(t, time.Date(2026, time.March, 7, 9, 0, 0, 0, loc).Equal(since), "got %s", since)
+	assert.Equal(t, 23*time.Hour, scheduledAt.Sub(since))
+}
+
+// 到达计划时间且在宽限期内时认领并发送一次摘要，统计区间为上一次计划时间到本次计划时间
+func TestRunDueDigests(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	scheduledAt := time.Date(2026, time.June, 8, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		claimed   bool
+		wantClaim bool
+		wantSend  bool
+	}{
+		{name: "due and claimed", now: scheduledAt.Add(10 * time.Minute), claimed: true, wantClaim: true, wantSend: true},
+		{name: "already sent by another instance", now: scheduledAt.Add(10 * time.Minute), wantClaim: true},
+		{name: "missed beyond grace period", now: scheduledAt.Add(digestGracePeriod + time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := larkMock.NewMockClient(ctrl)
+			digestDAO := daoMock.NewMockDigestDAO(ctrl)
+			d := &DigestServiceImpl{c: client, log: newTestLogger(), digestDAO: digestDAO}
+
+			tc := newTestTableConfig()
+			tc.DigestSchedule = "weekly mon 09:00"
+			tc.DigestReceivers = []domain.LarkReceiver{{Type: "chat_id", ID: "oc_admin"}}
+			setTestTables(t, tc)
+
+			if tt.wantClaim {
+				digestDAO.EXPECT().ClaimDigestRun(*tc.TableIdentity, scheduledAt).Return(tt.claimed, nil)
+			}
+			if tt.wantSend {
+				since := scheduledAt.AddDate(0, 0, -7)
+				digestDAO.EXPECT().CountNewRecords(*tc.TableIdentity, since, scheduledAt).Return(int64(3), nil)
+				digestDAO.EXPECT().CountPendingByProgress(*tc.TableIdentity).Return(nil, nil)
+				digestDAO.EXPECT().ListOldestPending(*tc.TableIdentity, digestOldestLimit).Return(nil, nil)
+				// 每个接收者发送一次
+				client.EXPECT().SendNotice(gomock.Any(), gomock.Any()).Return(&larkim.CreateMessageResp{}, nil)
+			}
+
+			d.runDueDigests(tt.now)
+		})
+	}
+}
+
+func TestDigestCardFAQVotes(t *testing.T) {
+	card := digestCard(&domain.Digest{
+		TableIdentify:   "mock-faq",
+		IsFAQ:           true,
+		VotedResolved:   4,
+		VotedUnresolved: 1,
+	})
+
+	content := card["elements"].([]any)[0].(map[string]any)["content"].(string)
+	assert.Contains(t, content, "已解决 4 票，未解决 1 票")
+	assert.NotContains(t, content, "+")
+}
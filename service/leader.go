@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	leaderLeaseName     = "scheduler"      // 定时任务共用的租约名称
+	leaderLeaseTTL      = 30 * time.Second // 租约有效期，主节点失联后最多经过该时间由其他实例接管
+	leaderRenewInterval = 10 * time.Second // 抢占与续期的间隔
+)
+
+var leaderGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "feedback_scheduler_leader",
+		Help: "Whether this pod holds the scheduler leader lease (1 = leader)",
+	},
+	[]string{"pod"},
+)
+
+// LeaderElector 多副本部署时选出一个主节点运行定时扫描等任务，避免重复执行
+type LeaderElector struct {
+	lease  cache.LeaderLease
+	log    logger.Logger
+	life   *lifecycle.Lifecycle
+	pod    string
+	holder string // 租约持有者标识，同一主机上的多个进程也能区分
+
+	mu        sync.Mutex
+	leaderCtx context.Context // 当选期间有效，失去租约或退出时取消
+	cancel    context.CancelFunc
+	changed   chan struct{} // 当选时关闭，用于唤醒等待中的任务
+	lastRenew time.Time
+}
+
+func NewLeaderElector(lease cache.LeaderLease, log logger.Logger, life *lifecycle.Lifecycle, reg *prometheus.Registry) *LeaderElector {
+	pod := syncConsumerName()
+	e := &LeaderElector{
+		lease:   lease,
+		log:     log,
+		life:    life,
+		pod:     pod,
+		holder:  pod + ":" + uuid.NewString()[:8],
+		changed: make(chan struct{}),
+	}
+
+	reg.MustRegister(leaderGauge)
+	leaderGauge.WithLabelValues(pod).Set(0)
+
+	life.Go("leader election", e.run)
+
+	return e
+}
+
+// IsLeader 当前实例是否持有租约
+func (e *LeaderElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leaderCtx != nil
+}
+
+// Go 启动一个只在主节点上运行的后台任务
+// 当选后以 leaderCtx 调用 fn，失去租约时 leaderCtx 被取消，fn 返回后重新等待当选
+func (e *LeaderElector) Go(name string, fn func(ctx context.Context)) {
+	e.life.Go(name, func(ctx context.Context) {
+		for {
+			leaderCtx := e.waitLeader(ctx)
+			if leaderCtx == nil {
+				return
+			}
+
+			e.log.Info("leader task started",
+				logger.String("task", name),
+				logger.String("holder", e.holder),
+			)
+			fn(leaderCtx)
+		}
+	})
+}
+
+// waitLeader 阻塞到当前实例当选，ctx 取消时返回 nil
+func (e *LeaderElector) waitLeader(ctx context.Context) context.Context {
+	for {
+		e.mu.Lock()
+		leaderCtx, changed := e.leaderCtx, e.changed
+		e.mu.Unlock()
+
+		if leaderCtx != nil && leaderCtx.Err() == nil {
+			return leaderCtx
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func (e *LeaderElector) run(ctx context.Context) {
+	ticker := time.NewTicker(leaderRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.tryAcquire(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.stepDown("shutdown")
+			// 主动释放租约，其他实例无需等待过期即可接管
+			releaseCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			if err := e.lease.Release(releaseCtx, leaderLeaseName, e.holder); err != nil {
+				e.log.Error("release leader lease failed",
+					logger.String("error", err.Error()),
+				)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *LeaderElector) tryAcquire(ctx context.Context) {
+	ok, err := e.lease.Acquire(ctx, leaderLeaseName, e.holder, leaderLeaseTTL)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		e.log.Error("acquire leader lease failed",
+			logger.String("error", err.Error()),
+		)
+		// 无法确认租约时，在租约可能过期之前主动让出，避免与新主节点同时运行
+		e.mu.Lock()
+		expired := e.leaderCtx != nil && time.Since(e.lastRenew) >= leaderLeaseTTL-leaderRenewInterval
+		e.mu.Unlock()
+		if expired {
+			e.stepDown("renew failed")
+		}
+		return
+	}
+
+	if !ok {
+		e.stepDown("lease lost")
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lastRenew = time.Now()
+	if e.leaderCtx != nil {
+		return
+	}
+
+	e.leaderCtx, e.cancel = context.WithCancel(ctx)
+	close(e.changed)
+	e.changed = make(chan struct{})
+	leaderGauge.WithLabelValues(e.pod).Set(1)
+	e.log.Info("became leader",
+		logger.String("holder", e.holder),
+	)
+}
+
+func (e *LeaderElector) stepDown(reason string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.leaderCtx == nil {
+		return
+	}
+
+	e.cancel()
+	e.leaderCtx, e.cancel = nil, nil
+	leaderGauge.WithLabelValues(e.pod).Set(0)
+	e.log.Warn("stepped down from leader",
+		logger.String("holder", e.holder),
+		logger.String("reason", reason),
+	)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLease(t *testing.T) (*miniredis.Miniredis, cache.LeaderLease) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return s, cache.NewLeaderLease(client)
+}
+
+// newTestElector 不启动选举循环，由测试调用 tryAcquire 控制抢占与续期的时机
+func newTestElector(t *testing.T, lease cache.LeaderLease, pod string) *LeaderElector {
+	life := lifecycle.New()
+	e := &LeaderElector{
+		lease:   lease,
+		log:     newTestLogger(),
+		life:    life,
+		pod:     pod,
+		holder:  pod + ":test",
+		changed: make(chan struct{}),
+	}
+	t.Cleanup(func() {
+		// 测试中的 leaderCtx 不随 lifecycle 取消，先让出以结束主节点任务
+		e.stepDown("shutdown")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, life.Shutdown(ctx))
+	})
+	return e
+}
+
+func leaderGaugeValue(pod string) float64 {
+	return testutil.ToFloat64(leaderGauge.WithLabelValues(pod))
+}
+
+// 当选后启动主节点任务并上报指标，续期成功保持主节点身份
+func TestLeaderElectorAcquireAndRenew(t *testing.T) {
+	_, lease := newTestLease(t)
+	e := newTestElector(t, lease, "pod-acquire")
+	ctx := context.Background()
+
+	started := make(chan context.Context, 1)
+	e.Go("task", func(ctx context.Context) {
+		started <- ctx
+		<-ctx.Done()
+	})
+
+	assert.False(t, e.IsLeader())
+	e.tryAcquire(ctx)
+	assert.True(t, e.IsLeader())
+	assert.Equal(t, float64(1), leaderGaugeValue("pod-acquire"))
+
+	var taskCtx context.Context
+	select {
+	case taskCtx = <-started:
+	case <-time.After(time.Second):
+		t.Fatal("leader task not started")
+	}
+
+	// 续期不会重新启动任务
+	e.tryAcquire(ctx)
+	assert.True(t, e.IsLeader())
+	assert.NoError(t, taskCtx.Err())
+	select {
+	case <-started:
+		t.Fatal("leader task started twice")
+	default:
+	}
+}
+
+// 续期失败时在租约可能过期前让出主节点，取消正在运行的任务
+func TestLeaderElectorStepDownOnRenewFailure(t *testing.T) {
+	s, lease := newTestLease(t)
+	e := newTestElector(t, lease, "pod-renew-failure")
+	ctx := context.Background()
+
+	e.tryAcquire(ctx)
+	require.True(t, e.IsLeader())
+	e.mu.Lock()
+	leaderCtx := e.leaderCtx
+	e.mu.Unlock()
+
+	s.Close()
+
+	// 刚续期过，租约仍然有效，暂不让出
+	e.tryAcquire(ctx)
+	assert.True(t, e.IsLeader())
+
+	// 距上次续期已接近租约有效期，无法确认租约时让出
+	e.mu.Lock()
+	e.lastRenew = time.Now().Add(-(leaderLeaseTTL - leaderRenewInterval))
+	e.mu.Unlock()
+	e.tryAcquire(ctx)
+	assert.False(t, e.IsLeader())
+	assert.Error(t, leaderCtx.Err())
+	assert.Equal(t, float64(0), leaderGaugeValue("pod-renew-failure"))
+}
+
+// 主节点停止续期后，租约过期由另一个实例接管，原主节点下次续期时发现租约已丢失并让出
+func TestLeaderElectorFailover(t *testing.T) {
+	s, lease := newTestLease(t)
+	a := newTestElector(t, lease, "pod-failover-a")
+	b := newTestElector(t, lease, "pod-failover-b")
+	ctx := context.Background()
+
+	a.tryAcquire(ctx)
+	b.tryAcquire(ctx)
+	require.True(t, a.IsLeader())
+	require.False(t, b.IsLeader())
+	assert.Equal(t, float64(0), leaderGaugeValue("pod-failover-b"))
+
+	s.FastForward(leaderLeaseTTL)
+	b.tryAcquire(ctx)
+	assert.True(t, b.IsLeader())
+	assert.Equal(t, float64(1), leaderGaugeValue("pod-failover-b"))
+
+	a.tryAcquire(ctx)
+	assert.False(t, a.IsLeader())
+	assert.Equal(t, float64(0), leaderGaugeValue("pod-failover-a"))
+}
+
+// 多个实例同时抢占时只有一个当选，主节点释放租约后其他实例立即接管
+func TestLeaderElectorContention(t *testing.T) {
+	_, lease := newTestLease(t)
+	electors := []*LeaderElector{
+		newTestElector(t, lease, "pod-contend-a"),
+		newTestElector(t, lease, "pod-contend-b"),
+		newTestElector(t, lease, "pod-contend-c"),
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, e := range electors {
+		wg.Add(1)
+		go func(e *LeaderElector) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				e.tryAcquire(ctx)
+			}
+		}(e)
+	}
+	wg.Wait()
+
+	var leader *LeaderElector
+	for _, e := range electors {
+		if e.IsLeader() {
+			require.Nil(t, leader, "more than one leader")
+			leader = e
+		}
+	}
+	require.NotNil(t, leader)
+
+	leader.stepDown("shutdown")
+	require.NoError(t, lease.Release(ctx, leaderLeaseName, leader.holder))
+	for _, e := range electors {
+		if e != leader {
+			e.tryAcquire(ctx)
+			assert.True(t, e.IsLeader())
+			break
+		}
+	}
+}
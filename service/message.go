@@ -1,15 +1,10 @@
 package service
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
@@ -19,109 +14,238 @@ import (
 	"github.com/muxi-Infra/FeedBack-Backend/errs"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-var (
-	errCount atomic.Int32
+const (
+	noticeTriggerPollInterval = 2 * time.Second // 主节点检查手动触发的通知任务的间隔
+	noticeTriggerBatchSize    = 10              // 每次取出的手动触发任务数
+)
+
+var larkMessageCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "feedback_lark_message_total",
+		Help: "Total number of new-feedback Lark cards sent, by receiver type and result",
+	},
+	[]string{"receive_type", "result"},
 )
 
 //go:generate mockgen -destination=./mock/message_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service MessageService
 type MessageService interface {
-	SendLarkNotification(tableName, content, url string) error
+	SendLarkNotification(content, url string, receivers []domain.LarkReceiver, tableConfig *domain.TableConfig) (domain.LarkDeliveryReport, error)
 	TriggerNotification(tableIdentify string) error
 	GetPendingNotifications(tableConfig *domain.TableConfig) ([]domain.NotificationRecipient, error)
-	SendCCNUBoxNotification(studentID, recordID *string) error
-	MarkRecordNoticed(recordID *string, tableConfig *domain.TableConfig) error
+	MarkRecordNoticed(recordID *string, progress string, tableConfig *domain.TableConfig) error
+	ListFailedNotifications(recordID string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.NotificationDeliveries, error)
+	GetNotificationPreference(studentID string, tableConfig *domain.TableConfig) (*domain.NotificationPreference, error)
+	UpdateNotificationPreference(pref *domain.NotificationPreference, tableConfig *domain.TableConfig) error
+	ListNotifications(studentID string, unreadOnly bool, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.Notifications, error)
+	MarkNotificationsRead(studentID string, ids []uint64, tableConfig *domain.TableConfig) (int64, error)
+	CountUnreadNotifications(studentID string, tableConfig *domain.TableConfig) (int64, error)
 }
 
 type MessageServiceImpl struct {
-	c        lark.Client
-	log      logger.Logger
-	lc       *config.LarkMessage
-	cc       *config.CCNUBoxMessage
-	sheetDao dao.SheetDAO
+	c           lark.Client
+	log         logger.Logger
+	lc          *config.LarkMessage
+	nc          *config.NoticeConfig
+	notifiers   *NotifierRegistry
+	sheetDao    dao.SheetDAO
+	deliveryDAO dao.NotificationDeliveryDAO
+	prefDAO     dao.NotificationPreferenceDAO
+	inboxDAO    dao.NotificationDAO
+	threadDAO   dao.FeedbackMessageDAO
+	trigger     cache.NoticeTrigger
 }
 
-func NewMessageService(c lark.Client, log logger.Logger, lc *config.LarkMessage, cc *config.CCNUBoxMessage, sheetDao dao.SheetDAO) MessageService {
+func NewMessageService(c lark.Client, log logger.Logger, lc *config.LarkMessage, nc *config.NoticeConfig, notifiers *NotifierRegistry, sheetDao dao.SheetDAO, deliveryDAO dao.NotificationDeliveryDAO, prefDAO dao.NotificationPreferenceDAO, inboxDAO dao.NotificationDAO, threadDAO dao.FeedbackMessageDAO, trigger cache.NoticeTrigger, elector *LeaderElector, reg *prometheus.Registry) MessageService {
+	reg.MustRegister(larkMessageCounter)
+
 	m := &MessageServiceImpl{
-		c:        c,
-		log:      log,
-		lc:       lc,
-		cc:       cc,
-		sheetDao: sheetDao,
+		c:           c,
+		log:         log,
+		lc:          lc,
+		nc:          nc,
+		notifiers:   notifiers,
+		sheetDao:    sheetDao,
+		deliveryDAO: deliveryDAO,
+		prefDAO:     prefDAO,
+		inboxDAO:    inboxDAO,
+		threadDAO:   threadDAO,
+		trigger:     trigger,
 	}
 
-	// 消费者，监听通知通道，根据表格配置查询待通知的记录，并发送通知
-	go func() {
+	// 消费者，监听通知通道与手动触发的任务，根据表格配置查询待通知的记录，并发送通知
+	// 只在主节点上运行，避免多个副本同时查询到同一批未通知的记录而重复推送
+	elector.Go("notice consumer", func(ctx context.Context) {
+		ticker := time.NewTicker(noticeTriggerPollInterval)
+		defer ticker.Stop()
+
 		for {
-			table := <-noticeCh
-			if !table.Notice {
-				// 如果表格配置未启用通知，跳过处理
-				m.log.Warn("notification skipped for table",
-					logger.String("table_identity", *table.TableIdentity),
-				)
+			select {
+			case <-ctx.Done():
+				// 失去租约或退出时立即停止，通道中剩余的表格由主节点下一轮扫描重新投递
+				return
+			case table := <-noticeCh:
+				m.handleNoticeTable(table)
+			case <-ticker.C:
+				m.handleNoticeTriggers(ctx)
+			}
+		}
+	})
+
+	return m
+}
+
+// handleNoticeTable 查询表格中进度变化的记录和工作人员的新回复，逐条通知学生
+func (m *MessageServiceImpl) handleNoticeTable(table domain.TableConfig) {
+	if !table.Notice {
+		// 如果表格配置未启用通知，跳过处理
+		m.log.Warn("notification skipped for table",
+			logger.String("table_identity", *table.TableIdentity),
+		)
+		return
+	}
+	// 根据表格配置的通知渠道发送通知，学生设置了通知渠道时优先使用学生的设置
+	if _, ok := m.notifiers.Get(&table); !ok {
+		m.log.Error("unsupported notice channel",
+			logger.String("table_identity", *table.TableIdentity),
+			logger.String("notice_channel", noticeChannel(&table)),
+		)
+		return
+	}
+
+	m.handleProgressNotices(table)
+	m.handleReplyNotices(table)
+}
+
+// handleProgressNotices 查询表格中进度变化后待通知的记录并逐条发送通知
+func (m *MessageServiceImpl) handleProgressNotices(table domain.TableConfig) {
+	// 获取待通知的记录列表
+	recipients, err := m.GetPendingNotifications(&table)
+	if err != nil {
+		m.log.Error("get pending notifications failed",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *table.TableIdentity),
+		)
+		return
+	}
+
+	// 查询各记录最近一次的投递，失败的记录按退避时间重试
+	latest, err := m.latestDeliveries(recipients, &table)
+	if err != nil {
+		return
+	}
+	prefs, err := m.preferences(recipients, &table)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, recipient := range recipients {
+		// 站内通知不受推送偏好影响，每次进度变化写入一次
+		m.saveInbox(recipient, &table)
+
+		pref := prefs[recipient.StudentID]
+		if pref != nil && pref.OptOut {
+			// 学生不再接收通知，直接标记为已通知，进度再次变化时重新判断
+			m.log.Info("notification skipped, student opted out",
+				logger.String("table_identity", *table.TableIdentity),
+				logger.String("student_id", recipient.StudentID),
+			)
+			m.markNoticed(recipient, &table)
+			continue
+		}
+		if pref != nil && inQuietHours(pref.QuietHours, now) {
+			// 免打扰时段内不发送，保持未通知，时段结束后的扫描中发送
+			continue
+		}
+
+		last, ok := latest[recipient.RecordID]
+		// 之前的投递属于其他进度时，按首次通知处理
+		if ok && (last.Progress == nil || *last.Progress != recipient.Progress) {
+			ok = false
+		}
+		// 已投递成功但标记失败的记录，只需重新标记
+		if !ok || !last.Success {
+			if ok && !notificationRetryDue(last) {
 				continue
 			}
-			// 获取待通知的记录列表
-			recipients, err := m.GetPendingNotifications(&table)
+			attempt := 1
+			if ok {
+				attempt = last.Attempt + 1
+			}
+
+			channel, notifier := m.recipientNotifier(pref, &table)
+			res, err := notifier.Notify(recipient, &table)
+			m.recordDelivery(recipient, &table, channel, attempt, res, err)
 			if err != nil {
-				m.log.Error("get ccnubox pending notifications failed",
+				m.log.Error("send notification failed",
+					logger.String("notice_channel", channel),
+					logger.String("student_id", recipient.StudentID),
+					logger.String("progress", recipient.Progress),
+					logger.Int("attempt", attempt),
 					logger.String("error", err.Error()),
 				)
 				continue
 			}
-
-			// 根据表格标识发送不同类型的通知
-			switch *table.TableIdentity {
-			case "ccnubox":
-				for _, recipient := range recipients {
-					err = m.SendCCNUBoxNotification(&recipient.StudentID, &recipient.RecordID)
-					if err != nil {
-						m.log.Error("send ccnubox notification failed",
-							logger.String("student_id", recipient.StudentID),
-							logger.String("error", err.Error()),
-						)
-					}
-					err := m.MarkRecordNoticed(&recipient.RecordID, &table)
-					if err != nil {
-						m.log.Error("MarkRecordNoticed 更新记录通知状态失败",
-							logger.String("error", err.Error()),
-							logger.String("record_id", recipient.RecordID),
-							logger.String("table_identity", *table.TableIdentity),
-						)
-					}
-				}
-			default:
-				m.log.Error("unsupported table identity",
-					logger.String("table_identity", *table.TableIdentity))
-			}
 		}
-	}()
 
-	return m
+		m.markNoticed(recipient, &table)
+	}
 }
 
-func (m *MessageServiceImpl) SendLarkNotification(tableName, content, url string) error {
+func (m *MessageServiceImpl) markNoticed(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) {
+	err := m.MarkRecordNoticed(&recipient.RecordID, recipient.Progress, tableConfig)
+	if err != nil {
+		m.log.Error("MarkRecordNoticed 更新记录通知状态失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recipient.RecordID),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+	}
+}
+
+// SendLarkNotification 向表格配置的飞书群组或人员发送新反馈卡片，表格未配置时使用全局配置
+// receivers 不为空时只发送给这些接收者，用于重试上次发送失败的接收者。返回每个接收者的发送结果，存在失败时同时返回错误
+func (m *MessageServiceImpl) SendLarkNotification(content, url string, receivers []domain.LarkReceiver, tableConfig *domain.TableConfig) (domain.LarkDeliveryReport, error) {
+	var report domain.LarkDeliveryReport
+
 	if len(content) > 30 {
 		content = content[:30] + "......"
 	}
 
+	templateID, routeReceivers, extra := m.larkRoute(tableConfig)
+	if len(receivers) == 0 {
+		receivers = routeReceivers
+	}
+
+	// 表格配置的附加变量在前，内置变量不允许被覆盖
+	variables := make(map[string]interface{}, len(extra)+3)
+	for k, v := range extra {
+		variables[k] = v
+	}
+	var tableName string
+	if tableConfig.TableName != nil {
+		tableName = *tableConfig.TableName
+	}
+	variables["table_name"] = tableName
+	variables["feedback_content"] = content
+	variables["shared_url"] = map[string]string{"url": url}
+
 	message := domain.LarkMessage{
 		Type: "template",
 		Data: domain.LarkMessageData{
-			TemplateId:          m.lc.TemplateID,
+			TemplateId:          templateID,
 			TemplateVersionName: "",
-			TemplateVariable: map[string]interface{}{
-				"table_name":       tableName,
-				"feedback_content": content,
-				"shared_url":       map[string]string{"url": url},
-			},
+			TemplateVariable:    variables,
 		},
 	}
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
-		return errs.SerializationError(err)
+		return report, errs.SerializationError(err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -129,9 +253,13 @@ func (m *MessageServiceImpl) SendLarkNotification(tableName, content, url string
 
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, 5)
+	// 每个 goroutine 只写自己下标的结果，无需加锁
+	report.Results = make([]domain.LarkDeliveryResult, len(receivers))
 
-	for _, r := range m.lc.ReceiveIDs {
+	for i, r := range receivers {
 		r := r // 避免闭包问题
+		res := &report.Results[i]
+		res.Receiver = r
 		wg.Add(1)
 
 		go func() {
@@ -141,6 +269,7 @@ func (m *MessageServiceImpl) SendLarkNotification(tableName, content, url string
 			case sem <- struct{}{}:
 				defer func() { <-sem }()
 			case <-ctx.Done():
+				res.Error = ctx.Err().Error()
 				m.log.Warn("SendLarkMessage canceled by context",
 					logger.String("receive_id", r.ID),
 				)
@@ -159,7 +288,7 @@ func (m *MessageServiceImpl) SendLarkNotification(tableName, content, url string
 			resp, err := m.c.SendNotice(ctx, req)
 			// 处理错误
 			if err != nil {
-				errCount.Add(1)
+				res.Error = err.Error()
 				m.log.Error("SendLarkMessage failed",
 					logger.String("receive_id", r.ID),
 					logger.String("error", err.Error()),
@@ -167,9 +296,11 @@ func (m *MessageServiceImpl) SendLarkNotification(tableName, content, url string
 				return
 			}
 
+			res.RequestID = resp.RequestId()
 			// 服务端错误处理
 			if !resp.Success() {
-				errCount.Add(1)
+				res.Code = resp.Code
+				res.Error = resp.Msg
 				m.log.Error("Lark API error",
 					logger.String("receive_id", r.ID),
 					logger.String("request_id", resp.RequestId()),
@@ -177,120 +308,154 @@ func (m *MessageServiceImpl) SendLarkNotification(tableName, content, url string
 				)
 				return
 			}
+			res.Success = true
 		}()
 	}
 
 	wg.Wait()
-	if errCount.Load() > 0 {
-		return errs.LarkMessagePartialFailureError(fmt.Errorf("send message failed: %v", errCount.Load()))
+
+	var failed int
+	for _, res := range report.Results {
+		result := "success"
+		if !res.Success {
+			result = "failure"
+			failed++
+		}
+		larkMessageCounter.WithLabelValues(res.Receiver.Type, result).Inc()
+	}
+	m.log.Info("SendLarkMessage report",
+		logger.String("table_identity", *tableConfig.TableIdentity),
+		logger.Int("receivers", len(report.Results)),
+		logger.Int("failed", failed),
+	)
+
+	if failed > 0 {
+		return report, errs.LarkMessagePartialFailureError(fmt.Errorf("send message failed: %d/%d", failed, len(report.Results)))
 	}
 
-	return nil
+	return report, nil
 }
 
-func (m *MessageServiceImpl) TriggerNotification(tableIdentify string) error {
-	table, ok := tableCfg[tableIdentify]
-	if !ok {
-		return errs.TableIdentifierInvalidError(fmt.Errorf("table identify not found: %s", tableIdentify))
-	}
-	if !table.Notice {
-		return errs.TableNotificationNotConfiguredError(fmt.Errorf("table notification not configured: %s", tableIdentify))
+// failedLarkReceivers 返回发送报告中发送失败的接收者
+func failedLarkReceivers(report domain.LarkDeliveryReport) []domain.LarkReceiver {
+	var failed []domain.LarkReceiver
+	for _, res := range report.Results {
+		if !res.Success {
+			failed = append(failed, res.Receiver)
+		}
 	}
+	return failed
+}
 
-	select {
-	case noticeCh <- table:
-		return nil
-	default:
-		return errs.AppNotificationChannelFullError(fmt.Errorf("notification channel is full"))
+// larkRoute 获取表格新反馈卡片的模板、接收者与附加变量，未配置的部分使用全局配置。
+// 调用方传入的表格配置可能来自 token，路由以基础配置表中最新的配置为准
+func (m *MessageServiceImpl) larkRoute(tableConfig *domain.TableConfig) (string, []domain.LarkReceiver, map[string]any) {
+	route := *tableConfig
+	if tableConfig.TableIdentity != nil {
+		if table, ok := getTableConfig(*tableConfig.TableIdentity); ok {
+			route = table
+		}
 	}
-}
 
-// GetPendingNotifications 根据表格配置查询待通知的记录，并返回通知对象列表
-func (m *MessageServiceImpl) GetPendingNotifications(tableConfig *domain.TableConfig) ([]domain.NotificationRecipient, error) {
-	records, err := m.sheetDao.GetUnNoticedRecordsByTable(*tableConfig.TableIdentity)
-	if err != nil {
-		return nil, errs.GetUnNoticedRecordByTableError(err)
+	templateID := m.lc.TemplateID
+	if route.LarkTemplateID != "" {
+		templateID = route.LarkTemplateID
 	}
 
-	var recipients []domain.NotificationRecipient
-	for _, record := range records {
-		recipients = append(recipients, domain.NotificationRecipient{
-			RecordID:  *record.RecordID,
-			StudentID: *record.UserID,
-		})
+	receivers := route.LarkReceivers
+	if len(receivers) == 0 {
+		receivers = make([]domain.LarkReceiver, 0, len(m.lc.ReceiveIDs))
+		for _, r := range m.lc.ReceiveIDs {
+			receivers = append(receivers, domain.LarkReceiver{Type: r.Type, ID: r.ID})
+		}
 	}
 
-	return recipients, nil
+	return templateID, receivers, route.LarkTemplateVariables
 }
 
-func (m *MessageServiceImpl) SendCCNUBoxNotification(studentID, recordID *string) error {
-	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(m.cc.BasicUser+":"+m.cc.BasicPassword))
-	message := domain.CCNUBoxFeedMessage{
-		Content:   "您的问题已经处理完成，点击查看详情",
-		StudentID: *studentID,
-		Title:     "反馈处理完成提醒",
-		RecordID:  *recordID,
+func (m *MessageServiceImpl) TriggerNotification(tableIdentify string) error {
+	table, ok := getTableConfig(tableIdentify)
+	if !ok {
+		return errs.TableIdentifierInvalidError(fmt.Errorf("table identify not found: %s", tableIdentify))
+	}
+	if !table.Notice {
+		return errs.TableNotificationNotConfiguredError(fmt.Errorf("table notification not configured: %s", tableIdentify))
+	}
+	if _, ok := m.notifiers.Get(&table); !ok {
+		return errs.TableNotificationNotConfiguredError(fmt.Errorf("unsupported notice channel: %s", noticeChannel(&table)))
 	}
 
-	// 编码请求体
-	jsonData, err := json.Marshal(message)
-	if err != nil {
-		m.log.Error("marshal request body failed",
-			logger.String("student_id", *studentID),
+	// 收到请求的实例不一定是主节点，写入 Redis 后由主节点上的通知消费者处理
+	if err := m.trigger.Trigger(context.Background(), tableIdentify); err != nil {
+		m.log.Error("Trigger 登记通知任务失败",
 			logger.String("error", err.Error()),
+			logger.String("table_identity", tableIdentify),
 		)
-		return errs.SerializationError(fmt.Errorf("编码请求体失败: %w", err))
+		return errs.TriggerNotificationError(err)
 	}
+	return nil
+}
 
-	// 创建HTTP请求
-	req, err := http.NewRequest("POST", m.cc.BaseURL, bytes.NewBuffer(jsonData))
+// handleNoticeTriggers 取出手动触发的表格并发送通知，表格配置以取出时最新的配置为准
+// 取出后实例崩溃时该次触发丢失，由定时扫描兜底
+func (m *MessageServiceImpl) handleNoticeTriggers(ctx context.Context) {
+	ids, err := m.trigger.PopTriggers(ctx, noticeTriggerBatchSize)
 	if err != nil {
-		m.log.Error("create http request failed",
-			logger.String("student_id", *studentID),
-			logger.String("error", err.Error()),
-		)
-		return errs.HTTPRequestCreationError(fmt.Errorf("创建请求失败: %w", err))
+		if ctx.Err() == nil {
+			m.log.Error("PopTriggers 读取通知任务失败",
+				logger.String("error", err.Error()),
+			)
+		}
+		return
 	}
-	// 设置请求头
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", auth)
-	// 发送请求
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		m.log.Error("send request failed",
-			logger.String("student_id", *studentID),
-			logger.String("error", err.Error()),
-		)
-		return errs.CCNUBoxRequestError(fmt.Errorf("发送请求失败: %w", err))
+
+	for _, id := range ids {
+		table, ok := getTableConfig(id)
+		if !ok {
+			m.log.Warn("triggered table not found, skipped",
+				logger.String("table_identity", id),
+			)
+			continue
+		}
+		m.handleNoticeTable(table)
+	}
+}
+
+// GetPendingNotifications 根据表格配置查询进度变化后待通知的记录，并返回通知对象列表，只通知配置了通知内容的进度
+func (m *MessageServiceImpl) GetPendingNotifications(tableConfig *domain.TableConfig) ([]domain.NotificationRecipient, error) {
+	progresses := make([]string, 0, len(m.nc.Progress))
+	for progress := range m.nc.Progress {
+		progresses = append(progresses, progress)
 	}
-	defer resp.Body.Close()
 
-	// 读取响应
-	body, err := ioutil.ReadAll(resp.Body)
+	records, err := m.sheetDao.GetUnNoticedRecordsByTable(*tableConfig.TableIdentity, progresses)
 	if err != nil {
-		m.log.Error("read response failed",
-			logger.String("student_id", *studentID),
-			logger.String("error", err.Error()),
-		)
-		return errs.HTTPResponseReadError(fmt.Errorf("读取响应失败: %w", err))
+		return nil, errs.GetUnNoticedRecordByTableError(err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		m.log.Error("ccnubox response not ok",
-			logger.String("student_id", *studentID),
-			logger.String("status", fmt.Sprintf("%d", resp.StatusCode)),
-			logger.String("body", string(body)),
-		)
-		return errs.CCNUBoxResponseError(fmt.Errorf("请求返回异常: %d : %s", resp.StatusCode, string(body)))
+	var recipients []domain.NotificationRecipient
+	for _, record := range records {
+		recipient := domain.NotificationRecipient{
+			RecordID:  *record.RecordID,
+			StudentID: *record.UserID,
+			Record:    record.Record,
+		}
+		if record.ShareUrl != nil {
+			recipient.ShareURL = *record.ShareUrl
+		}
+		if record.Progress != nil {
+			recipient.Progress = *record.Progress
+		}
+		recipient.ProgressVersion = record.ProgressVersion
+		recipients = append(recipients, recipient)
 	}
 
-	return nil
+	return recipients, nil
 }
 
-// MarkRecordNoticed 更新通知记录的状态为已完成
-func (m *MessageServiceImpl) MarkRecordNoticed(recordID *string, tableConfig *domain.TableConfig) error {
-	err := m.sheetDao.MarkRecordNoticed(*tableConfig.TableIdentity, *recordID)
+// MarkRecordNoticed 将记录在该进度下的通知状态更新为已通知
+func (m *MessageServiceImpl) MarkRecordNoticed(recordID *string, progress string, tableConfig *domain.TableConfig) error {
+	err := m.sheetDao.MarkRecordNoticed(*tableConfig.TableIdentity, *recordID, progress)
 	if err != nil {
 		return errs.MarkRecordNoticedError(err)
 	}
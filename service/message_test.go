@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	cacheMock "github.com/muxi-Infra/FeedBack-Backend/repository/cache/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLarkConfig() *config.LarkMessage {
+	return &config.LarkMessage{
+		TemplateID: "global-template",
+		ReceiveIDs: []config.ReceiveID{{Type: "chat_id", ID: "oc_global"}},
+	}
+}
+
+// 新反馈卡片按表格配置的模板和接收者发送，表格未配置时使用全局配置
+func TestLarkRoute(t *testing.T) {
+	global := []domain.LarkReceiver{{Type: "chat_id", ID: "oc_global"}}
+	table := []domain.LarkReceiver{{Type: "open_id", ID: "ou_admin"}}
+
+	tests := []struct {
+		name          string
+		templateID    string
+		receivers     []domain.LarkReceiver
+		variables     map[string]any
+		wantTemplate  string
+		wantReceivers []domain.LarkReceiver
+	}{
+		{name: "global fallback", wantTemplate: "global-template", wantReceivers: global},
+		{name: "table template only", templateID: "table-template", wantTemplate: "table-template", wantReceivers: global},
+		{name: "table receivers only", receivers: table, wantTemplate: "global-template", wantReceivers: table},
+		{
+			name:          "table route with variables",
+			templateID:    "table-template",
+			receivers:     table,
+			variables:     map[string]any{"team": "muxi"},
+			wantTemplate:  "table-template",
+			wantReceivers: table,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MessageServiceImpl{log: newTestLogger(), lc: newTestLarkConfig()}
+			tc := newTestTableConfig()
+			tc.LarkTemplateID = tt.templateID
+			tc.LarkReceivers = tt.receivers
+			tc.LarkTemplateVariables = tt.variables
+			setTestTables(t, tc)
+
+			// 学生接口传入的表格配置来自 token，只有表格基础信息，路由以最新的表格配置为准
+			token := newTestTableConfig()
+			templateID, receivers, variables := m.larkRoute(&token)
+			assert.Equal(t, tt.wantTemplate, templateID)
+			assert.Equal(t, tt.wantReceivers, receivers)
+			assert.Equal(t, tt.variables, variables)
+		})
+	}
+}
+
+// larkMessageReceiveID 读取消息请求的接收者，Build 后请求体只保存在 SDK 未导出的 apiReq 中
+func larkMessageReceiveID(req *larkim.CreateMessageReq) string {
+	body := reflect.ValueOf(req).Elem().FieldByName("apiReq").Elem().FieldByName("Body").Elem()
+	return body.Elem().FieldByName("ReceiveId").Elem().String()
+}
+
+// 逐个接收者返回发送结果，部分失败时同时返回错误；指定接收者时只发送给这些接收者
+func TestSendLarkNotificationReport(t *testing.T) {
+	ok := domain.LarkReceiver{Type: "chat_id", ID: "oc_ok"}
+	rejected := domain.LarkReceiver{Type: "chat_id", ID: "oc_rejected"}
+	unreachable := domain.LarkReceiver{Type: "open_id", ID: "ou_unreachable"}
+
+	ctrl := gomock.NewController(t)
+	client := larkMock.NewMockClient(ctrl)
+	m := &MessageServiceImpl{c: client, log: newTestLogger(), lc: newTestLarkConfig()}
+	tc := newTestTableConfig()
+	tc.LarkReceivers = []domain.LarkReceiver{ok, rejected, unreachable}
+	setTestTables(t, tc)
+
+	// 接收者并发发送，记录时加锁
+	var (
+		mu   sync.Mutex
+		sent []string
+	)
+	client.EXPECT().SendNotice(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *larkim.CreateMessageReq, _ ...larkcore.RequestOptionFunc) (*larkim.CreateMessageResp, error) {
+			id := larkMessageReceiveID(req)
+			mu.Lock()
+			sent = append(sent, id)
+			mu.Unlock()
+			resp := &larkim.CreateMessageResp{ApiResp: &larkcore.ApiResp{Header: http.Header{}}}
+			switch id {
+			case rejected.ID:
+				resp.CodeError = larkcore.CodeError{Code: 230002, Msg: "bot not in chat"}
+			case unreachable.ID:
+				return nil, errors.New("timeout")
+			}
+			return resp, nil
+		}).Times(4)
+
+	report, err := m.SendLarkNotification("新反馈", "https://share/rec-1", nil, &tc)
+	require.Error(t, err)
+	assert.Equal(t, errs.LarkMessagePartialFailureCode, errorx.ToCustomError(err).Code)
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, domain.LarkDeliveryResult{Receiver: ok, Success: true}, report.Results[0])
+	assert.Equal(t, domain.LarkDeliveryResult{Receiver: rejected, Code: 230002, Error: "bot not in chat"}, report.Results[1])
+	assert.Equal(t, domain.LarkDeliveryResult{Receiver: unreachable, Error: "timeout"}, report.Results[2])
+	assert.Equal(t, []domain.LarkReceiver{rejected, unreachable}, failedLarkReceivers(report))
+
+	sent = nil
+	report, err = m.SendLarkNotification("新反馈", "https://share/rec-1", []domain.LarkReceiver{ok}, &tc)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.True(t, report.Results[0].Success)
+	assert.Equal(t, []string{ok.ID}, sent)
+}
+
+// 表格配置刷新与读取并发进行，配合 -race 检查路由读取配置时加锁
+func TestLarkRouteDuringConfigRefresh(t *testing.T) {
+	m := &MessageServiceImpl{log: newTestLogger(), lc: newTestLarkConfig()}
+	tc := newTestTableConfig()
+	tc.LarkTemplateID = "table-template"
+	setTestTables(t, tc)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			setTableConfigs(map[string]domain.TableConfig{*tc.TableIdentity: tc})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		templateID, _, _ := m.larkRoute(&tc)
+		assert.Equal(t, "table-template", templateID)
+	}
+	wg.Wait()
+}
+
+// 手动触发只登记到 Redis，由主节点上的通知消费者取出后处理，不在收到请求的实例上直接发送
+func TestTriggerNotificationGoesThroughLeader(t *testing.T) {
+	recordID, studentID, progress := "rec-1", "2023001", "处理中"
+	notified := 0
+	m, mocks, tc := newTestMessageService(t, notifierFunc(func(domain.NotificationRecipient, *domain.TableConfig) (domain.NotificationResult, error) {
+		notified++
+		return domain.NotificationResult{}, nil
+	}))
+	trigger := cacheMock.NewMockNoticeTrigger(gomock.NewController(t))
+	m.trigger = trigger
+	setTestTables(t, tc)
+
+	trigger.EXPECT().Trigger(gomock.Any(), *tc.TableIdentity).Return(nil)
+	require.NoError(t, m.TriggerNotification(*tc.TableIdentity))
+	assert.Equal(t, 0, notified)
+
+	trigger.EXPECT().Trigger(gomock.Any(), *tc.TableIdentity).Return(errors.New("redis down"))
+	err := m.TriggerNotification(*tc.TableIdentity)
+	require.Error(t, err)
+	assert.Equal(t, errs.TriggerNotificationErrorCode, errorx.ToCustomError(err).Code)
+
+	// 主节点取出触发的表格，已移除的表格跳过
+	trigger.EXPECT().PopTriggers(gomock.Any(), int64(noticeTriggerBatchSize)).Return([]string{"removed-table", *tc.TableIdentity}, nil)
+	mocks.sheet.EXPECT().GetUnNoticedRecordsByTable(*tc.TableIdentity, []string{progress}).
+		Return([]model.Sheet{{RecordID: &recordID, UserID: &studentID, Progress: &progress}}, nil)
+	mocks.delivery.EXPECT().GetLatestDeliveries(*tc.TableIdentity, []string{recordID}).Return(nil, nil)
+	mocks.delivery.EXPECT().CreateDelivery(gomock.Any()).Return(nil)
+	mocks.sheet.EXPECT().MarkRecordNoticed(*tc.TableIdentity, recordID, progress).Return(nil)
+	mocks.thread.EXPECT().ListUnNoticedMessages(*tc.TableIdentity, FeedbackSenderStaff, notificationMaxAttempts).Return(nil, nil)
+
+	m.handleNoticeTriggers(context.Background())
+	assert.Equal(t, 1, notified)
+}
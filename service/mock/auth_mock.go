@@ -49,6 +49,21 @@ func (mr *MockAuthServiceMockRecorder) GetTableConfig(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableConfig", reflect.TypeOf((*MockAuthService)(nil).GetTableConfig), arg0)
 }
 
+// GetTableConfigByToken mocks base method.
+func (m *MockAuthService) GetTableConfigByToken(arg0, arg1 string) (domain.TableConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableConfigByToken", arg0, arg1)
+	ret0, _ := ret[0].(domain.TableConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableConfigByToken indicates an expected call of GetTableConfigByToken.
+func (mr *MockAuthServiceMockRecorder) GetTableConfigByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableConfigByToken", reflect.TypeOf((*MockAuthService)(nil).GetTableConfigByToken), arg0, arg1)
+}
+
 // GetTenantToken mocks base method.
 func (m *MockAuthService) GetTenantToken() string {
 	m.ctrl.T.Helper()
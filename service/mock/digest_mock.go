@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/service (interfaces: DigestService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/muxi-Infra/FeedBack-Backend/domain"
+)
+
+// MockDigestService is a mock of DigestService interface.
+type MockDigestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestServiceMockRecorder
+}
+
+// MockDigestServiceMockRecorder is the mock recorder for MockDigestService.
+type MockDigestServiceMockRecorder struct {
+	mock *MockDigestService
+}
+
+// NewMockDigestService creates a new mock instance.
+func NewMockDigestService(ctrl *gomock.Controller) *MockDigestService {
+	mock := &MockDigestService{ctrl: ctrl}
+	mock.recorder = &MockDigestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestService) EXPECT() *MockDigestServiceMockRecorder {
+	return m.recorder
+}
+
+// BuildDigest mocks base method.
+func (m *MockDigestService) BuildDigest(arg0, arg1 time.Time, arg2 *domain.TableConfig) (*domain.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildDigest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildDigest indicates an expected call of BuildDigest.
+func (mr *MockDigestServiceMockRecorder) BuildDigest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildDigest", reflect.TypeOf((*MockDigestService)(nil).BuildDigest), arg0, arg1, arg2)
+}
+
+// SendDigest mocks base method.
+func (m *MockDigestService) SendDigest(arg0 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDigest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendDigest indicates an expected call of SendDigest.
+func (mr *MockDigestServiceMockRecorder) SendDigest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDigest", reflect.TypeOf((*MockDigestService)(nil).SendDigest), arg0)
+}
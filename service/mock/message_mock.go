@@ -34,6 +34,36 @@ func (m *MockMessageService) EXPECT() *MockMessageServiceMockRecorder {
 	return m.recorder
 }
 
+// CountUnreadNotifications mocks base method.
+func (m *MockMessageService) CountUnreadNotifications(arg0 string, arg1 *domain.TableConfig) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockMessageServiceMockRecorder) CountUnreadNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockMessageService)(nil).CountUnreadNotifications), arg0, arg1)
+}
+
+// GetNotificationPreference mocks base method.
+func (m *MockMessageService) GetNotificationPreference(arg0 string, arg1 *domain.TableConfig) (*domain.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(*domain.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreference indicates an expected call of GetNotificationPreference.
+func (mr *MockMessageServiceMockRecorder) GetNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreference", reflect.TypeOf((*MockMessageService)(nil).GetNotificationPreference), arg0, arg1)
+}
+
 // GetPendingNotifications mocks base method.
 func (m *MockMessageService) GetPendingNotifications(arg0 *domain.TableConfig) ([]domain.NotificationRecipient, error) {
 	m.ctrl.T.Helper()
@@ -49,46 +79,78 @@ func (mr *MockMessageServiceMockRecorder) GetPendingNotifications(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNotifications", reflect.TypeOf((*MockMessageService)(nil).GetPendingNotifications), arg0)
 }
 
-// MarkRecordNoticed mocks base method.
-func (m *MockMessageService) MarkRecordNoticed(arg0 *string, arg1 *domain.TableConfig) error {
+// ListFailedNotifications mocks base method.
+func (m *MockMessageService) ListFailedNotifications(arg0 string, arg1 *string, arg2 int, arg3 *domain.TableConfig) (*domain.NotificationDeliveries, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkRecordNoticed", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListFailedNotifications", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.NotificationDeliveries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MarkRecordNoticed indicates an expected call of MarkRecordNoticed.
-func (mr *MockMessageServiceMockRecorder) MarkRecordNoticed(arg0, arg1 interface{}) *gomock.Call {
+// ListFailedNotifications indicates an expected call of ListFailedNotifications.
+func (mr *MockMessageServiceMockRecorder) ListFailedNotifications(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedNotifications", reflect.TypeOf((*MockMessageService)(nil).ListFailedNotifications), arg0, arg1, arg2, arg3)
+}
+
+// ListNotifications mocks base method.
+func (m *MockMessageService) ListNotifications(arg0 string, arg1 bool, arg2 *string, arg3 int, arg4 *domain.TableConfig) (*domain.Notifications, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.Notifications)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockMessageServiceMockRecorder) ListNotifications(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRecordNoticed", reflect.TypeOf((*MockMessageService)(nil).MarkRecordNoticed), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockMessageService)(nil).ListNotifications), arg0, arg1, arg2, arg3, arg4)
 }
 
-// SendCCNUBoxNotification mocks base method.
-func (m *MockMessageService) SendCCNUBoxNotification(arg0, arg1 *string) error {
+// MarkNotificationsRead mocks base method.
+func (m *MockMessageService) MarkNotificationsRead(arg0 string, arg1 []uint64, arg2 *domain.TableConfig) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockMessageServiceMockRecorder) MarkNotificationsRead(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockMessageService)(nil).MarkNotificationsRead), arg0, arg1, arg2)
+}
+
+// MarkRecordNoticed mocks base method.
+func (m *MockMessageService) MarkRecordNoticed(arg0 *string, arg1 string, arg2 *domain.TableConfig) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendCCNUBoxNotification", arg0, arg1)
+	ret := m.ctrl.Call(m, "MarkRecordNoticed", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendCCNUBoxNotification indicates an expected call of SendCCNUBoxNotification.
-func (mr *MockMessageServiceMockRecorder) SendCCNUBoxNotification(arg0, arg1 interface{}) *gomock.Call {
+// MarkRecordNoticed indicates an expected call of MarkRecordNoticed.
+func (mr *MockMessageServiceMockRecorder) MarkRecordNoticed(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCCNUBoxNotification", reflect.TypeOf((*MockMessageService)(nil).SendCCNUBoxNotification), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRecordNoticed", reflect.TypeOf((*MockMessageService)(nil).MarkRecordNoticed), arg0, arg1, arg2)
 }
 
 // SendLarkNotification mocks base method.
-func (m *MockMessageService) SendLarkNotification(arg0, arg1, arg2 string) error {
+func (m *MockMessageService) SendLarkNotification(arg0, arg1 string, arg2 []domain.LarkReceiver, arg3 *domain.TableConfig) (domain.LarkDeliveryReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendLarkNotification", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SendLarkNotification", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.LarkDeliveryReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendLarkNotification indicates an expected call of SendLarkNotification.
-func (mr *MockMessageServiceMockRecorder) SendLarkNotification(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockMessageServiceMockRecorder) SendLarkNotification(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendLarkNotification", reflect.TypeOf((*MockMessageService)(nil).SendLarkNotification), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendLarkNotification", reflect.TypeOf((*MockMessageService)(nil).SendLarkNotification), arg0, arg1, arg2, arg3)
 }
 
 // TriggerNotification mocks base method.
@@ -104,3 +166,17 @@ func (mr *MockMessageServiceMockRecorder) TriggerNotification(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerNotification", reflect.TypeOf((*MockMessageService)(nil).TriggerNotification), arg0)
 }
+
+// UpdateNotificationPreference mocks base method.
+func (m *MockMessageService) UpdateNotificationPreference(arg0 *domain.NotificationPreference, arg1 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationPreference indicates an expected call of UpdateNotificationPreference.
+func (mr *MockMessageServiceMockRecorder) UpdateNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPreference", reflect.TypeOf((*MockMessageService)(nil).UpdateNotificationPreference), arg0, arg1)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/service (interfaces: OutboxService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/muxi-Infra/FeedBack-Backend/domain"
+)
+
+// MockOutboxService is a mock of OutboxService interface.
+type MockOutboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxServiceMockRecorder
+}
+
+// MockOutboxServiceMockRecorder is the mock recorder for MockOutboxService.
+type MockOutboxServiceMockRecorder struct {
+	mock *MockOutboxService
+}
+
+// NewMockOutboxService creates a new mock instance.
+func NewMockOutboxService(ctrl *gomock.Controller) *MockOutboxService {
+	mock := &MockOutboxService{ctrl: ctrl}
+	mock.recorder = &MockOutboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxService) EXPECT() *MockOutboxServiceMockRecorder {
+	return m.recorder
+}
+
+// AbortCreatedRecord mocks base method.
+func (m *MockOutboxService) AbortCreatedRecord(arg0 *domain.RecordIntent, arg1 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AbortCreatedRecord", arg0, arg1)
+}
+
+// AbortCreatedRecord indicates an expected call of AbortCreatedRecord.
+func (mr *MockOutboxServiceMockRecorder) AbortCreatedRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortCreatedRecord", reflect.TypeOf((*MockOutboxService)(nil).AbortCreatedRecord), arg0, arg1)
+}
+
+// ConfirmCreatedRecord mocks base method.
+func (m *MockOutboxService) ConfirmCreatedRecord(arg0 *domain.RecordIntent, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ConfirmCreatedRecord", arg0, arg1)
+}
+
+// ConfirmCreatedRecord indicates an expected call of ConfirmCreatedRecord.
+func (mr *MockOutboxServiceMockRecorder) ConfirmCreatedRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmCreatedRecord", reflect.TypeOf((*MockOutboxService)(nil).ConfirmCreatedRecord), arg0, arg1)
+}
+
+// PrepareCreatedRecord mocks base method.
+func (m *MockOutboxService) PrepareCreatedRecord(arg0 *domain.TableRecord, arg1 string, arg2 domain.TableConfig) (*domain.RecordIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareCreatedRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.RecordIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareCreatedRecord indicates an expected call of PrepareCreatedRecord.
+func (mr *MockOutboxServiceMockRecorder) PrepareCreatedRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCreatedRecord", reflect.TypeOf((*MockOutboxService)(nil).PrepareCreatedRecord), arg0, arg1, arg2)
+}
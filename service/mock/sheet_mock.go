@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -49,29 +50,55 @@ func (mr *MockSheetServiceMockRecorder) CreateDBRecord(arg0, arg1, arg2, arg3 in
 }
 
 // CreateLarkRecord mocks base method.
-func (m *MockSheetService) CreateLarkRecord(arg0 *domain.TableRecord, arg1 *domain.TableConfig) (*string, error) {
+func (m *MockSheetService) CreateLarkRecord(arg0 *domain.TableRecord, arg1 string, arg2 *domain.TableConfig) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateLarkRecord", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateLarkRecord", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateLarkRecord indicates an expected call of CreateLarkRecord.
-func (mr *MockSheetServiceMockRecorder) CreateLarkRecord(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockSheetServiceMockRecorder) CreateLarkRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLarkRecord", reflect.TypeOf((*MockSheetService)(nil).CreateLarkRecord), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLarkRecord", reflect.TypeOf((*MockSheetService)(nil).CreateLarkRecord), arg0, arg1, arg2)
+}
+
+// DiscardSyncFailure mocks base method.
+func (m *MockSheetService) DiscardSyncFailure(arg0 string, arg1 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardSyncFailure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardSyncFailure indicates an expected call of DiscardSyncFailure.
+func (mr *MockSheetServiceMockRecorder) DiscardSyncFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardSyncFailure", reflect.TypeOf((*MockSheetService)(nil).DiscardSyncFailure), arg0, arg1)
+}
+
+// EditRecord mocks base method.
+func (m *MockSheetService) EditRecord(arg0 context.Context, arg1, arg2 string, arg3 domain.RecordEdit, arg4 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditRecord", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditRecord indicates an expected call of EditRecord.
+func (mr *MockSheetServiceMockRecorder) EditRecord(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditRecord", reflect.TypeOf((*MockSheetService)(nil).EditRecord), arg0, arg1, arg2, arg3, arg4)
 }
 
 // ForceSyncTableRecords mocks base method.
-func (m *MockSheetService) ForceSyncTableRecords(arg0 *domain.TableConfig) ([]string, int, bool, error) {
+func (m *MockSheetService) ForceSyncTableRecords(arg0 *domain.TableConfig) (*domain.SyncSubmission, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ForceSyncTableRecords", arg0)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(bool)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret0, _ := ret[0].(*domain.SyncSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ForceSyncTableRecords indicates an expected call of ForceSyncTableRecords.
@@ -81,14 +108,12 @@ func (mr *MockSheetServiceMockRecorder) ForceSyncTableRecords(arg0 interface{})
 }
 
 // ForceSyncUserTableRecords mocks base method.
-func (m *MockSheetService) ForceSyncUserTableRecords(arg0 *string, arg1 *domain.TableConfig) ([]string, int, bool, error) {
+func (m *MockSheetService) ForceSyncUserTableRecords(arg0 *string, arg1 *domain.TableConfig) (*domain.SyncSubmission, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ForceSyncUserTableRecords", arg0, arg1)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(bool)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret0, _ := ret[0].(*domain.SyncSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ForceSyncUserTableRecords indicates an expected call of ForceSyncUserTableRecords.
@@ -142,6 +167,51 @@ func (mr *MockSheetServiceMockRecorder) GetPhotoUrl(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhotoUrl", reflect.TypeOf((*MockSheetService)(nil).GetPhotoUrl), arg0)
 }
 
+// GetRatingStats mocks base method.
+func (m *MockSheetService) GetRatingStats(arg0 *domain.TableConfig) (*domain.RatingStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingStats", arg0)
+	ret0, _ := ret[0].(*domain.RatingStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingStats indicates an expected call of GetRatingStats.
+func (mr *MockSheetServiceMockRecorder) GetRatingStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingStats", reflect.TypeOf((*MockSheetService)(nil).GetRatingStats), arg0)
+}
+
+// GetSyncFailure mocks base method.
+func (m *MockSheetService) GetSyncFailure(arg0 string, arg1 *domain.TableConfig) (*domain.SyncFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncFailure", arg0, arg1)
+	ret0, _ := ret[0].(*domain.SyncFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncFailure indicates an expected call of GetSyncFailure.
+func (mr *MockSheetServiceMockRecorder) GetSyncFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncFailure", reflect.TypeOf((*MockSheetService)(nil).GetSyncFailure), arg0, arg1)
+}
+
+// GetSyncJob mocks base method.
+func (m *MockSheetService) GetSyncJob(arg0 string, arg1 *domain.TableConfig) (*domain.SyncJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncJob", arg0, arg1)
+	ret0, _ := ret[0].(*domain.SyncJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncJob indicates an expected call of GetSyncJob.
+func (mr *MockSheetServiceMockRecorder) GetSyncJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncJob", reflect.TypeOf((*MockSheetService)(nil).GetSyncJob), arg0, arg1)
+}
+
 // GetTableRecordReqByKey mocks base method.
 func (m *MockSheetService) GetTableRecordReqByKey(arg0 *domain.TableField, arg1 []string, arg2 *string, arg3 *domain.TableConfig) (*domain.TableRecords, error) {
 	m.ctrl.T.Helper()
@@ -188,14 +258,133 @@ func (mr *MockSheetServiceMockRecorder) GetTableRecordReqByUser(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableRecordReqByUser", reflect.TypeOf((*MockSheetService)(nil).GetTableRecordReqByUser), arg0, arg1, arg2, arg3)
 }
 
-// SyncFAQRecord mocks base method.
-func (m *MockSheetService) SyncFAQRecord(arg0 *domain.TableConfig) error {
+// IncrementalSyncTableRecords mocks base method.
+func (m *MockSheetService) IncrementalSyncTableRecords(arg0 *domain.TableConfig) (*domain.SyncSubmission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SyncFAQRecord", arg0)
+	ret := m.ctrl.Call(m, "IncrementalSyncTableRecords", arg0)
+	ret0, _ := ret[0].(*domain.SyncSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementalSyncTableRecords indicates an expected call of IncrementalSyncTableRecords.
+func (mr *MockSheetServiceMockRecorder) IncrementalSyncTableRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementalSyncTableRecords", reflect.TypeOf((*MockSheetService)(nil).IncrementalSyncTableRecords), arg0)
+}
+
+// ListSyncFailures mocks base method.
+func (m *MockSheetService) ListSyncFailures(arg0 string, arg1 *string, arg2 int, arg3 *domain.TableConfig) (*domain.SyncFailures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncFailures", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.SyncFailures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSyncFailures indicates an expected call of ListSyncFailures.
+func (mr *MockSheetServiceMockRecorder) ListSyncFailures(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncFailures", reflect.TypeOf((*MockSheetService)(nil).ListSyncFailures), arg0, arg1, arg2, arg3)
+}
+
+// ListSyncJobs mocks base method.
+func (m *MockSheetService) ListSyncJobs(arg0 *string, arg1 int, arg2 *domain.TableConfig) (*domain.SyncJobs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncJobs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.SyncJobs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSyncJobs indicates an expected call of ListSyncJobs.
+func (mr *MockSheetServiceMockRecorder) ListSyncJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncJobs", reflect.TypeOf((*MockSheetService)(nil).ListSyncJobs), arg0, arg1, arg2)
+}
+
+// RateRecord mocks base method.
+func (m *MockSheetService) RateRecord(arg0, arg1 string, arg2 int, arg3 *string, arg4 *domain.TableConfig) (*domain.RecordRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateRecord", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.RecordRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateRecord indicates an expected call of RateRecord.
+func (mr *MockSheetServiceMockRecorder) RateRecord(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateRecord", reflect.TypeOf((*MockSheetService)(nil).RateRecord), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ReconcileDeletedRecords mocks base method.
+func (m *MockSheetService) ReconcileDeletedRecords(arg0 *domain.TableConfig) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileDeletedRecords", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileDeletedRecords indicates an expected call of ReconcileDeletedRecords.
+func (mr *MockSheetServiceMockRecorder) ReconcileDeletedRecords(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileDeletedRecords", reflect.TypeOf((*MockSheetService)(nil).ReconcileDeletedRecords), arg0)
+}
+
+// RetrySyncFailure mocks base method.
+func (m *MockSheetService) RetrySyncFailure(arg0 string, arg1 *domain.TableConfig) (domain.SyncStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrySyncFailure", arg0, arg1)
+	ret0, _ := ret[0].(domain.SyncStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrySyncFailure indicates an expected call of RetrySyncFailure.
+func (mr *MockSheetServiceMockRecorder) RetrySyncFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrySyncFailure", reflect.TypeOf((*MockSheetService)(nil).RetrySyncFailure), arg0, arg1)
+}
+
+// SyncChangedRecords mocks base method.
+func (m *MockSheetService) SyncChangedRecords(arg0 []string, arg1 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncChangedRecords", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SyncChangedRecords indicates an expected call of SyncChangedRecords.
+func (mr *MockSheetServiceMockRecorder) SyncChangedRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncChangedRecords", reflect.TypeOf((*MockSheetService)(nil).SyncChangedRecords), arg0, arg1)
+}
+
+// SyncDeletedRecords mocks base method.
+func (m *MockSheetService) SyncDeletedRecords(arg0 []string, arg1 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncDeletedRecords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncDeletedRecords indicates an expected call of SyncDeletedRecords.
+func (mr *MockSheetServiceMockRecorder) SyncDeletedRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncDeletedRecords", reflect.TypeOf((*MockSheetService)(nil).SyncDeletedRecords), arg0, arg1)
+}
+
+// SyncFAQRecord mocks base method.
+func (m *MockSheetService) SyncFAQRecord(arg0 *domain.TableConfig) (*domain.SyncSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncFAQRecord", arg0)
+	ret0, _ := ret[0].(*domain.SyncSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // SyncFAQRecord indicates an expected call of SyncFAQRecord.
 func (mr *MockSheetServiceMockRecorder) SyncFAQRecord(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -203,14 +392,12 @@ func (mr *MockSheetServiceMockRecorder) SyncFAQRecord(arg0 interface{}) *gomock.
 }
 
 // SyncUnsyncedTableRecords mocks base method.
-func (m *MockSheetService) SyncUnsyncedTableRecords(arg0 *domain.TableConfig) ([]string, int, bool, error) {
+func (m *MockSheetService) SyncUnsyncedTableRecords(arg0 *domain.TableConfig) (*domain.SyncSubmission, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SyncUnsyncedTableRecords", arg0)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(bool)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret0, _ := ret[0].(*domain.SyncSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SyncUnsyncedTableRecords indicates an expected call of SyncUnsyncedTableRecords.
@@ -260,3 +447,17 @@ func (mr *MockSheetServiceMockRecorder) UpdateFAQResolutionRecordV2(arg0, arg1 i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFAQResolutionRecordV2", reflect.TypeOf((*MockSheetService)(nil).UpdateFAQResolutionRecordV2), arg0, arg1)
 }
+
+// WithdrawRecord mocks base method.
+func (m *MockSheetService) WithdrawRecord(arg0, arg1 string, arg2 *string, arg3 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawRecord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithdrawRecord indicates an expected call of WithdrawRecord.
+func (mr *MockSheetServiceMockRecorder) WithdrawRecord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawRecord", reflect.TypeOf((*MockSheetService)(nil).WithdrawRecord), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/service (interfaces: SubmissionService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/muxi-Infra/FeedBack-Backend/domain"
+)
+
+// MockSubmissionService is a mock of SubmissionService interface.
+type MockSubmissionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubmissionServiceMockRecorder
+}
+
+// MockSubmissionServiceMockRecorder is the mock recorder for MockSubmissionService.
+type MockSubmissionServiceMockRecorder struct {
+	mock *MockSubmissionService
+}
+
+// NewMockSubmissionService creates a new mock instance.
+func NewMockSubmissionService(ctrl *gomock.Controller) *MockSubmissionService {
+	mock := &MockSubmissionService{ctrl: ctrl}
+	mock.recorder = &MockSubmissionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubmissionService) EXPECT() *MockSubmissionServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckSubmission mocks base method.
+func (m *MockSubmissionService) CheckSubmission(arg0 string, arg1 *domain.TableRecord, arg2 *domain.TableConfig) (*domain.SubmissionCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSubmission", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.SubmissionCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSubmission indicates an expected call of CheckSubmission.
+func (mr *MockSubmissionServiceMockRecorder) CheckSubmission(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSubmission", reflect.TypeOf((*MockSubmissionService)(nil).CheckSubmission), arg0, arg1, arg2)
+}
+
+// RememberSubmission mocks base method.
+func (m *MockSubmissionService) RememberSubmission(arg0, arg1, arg2 string, arg3 *domain.TableConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RememberSubmission", arg0, arg1, arg2, arg3)
+}
+
+// RememberSubmission indicates an expected call of RememberSubmission.
+func (mr *MockSubmissionServiceMockRecorder) RememberSubmission(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RememberSubmission", reflect.TypeOf((*MockSubmissionService)(nil).RememberSubmission), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/muxi-Infra/FeedBack-Backend/service (interfaces: ThreadService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/muxi-Infra/FeedBack-Backend/domain"
+)
+
+// MockThreadService is a mock of ThreadService interface.
+type MockThreadService struct {
+	ctrl     *gomock.Controller
+	recorder *MockThreadServiceMockRecorder
+}
+
+// MockThreadServiceMockRecorder is the mock recorder for MockThreadService.
+type MockThreadServiceMockRecorder struct {
+	mock *MockThreadService
+}
+
+// NewMockThreadService creates a new mock instance.
+func NewMockThreadService(ctrl *gomock.Controller) *MockThreadService {
+	mock := &MockThreadService{ctrl: ctrl}
+	mock.recorder = &MockThreadServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThreadService) EXPECT() *MockThreadServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportLarkReply mocks base method.
+func (m *MockThreadService) ImportLarkReply(arg0, arg1, arg2 string, arg3 int64, arg4 *domain.TableConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLarkReply", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportLarkReply indicates an expected call of ImportLarkReply.
+func (mr *MockThreadServiceMockRecorder) ImportLarkReply(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLarkReply", reflect.TypeOf((*MockThreadService)(nil).ImportLarkReply), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListMessages mocks base method.
+func (m *MockThreadService) ListMessages(arg0, arg1 string, arg2 *string, arg3 int, arg4 *domain.TableConfig) (*domain.FeedbackMessages, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.FeedbackMessages)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockThreadServiceMockRecorder) ListMessages(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockThreadService)(nil).ListMessages), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PostStaffMessage mocks base method.
+func (m *MockThreadService) PostStaffMessage(arg0, arg1, arg2 string, arg3 *domain.TableConfig) (*domain.FeedbackMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostStaffMessage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.FeedbackMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostStaffMessage indicates an expected call of PostStaffMessage.
+func (mr *MockThreadServiceMockRecorder) PostStaffMessage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostStaffMessage", reflect.TypeOf((*MockThreadService)(nil).PostStaffMessage), arg0, arg1, arg2, arg3)
+}
+
+// PostStudentMessage mocks base method.
+func (m *MockThreadService) PostStudentMessage(arg0 context.Context, arg1, arg2, arg3 string, arg4 *domain.TableConfig) (*domain.FeedbackMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostStudentMessage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.FeedbackMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostStudentMessage indicates an expected call of PostStudentMessage.
+func (mr *MockThreadServiceMockRecorder) PostStudentMessage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostStudentMessage", reflect.TypeOf((*MockThreadService)(nil).PostStudentMessage), arg0, arg1, arg2, arg3, arg4)
+}
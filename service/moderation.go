@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/moderation"
+)
+
+const (
+	ModerationAllow  = "allow"  // 放行，不做检测
+	ModerationMask   = "mask"   // 将命中的内容打码后写入飞书
+	ModerationReject = "reject" // 拒绝提交
+)
+
+//go:generate mockgen -destination=./mock/moderation_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service ModerationService
+type ModerationService interface {
+	ModerateRecord(ctx context.Context, record *domain.TableRecord, tableConfig *domain.TableConfig) error
+}
+
+type ModerationServiceImpl struct {
+	log      logger.Logger
+	cfg      *config.ModerationConfig
+	pipeline *moderation.Pipeline
+	skip     map[string]bool
+}
+
+func NewModerationService(log logger.Logger, cfg *config.ModerationConfig) ModerationService {
+	var detectors []moderation.Detector
+	words := cfg.Words
+	if cfg.WordsFile != "" {
+		words = append(words, mustLoadWords(cfg.WordsFile)...)
+	}
+	if len(words) > 0 {
+		detectors = append(detectors, moderation.NewKeywordDetector(words))
+	}
+	if cfg.Phone {
+		detectors = append(detectors, moderation.NewPhoneDetector())
+	}
+	if cfg.IDCard {
+		detectors = append(detectors, moderation.NewIDCardDetector())
+	}
+	if cfg.Hook.URL != "" {
+		detectors = append(detectors, moderation.NewHTTPDetector(cfg.Hook.URL, time.Duration(cfg.Hook.Timeout)*time.Second))
+	}
+
+	skip := make(map[string]bool, len(cfg.SkipFields))
+	for _, f := range cfg.SkipFields {
+		skip[f] = true
+	}
+
+	return &ModerationServiceImpl{
+		log:      log,
+		cfg:      cfg,
+		pipeline: moderation.NewPipeline(detectors...),
+		skip:     skip,
+	}
+}
+
+// mustLoadWords 读取敏感词词典，词典属于配置，读取失败时直接 panic
+func mustLoadWords(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("moderation 配置无效: 读取敏感词词典 %s 失败: %v", path, err))
+	}
+
+	var words []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words
+}
+
+// ModerateRecord 在写入飞书前审核记录中的文本列，按表格配置的处理方式打码或拒绝
+// 列表与嵌套对象中的字符串同样审核，skipFields 中的列整列不审核；ctx 为请求的 context，请求取消时外部审核随之取消
+// 检测器出错时，reject 策略下拒绝提交，避免审核服务不可用时放行违规内容；mask 策略下按其余检测器的结果打码
+func (s *ModerationServiceImpl) ModerateRecord(ctx context.Context, record *domain.TableRecord, tableConfig *domain.TableConfig) error {
+	action := s.action(tableConfig)
+	if action == ModerationAllow || s.pipeline.Len() == 0 {
+		return nil
+	}
+
+	for field, v := range record.Record {
+		if s.skip[field] {
+			continue
+		}
+
+		moderated, err := s.moderateValue(ctx, field, v, action, tableConfig)
+		if err != nil {
+			return err
+		}
+		record.Record[field] = moderated
+	}
+
+	return nil
+}
+
+// moderateValue 审核一个值，字符串直接检测，[]any 与 map[string]any 递归审核其中的字符串，其余类型原样返回
+func (s *ModerationServiceImpl) moderateValue(ctx context.Context, field string, v any, action string, tableConfig *domain.TableConfig) (any, error) {
+	switch val := v.(type) {
+	case string:
+		return s.moderateText(ctx, field, val, action, tableConfig)
+	case []any:
+		for i, item := range val {
+			moderated, err := s.moderateValue(ctx, field, item, action, tableConfig)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = moderated
+		}
+		return val, nil
+	case map[string]any:
+		for k, item := range val {
+			moderated, err := s.moderateValue(ctx, field, item, action, tableConfig)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = moderated
+		}
+		return val, nil
+	default:
+		return v, nil
+	}
+}
+
+func (s *ModerationServiceImpl) moderateText(ctx context.Context, field, text, action string, tableConfig *domain.TableConfig) (string, error) {
+	if text == "" {
+		return text, nil
+	}
+
+	matches, err := s.pipeline.Detect(ctx, text)
+	if err != nil {
+		if action == ModerationReject {
+			s.log.Error("内容审核检测失败，按 reject 策略拒绝提交",
+				logger.String("error", err.Error()),
+				logger.String("field", field),
+			)
+			return "", errs.ModerationUnavailableError(err)
+		}
+		s.log.Warn("内容审核检测失败，已忽略出错的检测器",
+			logger.String("error", err.Error()),
+			logger.String("field", field),
+		)
+	}
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	// 日志中只记录命中的规则，不记录命中的内容，避免个人信息写入日志
+	labels := matchLabels(matches)
+	s.log.Info("反馈内容命中审核规则",
+		logger.String("table_identify", *tableConfig.TableIdentity),
+		logger.String("field", field),
+		logger.String("action", action),
+		logger.String("labels", labels),
+	)
+	if action == ModerationReject {
+		return "", errs.ContentRejectedError(fmt.Errorf("field %s matched %s", field, labels))
+	}
+	return moderation.Mask(text, matches, s.cfg.Mask), nil
+}
+
+// action 获取表格的审核处理方式，学生接口传入的表格配置来自 token，以最新的表格配置为准
+func (s *ModerationServiceImpl) action(tableConfig *domain.TableConfig) string {
+	table, _ := getTableConfig(*tableConfig.TableIdentity)
+	switch a := table.Moderation; a {
+	case ModerationAllow, ModerationMask, ModerationReject:
+		return a
+	default:
+		return s.cfg.Action
+	}
+}
+
+// matchLabels 去重后的命中规则，按字典序拼接
+func matchLabels(matches []moderation.Match) string {
+	seen := make(map[string]bool, len(matches))
+	labels := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if !seen[m.Label] {
+			seen[m.Label] = true
+			labels = append(labels, m.Label)
+		}
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ",")
+}
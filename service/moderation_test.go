@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModerationWord = "违禁词"
+
+// failingDetector 模拟不可用的外部审核服务
+type failingDetector struct{}
+
+func (failingDetector) Name() string { return "failing" }
+
+func (failingDetector) Detect(context.Context, string) ([]moderation.Match, error) {
+	return nil, errors.New("hook unavailable")
+}
+
+// newTestModerationService 默认处理方式为 action，检测敏感词 testModerationWord 与手机号，联系方式列不审核
+func newTestModerationService(action string, detectors ...moderation.Detector) *ModerationServiceImpl {
+	detectors = append(detectors, moderation.NewKeywordDetector([]string{testModerationWord}), moderation.NewPhoneDetector())
+	return &ModerationServiceImpl{
+		log:      newTestLogger(),
+		cfg:      &config.ModerationConfig{Action: action, Mask: "*"},
+		pipeline: moderation.NewPipeline(detectors...),
+		skip:     map[string]bool{"联系方式": true},
+	}
+}
+
+// 表格未配置或配置无效时使用全局的处理方式
+func TestModerationActionFallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+		want  string
+	}{
+		{name: "table override", table: ModerationReject, want: ModerationReject},
+		{name: "table allow", table: ModerationAllow, want: ModerationAllow},
+		{name: "not configured", want: ModerationMask},
+		{name: "invalid value", table: "block", want: ModerationMask},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTestTableConfig()
+			tc.Moderation = tt.table
+			setTestTables(t, tc)
+
+			assert.Equal(t, tt.want, newTestModerationService(ModerationMask).action(&tc))
+		})
+	}
+}
+
+// mask 策略下字符串、列表与嵌套对象中的命中内容均被打码，skipFields 中的列与非文本值原样保留
+func TestModerateRecordMasks(t *testing.T) {
+	tc := newTestTableConfig()
+	setTestTables(t, tc)
+
+	record := &domain.TableRecord{Record: map[string]any{
+		"反馈内容": "这里有" + testModerationWord,
+		"联系方式": "13812345678",
+		"补充说明": "电话 13812345678",
+		"标签":   []any{"正常", testModerationWord},
+		"详情":   map[string]any{"备注": testModerationWord, "次数": float64(2)},
+		"评分":   5,
+	}}
+	require.NoError(t, newTestModerationService(ModerationMask).ModerateRecord(context.Background(), record, &tc))
+
+	assert.Equal(t, "这里有***", record.Record["反馈内容"])
+	assert.Equal(t, "13812345678", record.Record["联系方式"])
+	assert.Equal(t, "电话 ***********", record.Record["补充说明"])
+	assert.Equal(t, []any{"正常", "***"}, record.Record["标签"])
+	assert.Equal(t, map[string]any{"备注": "***", "次数": float64(2)}, record.Record["详情"])
+	assert.Equal(t, 5, record.Record["评分"])
+}
+
+func TestModerateRecordRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   string
+		failing  bool
+		value    any
+		wantCode int
+	}{
+		{name: "rejected text", action: ModerationReject, value: testModerationWord, wantCode: errs.ContentRejectedErrorCode},
+		{name: "rejected nested value", action: ModerationReject, value: map[string]any{"备注": []any{testModerationWord}}, wantCode: errs.ContentRejectedErrorCode},
+		{name: "clean text", action: ModerationReject, value: "正常内容"},
+		{name: "detector error fails closed", action: ModerationReject, failing: true, value: "正常内容", wantCode: errs.ModerationUnavailableErrorCode},
+		{name: "detector error under mask", action: ModerationMask, failing: true, value: "正常内容"},
+		{name: "allow skips detection", action: ModerationAllow, failing: true, value: testModerationWord},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTestTableConfig()
+			setTestTables(t, tc)
+			var detectors []moderation.Detector
+			if tt.failing {
+				detectors = append(detectors, failingDetector{})
+			}
+			v := newTestModerationService(tt.action, detectors...)
+
+			err := v.ModerateRecord(context.Background(), &domain.TableRecord{Record: map[string]any{"反馈内容": tt.value}}, &tc)
+			if tt.wantCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+		})
+	}
+}
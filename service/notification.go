@@ -0,0 +1,124 @@
+package service
+
+import (
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+// ListNotifications 查询学生在表格下的站内通知，按时间倒序，支持分页
+func (m *MessageServiceImpl) ListNotifications(studentID string, unreadOnly bool, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.Notifications, error) {
+	lastID := new(uint64)
+	if pageToken != nil && *pageToken != "" {
+		ld, err := decodePageToken(*pageToken)
+		if err != nil {
+			return nil, errs.PageTokenInvalidError(err)
+		}
+		lastID = ld
+	}
+
+	list, hasMore, err := m.inboxDAO.ListNotifications(*tableConfig.TableIdentity, studentID, unreadOnly, lastID, limitSize)
+	if err != nil {
+		m.log.Error("ListNotifications 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("student_id", studentID),
+		)
+		return nil, errs.GetNotificationError(err)
+	}
+
+	ans := make([]domain.Notification, 0, len(list))
+	for _, n := range list {
+		ans = append(ans, toDomainNotification(n))
+	}
+
+	// 生成 nextPageToken
+	var nextToken *string
+	if hasMore && len(list) > 0 {
+		token, _ := encodePageToken(list[len(list)-1].ID)
+		nextToken = &token
+	}
+
+	return &domain.Notifications{
+		Notifications: ans,
+		HasMore:       &hasMore,
+		PageToken:     nextToken,
+	}, nil
+}
+
+// MarkNotificationsRead 将学生的站内通知标记为已读，ids 为空时标记全部未读通知，返回本次标记的条数
+func (m *MessageServiceImpl) MarkNotificationsRead(studentID string, ids []uint64, tableConfig *domain.TableConfig) (int64, error) {
+	n, err := m.inboxDAO.MarkNotificationsRead(*tableConfig.TableIdentity, studentID, ids)
+	if err != nil {
+		m.log.Error("MarkNotificationsRead 更新站内通知失败",
+			logger.String("error", err.Error()),
+			logger.String("student_id", studentID),
+		)
+		return 0, errs.UpdateNotificationError(err)
+	}
+
+	return n, nil
+}
+
+// CountUnreadNotifications 统计学生在表格下的未读站内通知数
+func (m *MessageServiceImpl) CountUnreadNotifications(studentID string, tableConfig *domain.TableConfig) (int64, error) {
+	n, err := m.inboxDAO.CountUnreadNotifications(*tableConfig.TableIdentity, studentID)
+	if err != nil {
+		m.log.Error("CountUnreadNotifications 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("student_id", studentID),
+		)
+		return 0, errs.GetNotificationError(err)
+	}
+
+	return n, nil
+}
+
+// saveInbox 写入学生的站内通知，内容与推送使用的 notice 配置一致，失败时只记录日志，不影响推送
+func (m *MessageServiceImpl) saveInbox(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) {
+	notice := noticeMessage(m.nc, recipient)
+	n := &model.Notification{
+		TableIdentify: tableConfig.TableIdentity,
+		UserID:        &recipient.StudentID,
+		RecordID:      &recipient.RecordID,
+		Progress:      &recipient.Progress,
+		Title:         notice.Title,
+		Content:       notice.Content,
+	}
+	if recipient.Reply != nil {
+		n.MessageID = recipient.Reply.ID
+	} else {
+		n.ProgressVersion = recipient.ProgressVersion
+	}
+	if recipient.ShareURL != "" {
+		n.ShareUrl = &recipient.ShareURL
+	}
+
+	if err := m.inboxDAO.CreateNotification(n); err != nil {
+		m.log.Error("CreateNotification 写入站内通知失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recipient.RecordID),
+		)
+	}
+}
+
+func toDomainNotification(m *model.Notification) domain.Notification {
+	n := domain.Notification{
+		ID:        m.ID,
+		MessageID: m.MessageID,
+		Title:     m.Title,
+		Content:   m.Content,
+		ShareURL:  m.ShareUrl,
+		IsRead:    m.IsRead,
+		ReadAt:    m.ReadAt,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.RecordID != nil {
+		n.RecordID = *m.RecordID
+	}
+	if m.Progress != nil {
+		n.Progress = *m.Progress
+	}
+
+	return n
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+const (
+	notificationBaseBackoff = time.Minute   // 首次失败后的重试等待时间，之后按指数增长
+	notificationMaxBackoff  = 6 * time.Hour // 重试等待时间上限
+	notificationMaxAttempts = 8             // 最大尝试次数，超过后不再自动重试
+	notificationMaxResponse = 1024          // 记录到投递日志的响应内容长度上限
+)
+
+// ListFailedNotifications 查询表格下投递失败的学生通知，recordID 为空时返回全部记录
+func (m *MessageServiceImpl) ListFailedNotifications(recordID string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.NotificationDeliveries, error) {
+	lastID := new(uint64)
+	if pageToken != nil && *pageToken != "" {
+		ld, err := decodePageToken(*pageToken)
+		if err != nil {
+			return nil, errs.PageTokenInvalidError(err)
+		}
+		lastID = ld
+	}
+
+	list, hasMore, err := m.deliveryDAO.ListFailedDeliveries(*tableConfig.TableIdentity, recordID, lastID, limitSize)
+	if err != nil {
+		m.log.Error("ListFailedDeliveries 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetNotificationDeliveryError(err)
+	}
+
+	ans := make([]domain.NotificationDelivery, 0, len(list))
+	for _, d := range list {
+		ans = append(ans, toDomainNotificationDelivery(d))
+	}
+
+	// 生成 nextPageToken
+	var nextToken *string
+	if hasMore && len(list) > 0 {
+		token, _ := encodePageToken(list[len(list)-1].ID)
+		nextToken = &token
+	}
+
+	return &domain.NotificationDeliveries{
+		Deliveries: ans,
+		HasMore:    &hasMore,
+		PageToken:  nextToken,
+	}, nil
+}
+
+// latestDeliveries 查询待通知记录最近一次的投递，key 为记录 ID
+func (m *MessageServiceImpl) latestDeliveries(recipients []domain.NotificationRecipient, tableConfig *domain.TableConfig) (map[string]*model.NotificationDelivery, error) {
+	ids := make([]string, 0, len(recipients))
+	for _, r := range recipients {
+		ids = append(ids, r.RecordID)
+	}
+
+	list, err := m.deliveryDAO.GetLatestDeliveries(*tableConfig.TableIdentity, ids)
+	if err != nil {
+		m.log.Error("GetLatestDeliveries 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetNotificationDeliveryError(err)
+	}
+
+	res := make(map[string]*model.NotificationDelivery, len(list))
+	for _, d := range list {
+		res[*d.RecordID] = d
+	}
+	return res, nil
+}
+
+// recordDelivery 记录一次投递，失败时只记录日志，不影响通知本身
+func (m *MessageServiceImpl) recordDelivery(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig, channel string, attempt int, res domain.NotificationResult, cause error) {
+	d := &model.NotificationDelivery{
+		TableIdentify: tableConfig.TableIdentity,
+		RecordID:      &recipient.RecordID,
+		UserID:        &recipient.StudentID,
+		Channel:       channel,
+		Progress:      &recipient.Progress,
+		Attempt:       attempt,
+		Success:       cause == nil,
+		StatusCode:    res.StatusCode,
+	}
+	if recipient.Reply != nil {
+		d.MessageID = &recipient.Reply.ID
+	}
+	if res.Response != "" {
+		resp := truncateUTF8(res.Response, notificationMaxResponse)
+		d.Response = &resp
+	}
+	if cause != nil {
+		msg := syncErrorMessage(cause)
+		d.Error = &msg
+		if attempt >= notificationMaxAttempts {
+			m.log.Warn("notification exceeded max attempts, will not retry",
+				logger.String("table_identity", *tableConfig.TableIdentity),
+				logger.String("record_id", recipient.RecordID),
+				logger.Int("attempts", attempt),
+			)
+		}
+	}
+
+	if err := m.deliveryDAO.CreateDelivery(d); err != nil {
+		m.log.Error("CreateDelivery 记录通知投递失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recipient.RecordID),
+		)
+	}
+}
+
+// notificationRetryDue 判断投递失败的记录是否到达重试时间，超过最大尝试次数后不再重试
+func notificationRetryDue(last *model.NotificationDelivery) bool {
+	if last.Attempt >= notificationMaxAttempts {
+		return false
+	}
+	return time.Since(last.CreatedAt) >= backoff(last.Attempt, notificationBaseBackoff, notificationMaxBackoff)
+}
+
+func toDomainNotificationDelivery(m *model.NotificationDelivery) domain.NotificationDelivery {
+	d := domain.NotificationDelivery{
+		ID:         m.ID,
+		Channel:    m.Channel,
+		Attempt:    m.Attempt,
+		Success:    m.Success,
+		StatusCode: m.StatusCode,
+		Response:   m.Response,
+		Error:      m.Error,
+		CreatedAt:  m.CreatedAt,
+	}
+	if m.RecordID != nil {
+		d.RecordID = *m.RecordID
+	}
+	if m.UserID != nil {
+		d.StudentID = *m.UserID
+	}
+	if m.Progress != nil {
+		d.Progress = *m.Progress
+	}
+	d.MessageID = m.MessageID
+
+	return d
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notifierFunc 测试中使用的通知渠道
+type notifierFunc func(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) (domain.NotificationResult, error)
+
+func (f notifierFunc) Notify(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) (domain.NotificationResult, error) {
+	return f(recipient, tableConfig)
+}
+
+type messageMocks struct {
+	sheet    *daoMock.MockSheetDAO
+	delivery *daoMock.MockNotificationDeliveryDAO
+	pref     *daoMock.MockNotificationPreferenceDAO
+	inbox    *daoMock.MockNotificationDAO
+	thread   *daoMock.MockFeedbackMessageDAO
+}
+
+const testNoticeChannel = "mock-channel"
+
+func newTestMessageService(t *testing.T, notifier Notifier) (*MessageServiceImpl, messageMocks, domain.TableConfig) {
+	ctrl := gomock.NewController(t)
+	mocks := messageMocks{
+		sheet:    daoMock.NewMockSheetDAO(ctrl),
+		delivery: daoMock.NewMockNotificationDeliveryDAO(ctrl),
+		pref:     daoMock.NewMockNotificationPreferenceDAO(ctrl),
+		inbox:    daoMock.NewMockNotificationDAO(ctrl),
+		thread:   daoMock.NewMockFeedbackMessageDAO(ctrl),
+	}
+	notifiers := &NotifierRegistry{notifiers: make(map[string]Notifier)}
+	notifiers.Register(testNoticeChannel, notifier)
+
+	m := &MessageServiceImpl{
+		log:         newTestLogger(),
+		nc:          &config.NoticeConfig{Progress: map[string]config.NoticeMessage{"处理中": {Title: "t", Content: "c"}}},
+		notifiers:   notifiers,
+		sheetDao:    mocks.sheet,
+		deliveryDAO: mocks.delivery,
+		prefDAO:     mocks.pref,
+		inboxDAO:    mocks.inbox,
+		threadDAO:   mocks.thread,
+	}
+	mocks.pref.EXPECT().ListPreferences(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mocks.inbox.EXPECT().CreateNotification(gomock.Any()).Return(nil).AnyTimes()
+
+	tc := newTestTableConfig()
+	tc.Notice = true
+	tc.NoticeChannel = testNoticeChannel
+	return m, mocks, tc
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{name: "short", s: "已处理", n: 20, want: "已处理"},
+		{name: "exact", s: "已处理", n: 9, want: "已处理"},
+		{name: "cut inside rune", s: "已处理", n: 7, want: "已处"},
+		{name: "cut before first rune ends", s: "已处理", n: 2, want: ""},
+		{name: "ascii", s: "abcdef", n: 3, want: "abc"},
+		{name: "invalid bytes removed", s: "ok\xff\xfe", n: 10, want: "ok"},
+		{name: "partial rune from limited reader", s: "abc\xe5\xb7", n: 5, want: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateUTF8(tt.s, tt.n)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
+
+// 投递日志决定进度通知是否发送：失败按退避重试，超过最大次数放弃，成功后只标记已通知
+func TestHandleProgressNoticesUsesDeliveryLedger(t *testing.T) {
+	recordID, studentID, progress := "rec-1", "2023001", "处理中"
+	otherProgress := "待处理"
+
+	tests := []struct {
+		name        string
+		last        *model.NotificationDelivery
+		notifyErr   error
+		wantNotify  bool
+		wantAttempt int
+		wantMarked  bool
+	}{
+		{
+			name:        "first attempt fails",
+			notifyErr:   errors.New("ccnubox unavailable"),
+			wantNotify:  true,
+			wantAttempt: 1,
+		},
+		{
+			name:        "first attempt succeeds",
+			wantNotify:  true,
+			wantAttempt: 1,
+			wantMarked:  true,
+		},
+		{
+			name: "retry not due yet",
+			last: &model.NotificationDelivery{Progress: &progress, Attempt: 2, CreatedAt: time.Now()},
+		},
+		{
+			name:        "retry due after backoff",
+			last:        &model.NotificationDelivery{Progress: &progress, Attempt: 2, CreatedAt: time.Now().Add(-time.Hour)},
+			wantNotify:  true,
+			wantAttempt: 3,
+			wantMarked:  true,
+		},
+		{
+			name: "max attempts reached",
+			last: &model.NotificationDelivery{Progress: &progress, Attempt: notificationMaxAttempts, CreatedAt: time.Now().Add(-24 * time.Hour)},
+		},
+		{
+			name:       "delivered but not marked",
+			last:       &model.NotificationDelivery{Progress: &progress, Attempt: 1, Success: true},
+			wantMarked: true,
+		},
+		{
+			name:        "previous delivery for another progress",
+			last:        &model.NotificationDelivery{Progress: &otherProgress, Attempt: 5, CreatedAt: time.Now()},
+			wantNotify:  true,
+			wantAttempt: 1,
+			wantMarked:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notified := 0
+			// 响应中包含多字节字符，超长时按字符边界截断
+			longResp := strings.Repeat("错", notificationMaxResponse)
+			m, mocks, tc := newTestMessageService(t, notifierFunc(func(r domain.NotificationRecipient, _ *domain.TableConfig) (domain.NotificationResult, error) {
+				notified++
+				assert.Equal(t, recordID, r.RecordID)
+				return domain.NotificationResult{StatusCode: 500, Response: longResp}, tt.notifyErr
+			}))
+
+			mocks.sheet.EXPECT().GetUnNoticedRecordsByTable(*tc.TableIdentity, []string{progress}).
+				Return([]model.Sheet{{RecordID: &recordID, UserID: &studentID, Progress: &progress}}, nil)
+			var latest []*model.NotificationDelivery
+			if tt.last != nil {
+				tt.last.RecordID = &recordID
+				latest = append(latest, tt.last)
+			}
+			mocks.delivery.EXPECT().GetLatestDeliveries(*tc.TableIdentity, []string{recordID}).Return(latest, nil)
+			if tt.wantNotify {
+				mocks.delivery.EXPECT().CreateDelivery(gomock.Any()).
+					DoAndReturn(func(d *model.NotificationDelivery) error {
+						assert.Equal(t, tt.wantAttempt, d.Attempt)
+						assert.Equal(t, tt.notifyErr == nil, d.Success)
+						assert.Equal(t, testNoticeChannel, d.Channel)
+						require.NotNil(t, d.Response)
+						assert.LessOrEqual(t, len(*d.Response), notificationMaxResponse)
+						assert.True(t, utf8.ValidString(*d.Response))
+						assert.Equal(t, tt.notifyErr != nil, d.Error != nil)
+						return nil
+					})
+			}
+			if tt.wantMarked {
+				mocks.sheet.EXPECT().MarkRecordNoticed(*tc.TableIdentity, recordID, progress).Return(nil)
+			}
+
+			m.handleProgressNotices(tc)
+			if tt.wantNotify {
+				assert.Equal(t, 1, notified)
+			} else {
+				assert.Equal(t, 0, notified)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+const maxQuietHours = 5 // 每个学生最多设置的免打扰时段数
+
+// GetNotificationPreference 获取学生在表格下的通知偏好，未设置时返回默认偏好
+func (m *MessageServiceImpl) GetNotificationPreference(studentID string, tableConfig *domain.TableConfig) (*domain.NotificationPreference, error) {
+	p, err := m.prefDAO.GetPreference(*tableConfig.TableIdentity, studentID)
+	if err != nil {
+		m.log.Error("GetPreference 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("student_id", studentID),
+		)
+		return nil, errs.GetNotificationPreferenceError(err)
+	}
+
+	if p == nil {
+		return &domain.NotificationPreference{
+			StudentID:  studentID,
+			Channels:   []string{},
+			QuietHours: []domain.QuietHours{},
+		}, nil
+	}
+
+	return toDomainNotificationPreference(p), nil
+}
+
+// UpdateNotificationPreference 保存学生在表格下的通知偏好，通知渠道必须是已启用的渠道
+func (m *MessageServiceImpl) UpdateNotificationPreference(pref *domain.NotificationPreference, tableConfig *domain.TableConfig) error {
+	for _, c := range pref.Channels {
+		if _, ok := m.notifiers.Lookup(c); !ok {
+			return errs.NotificationPreferenceInvalidError(fmt.Errorf("unsupported notice channel: %s", c))
+		}
+	}
+	if len(pref.QuietHours) > maxQuietHours {
+		return errs.NotificationPreferenceInvalidError(fmt.Errorf("at most %d quiet hours allowed", maxQuietHours))
+	}
+	for _, q := range pref.QuietHours {
+		if _, err := parseClock(q.Start); err != nil {
+			return errs.NotificationPreferenceInvalidError(err)
+		}
+		if _, err := parseClock(q.End); err != nil {
+			return errs.NotificationPreferenceInvalidError(err)
+		}
+	}
+
+	p := &model.NotificationPreference{
+		TableIdentify: tableConfig.TableIdentity,
+		UserID:        &pref.StudentID,
+		OptOut:        pref.OptOut,
+		Channels:      pref.Channels,
+		QuietHours:    pref.QuietHours,
+	}
+	err := m.prefDAO.UpsertPreference(p)
+	if err != nil {
+		m.log.Error("UpsertPreference 保存通知偏好失败",
+			logger.String("error", err.Error()),
+			logger.String("student_id", pref.StudentID),
+		)
+		return errs.UpdateNotificationPreferenceError(err)
+	}
+
+	return nil
+}
+
+// preferences 批量查询待通知学生的通知偏好，key 为学号，未设置的学生不返回
+func (m *MessageServiceImpl) preferences(recipients []domain.NotificationRecipient, tableConfig *domain.TableConfig) (map[string]*model.NotificationPreference, error) {
+	ids := make([]string, 0, len(recipients))
+	for _, r := range recipients {
+		ids = append(ids, r.StudentID)
+	}
+
+	list, err := m.prefDAO.ListPreferences(*tableConfig.TableIdentity, ids)
+	if err != nil {
+		m.log.Error("ListPreferences 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetNotificationPreferenceError(err)
+	}
+
+	res := make(map[string]*model.NotificationPreference, len(list))
+	for _, p := range list {
+		res[*p.UserID] = p
+	}
+	return res, nil
+}
+
+// recipientNotifier 选择学生的通知渠道，使用学生设置中第一个已启用的渠道，没有时使用表格的通知渠道
+func (m *MessageServiceImpl) recipientNotifier(pref *model.NotificationPreference, tableConfig *domain.TableConfig) (string, Notifier) {
+	if pref != nil {
+		for _, c := range pref.Channels {
+			if n, ok := m.notifiers.Lookup(c); ok {
+				return c, n
+			}
+		}
+	}
+
+	n, _ := m.notifiers.Get(tableConfig)
+	return noticeChannel(tableConfig), n
+}
+
+// inQuietHours 判断 now 是否处于任一免打扰时段内，时段为左闭右开，End 不晚于 Start 时表示跨天
+func inQuietHours(quietHours []domain.QuietHours, now time.Time) bool {
+	cur := now.Hour()*60 + now.Minute()
+	for _, q := range quietHours {
+		start, err := parseClock(q.Start)
+		if err != nil {
+			continue
+		}
+		end, err := parseClock(q.End)
+		if err != nil {
+			continue
+		}
+
+		if start < end {
+			if cur >= start && cur < end {
+				return true
+			}
+		} else if cur >= start || cur < end {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock 解析 HH:MM，返回当天的分钟数
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func toDomainNotificationPreference(m *model.NotificationPreference) *domain.NotificationPreference {
+	p := &domain.NotificationPreference{
+		OptOut:     m.OptOut,
+		Channels:   m.Channels,
+		QuietHours: m.QuietHours,
+	}
+	if m.UserID != nil {
+		p.StudentID = *m.UserID
+	}
+	if p.Channels == nil {
+		p.Channels = []string{}
+	}
+	if p.QuietHours == nil {
+		p.QuietHours = []domain.QuietHours{}
+	}
+
+	return p
+}
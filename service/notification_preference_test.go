@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, time.June, 1, hour, minute, 0, 0, time.Local)
+	}
+	night := []domain.QuietHours{{Start: "22:00", End: "07:00"}}
+	noon := []domain.QuietHours{{Start: "12:00", End: "13:30"}}
+
+	tests := []struct {
+		name       string
+		quietHours []domain.QuietHours
+		now        time.Time
+		want       bool
+	}{
+		{name: "no quiet hours", now: at(23, 0)},
+		{name: "same day inside", quietHours: noon, now: at(12, 30), want: true},
+		{name: "same day start inclusive", quietHours: noon, now: at(12, 0), want: true},
+		{name: "same day end exclusive", quietHours: noon, now: at(13, 30)},
+		{name: "across midnight before", quietHours: night, now: at(23, 59), want: true},
+		{name: "across midnight after", quietHours: night, now: at(6, 59), want: true},
+		{name: "across midnight outside", quietHours: night, now: at(7, 0)},
+		{name: "start equals end means all day", quietHours: []domain.QuietHours{{Start: "08:00", End: "08:00"}}, now: at(3, 0), want: true},
+		{name: "invalid period ignored", quietHours: []domain.QuietHours{{Start: "25:00", End: "07:00"}}, now: at(23, 0)},
+		{name: "any period matches", quietHours: append(noon, night...), now: at(1, 0), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inQuietHours(tt.quietHours, tt.now))
+		})
+	}
+}
+
+func TestUpdateNotificationPreferenceValidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		pref     domain.NotificationPreference
+		wantCode int
+	}{
+		{name: "valid", pref: domain.NotificationPreference{Channels: []string{testNoticeChannel}, QuietHours: []domain.QuietHours{{Start: "22:00", End: "07:00"}}}},
+		{name: "unsupported channel", pref: domain.NotificationPreference{Channels: []string{"sms"}}, wantCode: errs.NotificationPreferenceInvalidErrorCode},
+		{name: "invalid time", pref: domain.NotificationPreference{QuietHours: []domain.QuietHours{{Start: "22:00", End: "7点"}}}, wantCode: errs.NotificationPreferenceInvalidErrorCode},
+		{name: "too many quiet hours", pref: domain.NotificationPreference{QuietHours: make([]domain.QuietHours, maxQuietHours+1)}, wantCode: errs.NotificationPreferenceInvalidErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mocks, tc := newTestMessageService(t, notifierFunc(nil))
+			tt.pref.StudentID = "2023001"
+			if tt.wantCode == 0 {
+				mocks.pref.EXPECT().UpsertPreference(gomock.Any()).
+					DoAndReturn(func(p *model.NotificationPreference) error {
+						assert.Equal(t, *tc.TableIdentity, *p.TableIdentify)
+						assert.Equal(t, "2023001", *p.UserID)
+						assert.Equal(t, tt.pref.Channels, p.Channels)
+						return nil
+					})
+			}
+
+			err := m.UpdateNotificationPreference(&tt.pref, &tc)
+			if tt.wantCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+		})
+	}
+}
+
+// 退订的学生不推送但标记为已通知，免打扰时段内保持未通知，设置了通知渠道时优先使用学生的渠道
+func TestHandleProgressNoticesAppliesPreferences(t *testing.T) {
+	recordID, studentID, progress := "rec-1", "2023001", "处理中"
+	const preferredChannel = "mock-preferred"
+
+	tests := []struct {
+		name        string
+		pref        *model.NotificationPreference
+		wantChannel string
+		wantMarked  bool
+	}{
+		{name: "no preference", wantChannel: testNoticeChannel, wantMarked: true},
+		{name: "opted out", pref: &model.NotificationPreference{OptOut: true}, wantMarked: true},
+		{name: "in quiet hours", pref: &model.NotificationPreference{QuietHours: []domain.QuietHours{{Start: "00:00", End: "00:00"}}}},
+		{name: "preferred channel", pref: &model.NotificationPreference{Channels: []string{"unknown", preferredChannel}}, wantChannel: preferredChannel, wantMarked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var notified []string
+			channel := func(name string) notifierFunc {
+				return func(domain.NotificationRecipient, *domain.TableConfig) (domain.NotificationResult, error) {
+					notified = append(notified, name)
+					return domain.NotificationResult{}, nil
+				}
+			}
+			m, mocks, tc := newTestMessageService(t, channel(testNoticeChannel))
+			m.notifiers.Register(preferredChannel, channel(preferredChannel))
+			// 默认的偏好查询总是返回空，这里替换为按用例返回
+			prefDAO := daoMock.NewMockNotificationPreferenceDAO(gomock.NewController(t))
+			m.prefDAO = prefDAO
+
+			var prefs []*model.NotificationPreference
+			if tt.pref != nil {
+				tt.pref.UserID = &studentID
+				prefs = append(prefs, tt.pref)
+			}
+			prefDAO.EXPECT().ListPreferences(*tc.TableIdentity, []string{studentID}).Return(prefs, nil)
+			mocks.sheet.EXPECT().GetUnNoticedRecordsByTable(*tc.TableIdentity, []string{progress}).
+				Return([]model.Sheet{{RecordID: &recordID, UserID: &studentID, Progress: &progress}}, nil)
+			mocks.delivery.EXPECT().GetLatestDeliveries(*tc.TableIdentity, []string{recordID}).Return(nil, nil)
+			if tt.wantChannel != "" {
+				mocks.delivery.EXPECT().CreateDelivery(gomock.Any()).
+					DoAndReturn(func(d *model.NotificationDelivery) error {
+						assert.Equal(t, tt.wantChannel, d.Channel)
+						return nil
+					})
+			}
+			if tt.wantMarked {
+				mocks.sheet.EXPECT().MarkRecordNoticed(*tc.TableIdentity, recordID, progress).Return(nil)
+			}
+
+			m.handleProgressNotices(tc)
+			if tt.wantChannel == "" {
+				assert.Empty(t, notified)
+			} else {
+				assert.Equal(t, []string{tt.wantChannel}, notified)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+const replySummaryLength = 60 // 回复通知中附带的回复内容长度上限（字符数）
+
+// handleReplyNotices 将工作人员的新回复通知给学生，与进度通知一样遵循学生的通知偏好
+// 每次发送都写入投递日志，失败的回复按退避时间重试，超过最大尝试次数后不再重试
+func (m *MessageServiceImpl) handleReplyNotices(table domain.TableConfig) {
+	list, err := m.threadDAO.ListUnNoticedMessages(*table.TableIdentity, FeedbackSenderStaff, notificationMaxAttempts)
+	if err != nil {
+		m.log.Error("ListUnNoticedMessages 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *table.TableIdentity),
+		)
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+
+	recipients := make([]domain.NotificationRecipient, 0, len(list))
+	for _, msg := range list {
+		reply := toDomainFeedbackMessage(msg)
+		recipient := domain.NotificationRecipient{
+			StudentID: *msg.UserID,
+			RecordID:  *msg.RecordID,
+			Reply:     &reply,
+		}
+		// 补充记录内容，用于渲染通知模板，记录不存在时只发送回复内容
+		if record, err := m.sheetDao.GetSheetRecord(*table.TableIdentity, *msg.RecordID); err == nil {
+			recipient.Record = record.Record
+			if record.ShareUrl != nil {
+				recipient.ShareURL = *record.ShareUrl
+			}
+			if record.Progress != nil {
+				recipient.Progress = *record.Progress
+			}
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	latest, err := m.latestReplyDeliveries(list, &table)
+	if err != nil {
+		return
+	}
+	prefs, err := m.preferences(recipients, &table)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, recipient := range recipients {
+		m.saveInbox(recipient, &table)
+
+		pref := prefs[recipient.StudentID]
+		if pref != nil && pref.OptOut {
+			m.markReplyNoticed(recipient.Reply.ID)
+			continue
+		}
+		if pref != nil && inQuietHours(pref.QuietHours, now) {
+			continue
+		}
+
+		// 已投递成功但标记失败的回复，只需重新标记
+		last, ok := latest[recipient.Reply.ID]
+		if !ok || !last.Success {
+			if ok && !notificationRetryDue(last) {
+				continue
+			}
+			attempt := 1
+			if ok {
+				attempt = last.Attempt + 1
+			}
+
+			channel, notifier := m.recipientNotifier(pref, &table)
+			res, err := notifier.Notify(recipient, &table)
+			m.recordDelivery(recipient, &table, channel, attempt, res, err)
+			if err != nil {
+				m.log.Error("send reply notification failed",
+					logger.String("notice_channel", channel),
+					logger.String("student_id", recipient.StudentID),
+					logger.String("record_id", recipient.RecordID),
+					logger.Int("attempt", attempt),
+					logger.String("error", err.Error()),
+				)
+				// 尝试次数同时记在留言上，达到上限后不再出现在待通知列表中
+				if err := m.threadDAO.IncrMessageNoticeAttempts(recipient.Reply.ID); err != nil {
+					m.log.Error("IncrMessageNoticeAttempts 更新留言通知次数失败",
+						logger.String("error", err.Error()),
+						logger.Uint64("message_id", recipient.Reply.ID),
+					)
+				}
+				continue
+			}
+		}
+
+		m.markReplyNoticed(recipient.Reply.ID)
+	}
+}
+
+// latestReplyDeliveries 查询各条回复最近一次的投递记录，按留言 ID 索引
+func (m *MessageServiceImpl) latestReplyDeliveries(list []*model.FeedbackMessage, tableConfig *domain.TableConfig) (map[uint64]*model.NotificationDelivery, error) {
+	ids := make([]uint64, 0, len(list))
+	for _, msg := range list {
+		ids = append(ids, msg.ID)
+	}
+
+	deliveries, err := m.deliveryDAO.GetLatestReplyDeliveries(ids)
+	if err != nil {
+		m.log.Error("GetLatestReplyDeliveries 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetNotificationDeliveryError(err)
+	}
+
+	res := make(map[uint64]*model.NotificationDelivery, len(deliveries))
+	for _, d := range deliveries {
+		if d.MessageID != nil {
+			res[*d.MessageID] = d
+		}
+	}
+	return res, nil
+}
+
+func (m *MessageServiceImpl) markReplyNoticed(id uint64) {
+	if err := m.threadDAO.MarkMessageNoticed(id); err != nil {
+		m.log.Error("MarkMessageNoticed 更新留言通知状态失败",
+			logger.String("error", err.Error()),
+			logger.Uint64("message_id", id),
+		)
+	}
+}
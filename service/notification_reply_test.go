@@ -0,0 +1,111 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// 回复通知与进度通知共用投递日志：每次发送都记录，失败按退避重试，成功后标记留言已通知
+func TestHandleReplyNoticesUsesDeliveryLedger(t *testing.T) {
+	var messageID uint64 = 42
+	recordID, studentID := "rec-1", "2023001"
+
+	tests := []struct {
+		name        string
+		last        *model.NotificationDelivery
+		notifyErr   error
+		wantNotify  bool
+		wantAttempt int
+		wantMarked  bool
+	}{
+		{
+			name:        "first attempt fails",
+			notifyErr:   errors.New("ccnubox unavailable"),
+			wantNotify:  true,
+			wantAttempt: 1,
+		},
+		{
+			name:        "first attempt succeeds",
+			wantNotify:  true,
+			wantAttempt: 1,
+			wantMarked:  true,
+		},
+		{
+			name: "retry not due yet",
+			last: &model.NotificationDelivery{Attempt: 2, CreatedAt: time.Now()},
+		},
+		{
+			name:        "retry due after backoff",
+			last:        &model.NotificationDelivery{Attempt: 2, CreatedAt: time.Now().Add(-time.Hour)},
+			wantNotify:  true,
+			wantAttempt: 3,
+			wantMarked:  true,
+		},
+		{
+			name:       "delivered but not marked",
+			last:       &model.NotificationDelivery{Attempt: 1, Success: true},
+			wantMarked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notified := 0
+			m, mocks, tc := newTestMessageService(t, notifierFunc(func(r domain.NotificationRecipient, _ *domain.TableConfig) (domain.NotificationResult, error) {
+				notified++
+				require.NotNil(t, r.Reply)
+				assert.Equal(t, messageID, r.Reply.ID)
+				assert.Equal(t, "已处理", r.Reply.Content)
+				return domain.NotificationResult{StatusCode: 200}, tt.notifyErr
+			}))
+
+			mocks.thread.EXPECT().ListUnNoticedMessages(*tc.TableIdentity, FeedbackSenderStaff, notificationMaxAttempts).
+				Return([]*model.FeedbackMessage{{
+					ID:       messageID,
+					RecordID: &recordID,
+					UserID:   &studentID,
+					Sender:   FeedbackSenderStaff,
+					Content:  "已处理",
+				}}, nil)
+			mocks.sheet.EXPECT().GetSheetRecord(*tc.TableIdentity, recordID).Return(nil, gorm.ErrRecordNotFound)
+			var latest []*model.NotificationDelivery
+			if tt.last != nil {
+				tt.last.MessageID = &messageID
+				latest = append(latest, tt.last)
+			}
+			mocks.delivery.EXPECT().GetLatestReplyDeliveries([]uint64{messageID}).Return(latest, nil)
+			if tt.wantNotify {
+				mocks.delivery.EXPECT().CreateDelivery(gomock.Any()).
+					DoAndReturn(func(d *model.NotificationDelivery) error {
+						require.NotNil(t, d.MessageID)
+						assert.Equal(t, messageID, *d.MessageID)
+						assert.Equal(t, recordID, *d.RecordID)
+						assert.Equal(t, tt.wantAttempt, d.Attempt)
+						assert.Equal(t, tt.notifyErr == nil, d.Success)
+						return nil
+					})
+			}
+			if tt.notifyErr != nil {
+				mocks.thread.EXPECT().IncrMessageNoticeAttempts(messageID).Return(nil)
+			}
+			if tt.wantMarked {
+				mocks.thread.EXPECT().MarkMessageNoticed(messageID).Return(nil)
+			}
+
+			m.handleReplyNotices(tc)
+			if tt.wantNotify {
+				assert.Equal(t, 1, notified)
+			} else {
+				assert.Equal(t, 0, notified)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 下一页的 pageToken 指向本页最后一条通知，传回后从该通知之后继续查询
+func TestListNotificationsPaging(t *testing.T) {
+	m, mocks, tc := newTestMessageService(t, notifierFunc(nil))
+	studentID, recordID, progress := "2023001", "rec-1", "处理中"
+
+	mocks.inbox.EXPECT().ListNotifications(*tc.TableIdentity, studentID, true, new(uint64), 2).
+		Return([]*model.Notification{
+			{ID: 9, RecordID: &recordID, Progress: &progress, Title: "t"},
+			{ID: 7, RecordID: &recordID, Progress: &progress, Title: "t"},
+		}, true, nil)
+	first, err := m.ListNotifications(studentID, true, nil, 2, &tc)
+	require.NoError(t, err)
+	require.Len(t, first.Notifications, 2)
+	assert.Equal(t, recordID, first.Notifications[0].RecordID)
+	assert.Equal(t, progress, first.Notifications[0].Progress)
+	assert.True(t, *first.HasMore)
+	require.NotNil(t, first.PageToken)
+
+	lastID := uint64(7)
+	mocks.inbox.EXPECT().ListNotifications(*tc.TableIdentity, studentID, true, &lastID, 2).
+		Return([]*model.Notification{{ID: 3}}, false, nil)
+	second, err := m.ListNotifications(studentID, true, first.PageToken, 2, &tc)
+	require.NoError(t, err)
+	assert.Len(t, second.Notifications, 1)
+	assert.False(t, *second.HasMore)
+	assert.Nil(t, second.PageToken)
+
+	invalid := "not-a-token"
+	_, err = m.ListNotifications(studentID, true, &invalid, 2, &tc)
+	require.Error(t, err)
+	assert.Equal(t, errs.PageTokenInvalidCode, errorx.ToCustomError(err).Code)
+}
+
+// 站内通知与推送使用相同的文案，退订推送的学生仍会收到站内通知
+func TestHandleProgressNoticesSavesInboxForOptedOut(t *testing.T) {
+	recordID, studentID, progress := "rec-1", "2023001", "处理中"
+	m, mocks, tc := newTestMessageService(t, notifierFunc(func(domain.NotificationRecipient, *domain.TableConfig) (domain.NotificationResult, error) {
+		t.Fatal("opted-out student should not be notified")
+		return domain.NotificationResult{}, nil
+	}))
+	ctrl := gomock.NewController(t)
+	prefDAO := daoMock.NewMockNotificationPreferenceDAO(ctrl)
+	inboxDAO := daoMock.NewMockNotificationDAO(ctrl)
+	m.prefDAO, m.inboxDAO = prefDAO, inboxDAO
+
+	mocks.sheet.EXPECT().GetUnNoticedRecordsByTable(*tc.TableIdentity, []string{progress}).
+		Return([]model.Sheet{{RecordID: &recordID, UserID: &studentID, Progress: &progress}}, nil)
+	mocks.delivery.EXPECT().GetLatestDeliveries(*tc.TableIdentity, []string{recordID}).Return(nil, nil)
+	prefDAO.EXPECT().ListPreferences(*tc.TableIdentity, []string{studentID}).
+		Return([]*model.NotificationPreference{{UserID: &studentID, OptOut: true}}, nil)
+	inboxDAO.EXPECT().CreateNotification(gomock.Any()).
+		DoAndReturn(func(n *model.Notification) error {
+			assert.Equal(t, studentID, *n.UserID)
+			assert.Equal(t, recordID, *n.RecordID)
+			assert.Equal(t, progress, *n.Progress)
+			assert.Equal(t, m.nc.Progress[progress].Title, n.Title)
+			assert.Equal(t, m.nc.Progress[progress].Content, n.Content)
+			assert.Zero(t, n.MessageID)
+			return nil
+		})
+	mocks.sheet.EXPECT().MarkRecordNoticed(*tc.TableIdentity, recordID, progress).Return(nil)
+
+	m.handleProgressNotices(tc)
+}
+
+// 进度反复变化（A→B→A）时，每次回到同一进度按进度版本各写入一条站内通知
+func TestHandleProgressNoticesSavesInboxPerTransition(t *testing.T) {
+	recordID, studentID, progress := "rec-1", "2023001", "处理中"
+	m, mocks, tc := newTestMessageService(t, notifierFunc(nil))
+	ctrl := gomock.NewController(t)
+	prefDAO := daoMock.NewMockNotificationPreferenceDAO(ctrl)
+	inboxDAO := daoMock.NewMockNotificationDAO(ctrl)
+	m.prefDAO, m.inboxDAO = prefDAO, inboxDAO
+	// 退订推送的学生只写入站内通知，不涉及投递
+	prefDAO.EXPECT().ListPreferences(*tc.TableIdentity, []string{studentID}).
+		Return([]*model.NotificationPreference{{UserID: &studentID, OptOut: true}}, nil).Times(2)
+	mocks.delivery.EXPECT().GetLatestDeliveries(*tc.TableIdentity, []string{recordID}).Return(nil, nil).Times(2)
+	mocks.sheet.EXPECT().MarkRecordNoticed(*tc.TableIdentity, recordID, progress).Return(nil).Times(2)
+
+	var versions []uint64
+	inboxDAO.EXPECT().CreateNotification(gomock.Any()).
+		DoAndReturn(func(n *model.Notification) error {
+			versions = append(versions, n.ProgressVersion)
+			return nil
+		}).Times(2)
+
+	for _, version := range []uint64{1, 3} {
+		mocks.sheet.EXPECT().GetUnNoticedRecordsByTable(*tc.TableIdentity, []string{progress}).
+			Return([]model.Sheet{{RecordID: &recordID, UserID: &studentID, Progress: &progress, ProgressVersion: version}}, nil)
+		m.handleProgressNotices(tc)
+	}
+	assert.Equal(t, []uint64{1, 3}, versions)
+}
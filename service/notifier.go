@@ -0,0 +1,170 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+)
+
+const NoticeChannelCCNUBox = "ccnubox" // 华师匣子消息推送
+
+// Notifier 反馈处理完成通知渠道，一个实现对应基础配置表中的一个 notice_channel
+type Notifier interface {
+	Notify(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) (domain.NotificationResult, error)
+}
+
+// NotifierRegistry 按 notice_channel 查找通知渠道，新应用只需在基础配置表中选择已注册的渠道即可接入通知
+type NotifierRegistry struct {
+	notifiers map[string]Notifier
+}
+
+func NewNotifierRegistry(log logger.Logger, cc *config.CCNUBoxMessage, mc *config.MailConfig, nc *config.NoticeConfig) *NotifierRegistry {
+	r := &NotifierRegistry{
+		notifiers: make(map[string]Notifier),
+	}
+	r.Register(NoticeChannelCCNUBox, newCCNUBoxNotifier(log, cc, nc))
+	// 未配置 SMTP 服务器时不启用邮件通知
+	if mc.Host != "" {
+		r.Register(NoticeChannelEmail, newMailNotifier(log, mc, nc))
+	}
+
+	return r
+}
+
+// Register 注册通知渠道，同名渠道会被覆盖
+func (r *NotifierRegistry) Register(channel string, n Notifier) {
+	r.notifiers[channel] = n
+}
+
+// Get 获取表格使用的通知渠道
+func (r *NotifierRegistry) Get(tableConfig *domain.TableConfig) (Notifier, bool) {
+	return r.Lookup(noticeChannel(tableConfig))
+}
+
+// Lookup 按名称获取通知渠道
+func (r *NotifierRegistry) Lookup(channel string) (Notifier, bool) {
+	n, ok := r.notifiers[channel]
+	return n, ok
+}
+
+// noticeChannel 获取表格的通知渠道，未配置 notice_channel 时沿用表格标识，兼容原有的 ccnubox 表格
+func noticeChannel(tableConfig *domain.TableConfig) string {
+	if tableConfig.NoticeChannel != "" {
+		return tableConfig.NoticeChannel
+	}
+	if tableConfig.TableIdentity != nil {
+		return *tableConfig.TableIdentity
+	}
+	return ""
+}
+
+// noticeMessage 获取通知内容，工作人员回复时使用 reply 配置并附上回复摘要，未配置的进度使用通用内容
+func noticeMessage(nc *config.NoticeConfig, recipient domain.NotificationRecipient) config.NoticeMessage {
+	if recipient.Reply != nil {
+		return config.NoticeMessage{
+			Title:   nc.Reply.Title,
+			Content: fmt.Sprintf("%s：%s", nc.Reply.Content, truncateRunes(recipient.Reply.Content, replySummaryLength)),
+		}
+	}
+	if m, ok := nc.Progress[recipient.Progress]; ok {
+		return m
+	}
+	return config.NoticeMessage{
+		Title:   "反馈进度更新提醒",
+		Content: fmt.Sprintf("您的问题进度已更新为%s，点击查看详情", recipient.Progress),
+	}
+}
+
+// ccnuBoxNotifier 通过华师匣子 feed 接口推送通知
+type ccnuBoxNotifier struct {
+	log    logger.Logger
+	cc     *config.CCNUBoxMessage
+	nc     *config.NoticeConfig
+	client *http.Client
+}
+
+func newCCNUBoxNotifier(log logger.Logger, cc *config.CCNUBoxMessage, nc *config.NoticeConfig) Notifier {
+	return &ccnuBoxNotifier{
+		log:    log,
+		cc:     cc,
+		nc:     nc,
+		client: &http.Client{},
+	}
+}
+
+func (n *ccnuBoxNotifier) Notify(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) (domain.NotificationResult, error) {
+	var res domain.NotificationResult
+
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(n.cc.BasicUser+":"+n.cc.BasicPassword))
+	notice := noticeMessage(n.nc, recipient)
+	message := domain.CCNUBoxFeedMessage{
+		Content:   notice.Content,
+		StudentID: recipient.StudentID,
+		Title:     notice.Title,
+		RecordID:  recipient.RecordID,
+	}
+
+	// 编码请求体
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		n.log.Error("marshal request body failed",
+			logger.String("student_id", recipient.StudentID),
+			logger.String("error", err.Error()),
+		)
+		return res, errs.SerializationError(fmt.Errorf("编码请求体失败: %w", err))
+	}
+
+	// 创建HTTP请求
+	req, err := http.NewRequest("POST", n.cc.BaseURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		n.log.Error("create http request failed",
+			logger.String("student_id", recipient.StudentID),
+			logger.String("error", err.Error()),
+		)
+		return res, errs.HTTPRequestCreationError(fmt.Errorf("创建请求失败: %w", err))
+	}
+	// 设置请求头
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", auth)
+	// 发送请求
+	resp, err := n.client.Do(req)
+	if err != nil {
+		n.log.Error("send request failed",
+			logger.String("student_id", recipient.StudentID),
+			logger.String("error", err.Error()),
+		)
+		return res, errs.CCNUBoxRequestError(fmt.Errorf("发送请求失败: %w", err))
+	}
+	defer resp.Body.Close()
+	res.StatusCode = resp.StatusCode
+
+	// 读取响应
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		n.log.Error("read response failed",
+			logger.String("student_id", recipient.StudentID),
+			logger.String("error", err.Error()),
+		)
+		return res, errs.HTTPResponseReadError(fmt.Errorf("读取响应失败: %w", err))
+	}
+	res.Response = string(body)
+
+	if resp.StatusCode != http.StatusOK {
+		n.log.Error("ccnubox response not ok",
+			logger.String("student_id", recipient.StudentID),
+			logger.String("status", fmt.Sprintf("%d", resp.StatusCode)),
+			logger.String("body", string(body)),
+		)
+		return res, errs.CCNUBoxResponseError(fmt.Errorf("请求返回异常: %d : %s", resp.StatusCode, string(body)))
+	}
+
+	return res, nil
+}
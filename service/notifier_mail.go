@@ -0,0 +1,171 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/mailer"
+)
+
+const NoticeChannelEmail = "email" // SMTP 邮件
+
+var qqNumberRegexp = regexp.MustCompile(`^[1-9][0-9]{4,11}$`)
+
+// mailTemplate 解析后的邮件标题与正文模板
+type mailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// mailTemplateData 渲染邮件模板时可使用的字段
+type mailTemplateData struct {
+	TableIdentity string
+	TableName     string
+	RecordID      string
+	StudentID     string
+	ShareURL      string
+	Record        map[string]any
+	Progress      string // 记录当前的进度
+	Title         string // notice 配置中该进度的通知标题
+	Content       string // notice 配置中该进度的通知内容
+	Reply         string // 工作人员回复的内容，进度通知为空
+}
+
+// mailNotifier 通过 SMTP 将处理完成通知发送到记录中填写的邮箱，填写 QQ 号时发送到对应的 QQ 邮箱
+type mailNotifier struct {
+	log          logger.Logger
+	nc           *config.NoticeConfig
+	m            mailer.Mailer
+	contactField string
+	defaultTmpl  mailTemplate
+	templates    map[string]mailTemplate // key 为表格标识
+}
+
+func newMailNotifier(log logger.Logger, mc *config.MailConfig, nc *config.NoticeConfig) Notifier {
+	n := &mailNotifier{
+		log: log,
+		nc:  nc,
+		m: mailer.New(mailer.Config{
+			Host:     mc.Host,
+			Port:     mc.Port,
+			Username: mc.Username,
+			Password: mc.Password,
+			From:     mc.From,
+			Timeout:  time.Duration(mc.Timeout) * time.Second,
+		}),
+		contactField: mc.ContactField,
+		defaultTmpl:  mustParseMailTemplate("default", mc.Default),
+		templates:    make(map[string]mailTemplate, len(mc.Templates)),
+	}
+	for tableIdentify, t := range mc.Templates {
+		// 单独配置的模板未填写的部分沿用默认模板
+		if t.Subject == "" {
+			t.Subject = mc.Default.Subject
+		}
+		if t.Body == "" {
+			t.Body = mc.Default.Body
+		}
+		n.templates[tableIdentify] = mustParseMailTemplate(tableIdentify, t)
+	}
+
+	return n
+}
+
+// mustParseMailTemplate 解析邮件模板，模板属于配置，解析失败时与其他配置错误一样直接 panic
+func mustParseMailTemplate(name string, t config.MailTemplate) mailTemplate {
+	subject, err := template.New(name + "-subject").Option("missingkey=zero").Parse(t.Subject)
+	if err != nil {
+		panic(fmt.Sprintf("mail 配置无效: 模板 %s 的 subject 解析失败: %v", name, err))
+	}
+	body, err := template.New(name + "-body").Option("missingkey=zero").Parse(t.Body)
+	if err != nil {
+		panic(fmt.Sprintf("mail 配置无效: 模板 %s 的 body 解析失败: %v", name, err))
+	}
+
+	return mailTemplate{subject: subject, body: body}
+}
+
+func (n *mailNotifier) Notify(recipient domain.NotificationRecipient, tableConfig *domain.TableConfig) (domain.NotificationResult, error) {
+	var res domain.NotificationResult
+
+	contact, _ := recipient.Record[n.contactField].(string)
+	to, ok := mailAddress(contact)
+	if !ok {
+		return res, errs.MailAddressNotFoundError(fmt.Errorf("record %s has no valid email in %s", recipient.RecordID, n.contactField))
+	}
+
+	notice := noticeMessage(n.nc, recipient)
+	data := mailTemplateData{
+		RecordID:  recipient.RecordID,
+		StudentID: recipient.StudentID,
+		ShareURL:  recipient.ShareURL,
+		Record:    recipient.Record,
+		Progress:  recipient.Progress,
+		Title:     notice.Title,
+		Content:   notice.Content,
+	}
+	if recipient.Reply != nil {
+		data.Reply = recipient.Reply.Content
+	}
+	if tableConfig.TableIdentity != nil {
+		data.TableIdentity = *tableConfig.TableIdentity
+	}
+	if tableConfig.TableName != nil {
+		data.TableName = *tableConfig.TableName
+	}
+
+	tmpl, ok := n.templates[strings.ToLower(data.TableIdentity)]
+	if !ok {
+		tmpl = n.defaultTmpl
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return res, errs.MailRenderError(err)
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return res, errs.MailRenderError(err)
+	}
+
+	err := n.m.Send(context.Background(), mailer.Message{
+		To:      []string{to},
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	})
+	if err != nil {
+		n.log.Error("send mail failed",
+			logger.String("record_id", recipient.RecordID),
+			logger.String("error", err.Error()),
+		)
+		return res, errs.MailSendError(err)
+	}
+
+	return res, nil
+}
+
+// mailAddress 从联系方式中解析收件地址，支持邮箱和 QQ 号
+func mailAddress(contact string) (string, bool) {
+	contact = strings.TrimSpace(contact)
+	if contact == "" {
+		return "", false
+	}
+	if qqNumberRegexp.MatchString(contact) {
+		return contact + "@qq.com", true
+	}
+
+	addr, err := mail.ParseAddress(contact)
+	if err != nil {
+		return "", false
+	}
+	return addr.Address, true
+}
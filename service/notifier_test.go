@@ -0,0 +1,47 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// 每个配置的进度使用各自的文案，未配置的进度使用默认文案，回复通知附带截断后的回复摘要
+func TestNoticeMessage(t *testing.T) {
+	nc := &config.NoticeConfig{
+		Progress: map[string]config.NoticeMessage{
+			"处理中": {Title: "已受理", Content: "您的反馈正在处理"},
+			"已完成": {Title: "已解决", Content: "您的反馈已处理完成"},
+		},
+		Reply: config.NoticeMessage{Title: "新回复", Content: "工作人员回复了您的反馈"},
+	}
+	longReply := strings.Repeat("好", replySummaryLength+10)
+
+	tests := []struct {
+		name      string
+		recipient domain.NotificationRecipient
+		want      config.NoticeMessage
+	}{
+		{name: "processing", recipient: domain.NotificationRecipient{Progress: "处理中"}, want: nc.Progress["处理中"]},
+		{name: "completed", recipient: domain.NotificationRecipient{Progress: "已完成"}, want: nc.Progress["已完成"]},
+		{
+			name:      "unconfigured progress",
+			recipient: domain.NotificationRecipient{Progress: "待补充"},
+			want:      config.NoticeMessage{Title: "反馈进度更新提醒", Content: "您的问题进度已更新为待补充，点击查看详情"},
+		},
+		{
+			name:      "reply",
+			recipient: domain.NotificationRecipient{Progress: "处理中", Reply: &domain.FeedbackMessage{Content: longReply}},
+			want:      config.NoticeMessage{Title: "新回复", Content: "工作人员回复了您的反馈：" + strings.Repeat("好", replySummaryLength) + "……"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, noticeMessage(nc, tt.recipient))
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+const (
+	outboxPollInterval = 5 * time.Second  // 轮询待处理任务的间隔
+	outboxBatchSize    = 20               // 每次轮询处理的任务数
+	outboxLease        = 2 * time.Minute  // 认领任务后的租约时长，期间其他实例不会处理该任务
+	outboxBaseBackoff  = 10 * time.Second // 首次重试的等待时间，之后按指数增长
+	outboxMaxBackoff   = 30 * time.Minute // 重试等待时间上限
+	outboxMaxAttempts  = 10               // 最大重试次数，超过后标记为 dead
+	outboxCreateGrace  = 5 * time.Minute  // 登记后等待请求自行确认创建结果的时间，超过后由后台补建飞书记录
+)
+
+//go:generate mockgen -destination=./mock/outbox_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service OutboxService
+type OutboxService interface {
+	PrepareCreatedRecord(record *domain.TableRecord, content string, tableConfig domain.TableConfig) (*domain.RecordIntent, error)
+	ConfirmCreatedRecord(intent *domain.RecordIntent, recordID string)
+	AbortCreatedRecord(intent *domain.RecordIntent, cause error)
+}
+
+type OutboxServiceImpl struct {
+	log       logger.Logger
+	outboxDAO dao.OutboxDAO
+	s         SheetService
+	m         MessageService
+	webhook   WebhookService
+}
+
+func NewOutboxService(log logger.Logger, outboxDAO dao.OutboxDAO, s SheetService, m MessageService, webhook WebhookService, elector *LeaderElector) OutboxService {
+	o := &OutboxServiceImpl{
+		log:       log,
+		outboxDAO: outboxDAO,
+		s:         s,
+		m:         m,
+		webhook:   webhook,
+	}
+
+	// 后台处理新增记录的后续任务，只在主节点上运行，减少多个副本争抢同一批任务
+	elector.Go("outbox worker", func(ctx context.Context) {
+		ticker := time.NewTicker(outboxPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				o.processDueOutboxes(ctx)
+			}
+		}
+	})
+
+	return o
+}
+
+// PrepareCreatedRecord 在创建飞书记录之前登记处理任务，登记失败时不应再创建飞书记录
+// 任务在 outboxCreateGrace 之后才会被后台处理，期间由请求调用 ConfirmCreatedRecord 确认创建结果；
+// 请求在创建后崩溃或确认失败时，后台使用同一个 ClientToken 补建，得到的是同一条飞书记录
+func (o *OutboxServiceImpl) PrepareCreatedRecord(record *domain.TableRecord, content string, tableConfig domain.TableConfig) (*domain.RecordIntent, error) {
+	token := uuid.NewString()
+	m := &model.RecordOutbox{
+		TableIdentify: tableConfig.TableIdentity,
+		ClientToken:   &token,
+		TableConfig:   tableConfig,
+		Fields:        record.Record,
+		Content:       &content,
+		Status:        model.OutboxStatusPending,
+		NextRetryAt:   time.Now().Add(outboxCreateGrace),
+	}
+
+	err := o.outboxDAO.CreateOutbox(m)
+	if err != nil {
+		o.log.Error("CreateOutbox 登记处理任务失败",
+			logger.String("error", err.Error()),
+			logger.String("client_token", token),
+		)
+		return nil, errs.CreateRecordOutboxError(err)
+	}
+
+	return &domain.RecordIntent{
+		ID:          m.ID,
+		ClientToken: token,
+	}, nil
+}
+
+// ConfirmCreatedRecord 飞书记录创建成功后写入记录 ID，后台立即开始后续处理
+// 确认失败时只记录日志，任务到期后由后台按 ClientToken 找回同一条记录，不会丢失
+func (o *OutboxServiceImpl) ConfirmCreatedRecord(intent *domain.RecordIntent, recordID string) {
+	err := o.outboxDAO.ConfirmOutbox(intent.ID, recordID, time.Now())
+	if err != nil {
+		o.log.Error("ConfirmOutbox 确认飞书记录失败，将由后台补建",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+			logger.String("client_token", intent.ClientToken),
+		)
+	}
+}
+
+// AbortCreatedRecord 创建飞书记录失败后处理登记的任务
+// 飞书明确返回错误时记录一定没有创建，删除任务；网络错误时无法确定是否已创建，保留任务由后台按 ClientToken 补建
+func (o *OutboxServiceImpl) AbortCreatedRecord(intent *domain.RecordIntent, cause error) {
+	var customErr *errorx.CustomError
+	if !errors.As(cause, &customErr) || customErr.Code != errs.LarkResponseErrorCode {
+		o.log.Warn("创建飞书记录结果未知，将由后台补建",
+			logger.String("client_token", intent.ClientToken),
+		)
+		return
+	}
+
+	err := o.outboxDAO.DeleteOutbox(intent.ID)
+	if err != nil {
+		o.log.Error("DeleteOutbox 删除处理任务失败",
+			logger.String("error", err.Error()),
+			logger.String("client_token", intent.ClientToken),
+		)
+	}
+}
+
+// processDueOutboxes 处理到期的任务，ctx 取消后不再认领新任务，未处理的任务在下次启动后继续
+func (o *OutboxServiceImpl) processDueOutboxes(ctx context.Context) {
+	now := time.Now()
+	list, err := o.outboxDAO.GetDueOutboxes(now, outboxBatchSize)
+	if err != nil {
+		o.log.Error("GetDueOutboxes 查询待处理任务失败",
+			logger.String("error", err.Error()),
+		)
+		return
+	}
+
+	for i := range list {
+		if ctx.Err() != nil {
+			return
+		}
+		item := &list[i]
+		leaseUntil := now.Add(outboxLease)
+		ok, err := o.outboxDAO.ClaimOutbox(item.ID, item.NextRetryAt, leaseUntil)
+		if err != nil {
+			o.log.Error("ClaimOutbox 认领任务失败",
+				logger.String("error", err.Error()),
+				logger.String("record_id", outboxRecordID(item)),
+			)
+			continue
+		}
+		if !ok {
+			// 已被其他实例认领
+			continue
+		}
+		item.NextRetryAt = leaseUntil
+
+		o.processOutbox(item)
+	}
+}
+
+// processOutbox 依次执行未完成的步骤：获取记录与分享链接、保存到数据库、通知管理员
+// 落库先于通知，保证通知失败时数据库中也有记录
+func (o *OutboxServiceImpl) processOutbox(item *model.RecordOutbox) {
+	err := o.runSteps(item)
+	if err == nil {
+		item.Status = model.OutboxStatusDone
+		item.LastError = nil
+		o.saveOutbox(item)
+		return
+	}
+
+	item.Attempts++
+	msg := err.Error()
+	item.LastError = &msg
+	if item.Attempts >= outboxMaxAttempts {
+		item.Status = model.OutboxStatusDead
+		o.log.Error("outbox exceeded max attempts, marked dead",
+			logger.String("table_identity", *item.TableIdentify),
+			logger.String("record_id", outboxRecordID(item)),
+			logger.String("error", msg),
+		)
+	} else {
+		item.NextRetryAt = time.Now().Add(backoff(item.Attempts, outboxBaseBackoff, outboxMaxBackoff))
+		o.log.Warn("outbox step failed, will retry",
+			logger.String("table_identity", *item.TableIdentify),
+			logger.String("record_id", outboxRecordID(item)),
+			logger.Int("attempts", item.Attempts),
+			logger.String("error", msg),
+		)
+	}
+	o.saveOutbox(item)
+}
+
+func (o *OutboxServiceImpl) runSteps(item *model.RecordOutbox) error {
+	tc := item.TableConfig
+
+	if item.RecordID == nil {
+		// 请求未确认创建结果，使用同一个 ClientToken 创建，已创建时飞书返回原记录
+		var token string
+		if item.ClientToken != nil {
+			token = *item.ClientToken
+		}
+		recordID, err := o.s.CreateLarkRecord(&domain.TableRecord{Record: item.Fields}, token, &tc)
+		if err != nil {
+			return err
+		}
+		if recordID == nil {
+			return errors.New("created record id is empty")
+		}
+		item.RecordID = recordID
+		o.saveOutbox(item)
+	}
+
+	if !item.Fetched {
+		record, url, err := o.s.GetTableRecordReqByRecordID(item.RecordID, &tc)
+		if err != nil {
+			return err
+		}
+		if url == nil {
+			return errors.New("record share url is empty")
+		}
+		item.Record = record
+		item.ShareUrl = url
+		item.Fetched = true
+		o.saveOutbox(item)
+	}
+
+	if !item.Persisted {
+		// 使用 upsert 落库，重试时不会因为唯一索引冲突失败
+		err := o.s.UpdateDBRecord(item.RecordID, item.ShareUrl, item.Record, tc)
+		if err != nil {
+			return err
+		}
+		// 与落库在同一步骤中登记，重试时 upsert 不会重复创建记录，事件也只会登记一次
+		err = o.webhook.PublishRecordEvents(WebhookEventRecordCreated, []domain.WebhookRecord{{
+			RecordID: *item.RecordID,
+			Record:   item.Record,
+			ShareURL: *item.ShareUrl,
+		}}, tc)
+		if err != nil {
+			return err
+		}
+		item.Persisted = true
+		o.saveOutbox(item)
+	}
+
+	if !item.Notified {
+		var content string
+		if item.Content != nil {
+			content = *item.Content
+		}
+		report, err := o.m.SendLarkNotification(content, *item.ShareUrl, item.PendingReceivers, &tc)
+		if err != nil {
+			// 只重试发送失败的接收者，避免已收到的群组重复收到卡片
+			if failed := failedLarkReceivers(report); len(failed) > 0 {
+				item.PendingReceivers = failed
+			}
+			return err
+		}
+		item.PendingReceivers = nil
+		item.Notified = true
+	}
+
+	return nil
+}
+
+func (o *OutboxServiceImpl) saveOutbox(item *model.RecordOutbox) {
+	err := o.outboxDAO.UpdateOutbox(item)
+	if err != nil {
+		o.log.Error("UpdateOutbox 保存任务状态失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", outboxRecordID(item)),
+		)
+	}
+}
+
+// outboxRecordID 用于日志的记录标识，尚未确认飞书记录时使用 ClientToken
+func outboxRecordID(item *model.RecordOutbox) string {
+	if item.RecordID != nil {
+		return *item.RecordID
+	}
+	if item.ClientToken != nil {
+		return *item.ClientToken
+	}
+	return ""
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	serviceMock "github.com/muxi-Infra/FeedBack-Backend/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestLogger() logger.Logger {
+	return logger.NewZapLogger(zap.NewNop())
+}
+
+func newTestTableConfig() domain.TableConfig {
+	identity, token, tableID := "mock-table-identity", "mock-table-token", "mock-table-id"
+	return domain.TableConfig{
+		TableIdentity: &identity,
+		TableToken:    &token,
+		TableID:       &tableID,
+	}
+}
+
+// setTestTables 替换全局表格配置，测试结束后恢复
+func setTestTables(t *testing.T, tables ...domain.TableConfig) {
+	tableCfgMu.RLock()
+	old := tableCfg
+	tableCfgMu.RUnlock()
+
+	m := make(map[string]domain.TableConfig, len(tables))
+	for _, tc := range tables {
+		m[*tc.TableIdentity] = tc
+	}
+	setTableConfigs(m)
+	t.Cleanup(func() { setTableConfigs(old) })
+}
+
+type outboxMocks struct {
+	dao     *daoMock.MockOutboxDAO
+	sheet   *serviceMock.MockSheetService
+	message *serviceMock.MockMessageService
+	webhook *serviceMock.MockWebhookService
+}
+
+func newTestOutbox(ctrl *gomock.Controller) (*OutboxServiceImpl, outboxMocks) {
+	m := outboxMocks{
+		dao:     daoMock.NewMockOutboxDAO(ctrl),
+		sheet:   serviceMock.NewMockSheetService(ctrl),
+		message: serviceMock.NewMockMessageService(ctrl),
+		webhook: serviceMock.NewMockWebhookService(ctrl),
+	}
+	return &OutboxServiceImpl{
+		log:       newTestLogger(),
+		outboxDAO: m.dao,
+		s:         m.sheet,
+		m:         m.message,
+		webhook:   m.webhook,
+	}, m
+}
+
+func TestPrepareCreatedRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	o, m := newTestOutbox(ctrl)
+	tc := newTestTableConfig()
+	record := &domain.TableRecord{Record: map[string]any{"反馈内容": "内容"}}
+
+	var saved *model.RecordOutbox
+	m.dao.EXPECT().CreateOutbox(gomock.Any()).DoAndReturn(func(item *model.RecordOutbox) error {
+		item.ID = 7
+		saved = item
+		return nil
+	})
+
+	intent, err := o.PrepareCreatedRecord(record, "内容", tc)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), intent.ID)
+	assert.NotEmpty(t, intent.ClientToken)
+
+	// 任务在宽限期之后才会被后台处理，避免与请求同时创建飞书记录
+	assert.Nil(t, saved.RecordID)
+	assert.Equal(t, intent.ClientToken, *saved.ClientToken)
+	assert.Equal(t, record.Record, saved.Fields)
+	assert.True(t, saved.NextRetryAt.After(time.Now().Add(outboxCreateGrace-time.Minute)))
+
+	m.dao.EXPECT().CreateOutbox(gomock.Any()).Return(errors.New("db error"))
+	_, err = o.PrepareCreatedRecord(record, "内容", tc)
+	assert.Error(t, err)
+}
+
+func TestAbortCreatedRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	o, m := newTestOutbox(ctrl)
+	intent := &domain.RecordIntent{ID: 7, ClientToken: "token"}
+
+	// 飞书明确拒绝时记录一定没有创建，删除任务
+	m.dao.EXPECT().DeleteOutbox(uint64(7)).Return(nil)
+	o.AbortCreatedRecord(intent, errs.LarkResponseError(errors.New("invalid field")))
+
+	// 网络错误时无法确定是否已创建，保留任务由后台补建
+	o.AbortCreatedRecord(intent, errs.LarkRequestError(errors.New("timeout")))
+}
+
+// 请求创建飞书记录后没能确认（确认失败或进程退出），后台使用同一个 ClientToken 找回记录并完成后续处理
+func TestProcessOutboxRecoversUnconfirmedRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	o, m := newTestOutbox(ctrl)
+	tc := newTestTableConfig()
+	token := "client-token"
+	content := "内容"
+	item := &model.RecordOutbox{
+		ID:            7,
+		TableIdentify: tc.TableIdentity,
+		ClientToken:   &token,
+		TableConfig:   tc,
+		Fields:        map[string]any{"反馈内容": content},
+		Content:       &content,
+		Status:        model.OutboxStatusPending,
+	}
+
+	m.dao.EXPECT().ConfirmOutbox(uint64(7), "rec-1", gomock.Any()).Return(errors.New("db error"))
+	o.ConfirmCreatedRecord(&domain.RecordIntent{ID: 7, ClientToken: token}, "rec-1")
+
+	record := map[string]any{"反馈内容": content, "学号": "2021001234"}
+	shareURL := "https://example.com/rec-1"
+	m.dao.EXPECT().UpdateOutbox(gomock.Any()).Return(nil).AnyTimes()
+	gomock.InOrder(
+		m.sheet.EXPECT().
+			CreateLarkRecord(&domain.TableRecord{Record: item.Fields}, token, gomock.Any()).
+			Return(stringPtr("rec-1"), nil),
+		m.sheet.EXPECT().
+			GetTableRecordReqByRecordID(stringPtr("rec-1"), gomock.Any()).
+			Return(record, &shareURL, nil),
+		m.sheet.EXPECT().
+			UpdateDBRecord(stringPtr("rec-1"), &shareURL, record, tc).
+			Return(nil),
+		m.webhook.EXPECT().
+			PublishRecordEvents(WebhookEventRecordCreated, gomock.Any(), tc).
+			Return(nil),
+		m.message.EXPECT().
+			SendLarkNotification(content, shareURL, gomock.Any(), gomock.Any()).
+			Return(domain.LarkDeliveryReport{}, nil),
+	)
+
+	o.processOutbox(item)
+
+	assert.Equal(t, model.OutboxStatusDone, item.Status)
+	assert.Equal(t, "rec-1", *item.RecordID)
+	assert.True(t, item.Persisted)
+	assert.True(t, item.Notified)
+}
+
+// 补建失败时按退避时间重试，已确认的记录不会重复创建
+func TestProcessOutboxRetriesFailedCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	o, m := newTestOutbox(ctrl)
+	tc := newTestTableConfig()
+	token := "client-token"
+	item := &model.RecordOutbox{
+		ID:            7,
+		TableIdentify: tc.TableIdentity,
+		ClientToken:   &token,
+		TableConfig:   tc,
+		Status:        model.OutboxStatusPending,
+	}
+
+	m.dao.EXPECT().UpdateOutbox(gomock.Any()).Return(nil)
+	m.sheet.EXPECT().
+		CreateLarkRecord(gomock.Any(), token, gomock.Any()).
+		Return(nil, errs.LarkRequestError(errors.New("timeout")))
+
+	before := time.Now()
+	o.processOutbox(item)
+
+	assert.Equal(t, model.OutboxStatusPending, item.Status)
+	assert.Nil(t, item.RecordID)
+	assert.Equal(t, 1, item.Attempts)
+	assert.True(t, item.NextRetryAt.After(before))
+}
+
+// 通知部分接收者失败时只记录失败的接收者，下次重试只发送给它们，全部成功后清空
+func TestProcessOutboxRetriesOnlyFailedReceivers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	o, m := newTestOutbox(ctrl)
+	tc := newTestTableConfig()
+	content, shareURL := "内容", "https://example.com/rec-1"
+	item := &model.RecordOutbox{
+		ID:            7,
+		TableIdentify: tc.TableIdentity,
+		RecordID:      stringPtr("rec-1"),
+		TableConfig:   tc,
+		Content:       &content,
+		ShareUrl:      &shareURL,
+		Fetched:       true,
+		Persisted:     true,
+		Status:        model.OutboxStatusPending,
+	}
+
+	ok := domain.LarkReceiver{Type: "chat_id", ID: "oc_ok"}
+	failed := domain.LarkReceiver{Type: "chat_id", ID: "oc_failed"}
+	m.dao.EXPECT().UpdateOutbox(gomock.Any()).Return(nil).AnyTimes()
+	gomock.InOrder(
+		m.message.EXPECT().
+			SendLarkNotification(content, shareURL, nil, gomock.Any()).
+			Return(domain.LarkDeliveryReport{Results: []domain.LarkDeliveryResult{
+				{Receiver: ok, Success: true},
+				{Receiver: failed, Error: "timeout"},
+			}}, errs.LarkMessagePartialFailureError(errors.New("send message failed: 1/2"))),
+		m.message.EXPECT().
+			SendLarkNotification(content, shareURL, []domain.LarkReceiver{failed}, gomock.Any()).
+			Return(domain.LarkDeliveryReport{Results: []domain.LarkDeliveryResult{{Receiver: failed, Success: true}}}, nil),
+	)
+
+	o.processOutbox(item)
+	assert.Equal(t, model.OutboxStatusPending, item.Status)
+	assert.False(t, item.Notified)
+	assert.Equal(t, []domain.LarkReceiver{failed}, item.PendingReceivers)
+
+	o.processOutbox(item)
+	assert.Equal(t, model.OutboxStatusDone, item.Status)
+	assert.True(t, item.Notified)
+	assert.Nil(t, item.PendingReceivers)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
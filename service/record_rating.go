@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+const (
+	maxHandlerLength            = 64               // 处理人的长度上限，与数据库字段一致
+	ratingWriteBackPollInterval = 10 * time.Second // 轮询待重试评价回写的间隔
+)
+
+// RateRecord 学生评价自己已完成的反馈记录，每条记录只能评价一次，评价会回写到表格配置的评价列
+// 回写失败不影响评价结果，由 rating write-back worker 按退避时间重试
+func (s *SheetServiceImpl) RateRecord(recordID, studentID string, score int, comment *string, tableConfig *domain.TableConfig) (*domain.RecordRating, error) {
+	record, err := s.sheetDao.GetSheetRecordByRecordID(*tableConfig.TableIdentity, studentID, recordID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.TableRecordNotFoundError(fmt.Errorf("record %s not found", recordID))
+	}
+	if err != nil {
+		s.log.Error("RateRecord 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return nil, errs.CreateRatingError(err)
+	}
+	if !isRecordCompleted(record.Record) {
+		return nil, errs.RecordNotRatableError(fmt.Errorf("record progress is %s", recordProgress(record.Record)))
+	}
+
+	// 学生接口传入的表格配置来自 token，评价相关的列以最新的表格配置为准
+	cfg, _ := getTableConfig(*tableConfig.TableIdentity)
+	if comment != nil {
+		trimmed := strings.TrimSpace(*comment)
+		comment = &trimmed
+		if trimmed == "" {
+			comment = nil
+		}
+	}
+
+	handlers := recordHandlers(record.Record, cfg.HandlerField)
+	m := &model.RecordRating{
+		TableIdentify:   tableConfig.TableIdentity,
+		RecordID:        &recordID,
+		UserID:          &studentID,
+		Handler:         truncateHandler(strings.Join(handlers, ",")),
+		Score:           score,
+		Comment:         comment,
+		WriteBackStatus: model.OutboxStatusDone,
+	}
+	if len(ratingFields(m, cfg)) > 0 {
+		now := time.Now()
+		m.WriteBackStatus = model.OutboxStatusPending
+		m.NextRetryAt = &now
+	}
+	created, err := s.ratingDAO.CreateRating(m, handlers)
+	if err != nil {
+		s.log.Error("CreateRating 保存评价失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return nil, errs.CreateRatingError(err)
+	}
+	if !created {
+		return nil, errs.RecordAlreadyRatedError(fmt.Errorf("record %s already rated", recordID))
+	}
+
+	if m.WriteBackStatus == model.OutboxStatusPending {
+		s.writeBackRating(m, cfg)
+	}
+
+	return &domain.RecordRating{
+		RecordID:  recordID,
+		Score:     m.Score,
+		Comment:   m.Comment,
+		Handler:   m.Handler,
+		CreatedAt: m.CreatedAt,
+	}, nil
+}
+
+// GetRatingStats 统计表格的评价数与平均分，并按处理人分组
+func (s *SheetServiceImpl) GetRatingStats(tableConfig *domain.TableConfig) (*domain.RatingStats, error) {
+	total, handlers, err := s.ratingDAO.GetRatingStats(*tableConfig.TableIdentity)
+	if err != nil {
+		s.log.Error("GetRatingStats 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetRatingStatsError(err)
+	}
+
+	res := &domain.RatingStats{
+		Table: domain.RatingStat{
+			Count:   total.Count,
+			Average: total.Average,
+		},
+		Handlers: make([]domain.RatingStat, 0, len(handlers)),
+	}
+	for _, h := range handlers {
+		res.Handlers = append(res.Handlers, domain.RatingStat{
+			Handler: h.Handler,
+			Count:   h.Count,
+			Average: h.Average,
+		})
+	}
+
+	return res, nil
+}
+
+// processDueRatingWriteBacks 重试到期的评价回写，由 rating write-back worker 定期调用，ctx 取消后不再认领新任务
+func (s *SheetServiceImpl) processDueRatingWriteBacks(ctx context.Context) {
+	now := time.Now()
+	list, err := s.ratingDAO.GetDueWriteBacks(now, outboxBatchSize)
+	if err != nil {
+		s.log.Error("GetDueWriteBacks 查询待回写评价失败",
+			logger.String("error", err.Error()),
+		)
+		return
+	}
+
+	for i := range list {
+		if ctx.Err() != nil {
+			return
+		}
+		item := &list[i]
+
+		leaseUntil := now.Add(outboxLease)
+		ok, err := s.ratingDAO.ClaimWriteBack(item.ID, *item.NextRetryAt, leaseUntil)
+		if err != nil {
+			s.log.Error("ClaimWriteBack 认领评价回写失败",
+				logger.String("error", err.Error()),
+				logger.Uint64("rating_id", item.ID),
+			)
+			continue
+		}
+		if !ok {
+			// 已被其他实例认领
+			continue
+		}
+		item.NextRetryAt = &leaseUntil
+
+		// 后台重试时没有请求中的表格配置，以最新的表格配置为准
+		cfg, ok := getTableConfig(*item.TableIdentify)
+		if !ok {
+			// 表格已被移除，重试也无法回写
+			s.finishWriteBack(item, fmt.Errorf("table %s not found", *item.TableIdentify), 0)
+			continue
+		}
+		s.writeBackRating(item, cfg)
+	}
+}
+
+// writeBackRating 将评价回写到飞书记录，并保存回写状态，失败时按退避时间安排重试
+func (s *SheetServiceImpl) writeBackRating(m *model.RecordRating, cfg domain.TableConfig) {
+	fields := ratingFields(m, cfg)
+	if len(fields) == 0 {
+		// 评价列已从表格配置中移除，不再需要回写
+		s.finishWriteBack(m, nil, 0)
+		return
+	}
+
+	req := larkbitable.NewUpdateAppTableRecordReqBuilder().
+		AppToken(*cfg.TableToken).
+		TableId(*cfg.TableID).
+		RecordId(*m.RecordID).
+		AppTableRecord(larkbitable.NewAppTableRecordBuilder().
+			Fields(fields).
+			Build()).
+		Build()
+
+	resp, err := s.c.UpdateRecord(context.Background(), req)
+	if err != nil {
+		s.log.Error("writeBackRating UpdateRecord 调用失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", *m.RecordID),
+		)
+		s.finishWriteBack(m, err, outboxMaxAttempts)
+		return
+	}
+	if !resp.Success() {
+		s.log.Error("writeBackRating Lark 接口错误",
+			logger.String("request_id", resp.RequestId()),
+			logger.String("error", larkcore.Prettify(resp.CodeError)),
+			logger.String("record_id", *m.RecordID),
+		)
+		s.finishWriteBack(m, errors.New(larkcore.Prettify(resp.CodeError)), outboxMaxAttempts)
+		return
+	}
+
+	s.finishWriteBack(m, nil, 0)
+}
+
+// finishWriteBack 记录一次回写的结果，失败次数达到 maxAttempts 后标记为 dead，不再重试
+func (s *SheetServiceImpl) finishWriteBack(m *model.RecordRating, cause error, maxAttempts int) {
+	if cause == nil {
+		m.WriteBackStatus = model.OutboxStatusDone
+		m.LastError = nil
+	} else {
+		m.WriteBackAttempts++
+		msg := syncErrorMessage(cause)
+		m.LastError = &msg
+		if m.WriteBackAttempts >= maxAttempts {
+			m.WriteBackStatus = model.OutboxStatusDead
+			s.log.Error("rating write back exceeded max attempts, marked dead",
+				logger.String("table_identity", *m.TableIdentify),
+				logger.String("record_id", *m.RecordID),
+				logger.String("error", msg),
+			)
+		} else {
+			next := time.Now().Add(backoff(m.WriteBackAttempts, outboxBaseBackoff, outboxMaxBackoff))
+			m.NextRetryAt = &next
+		}
+	}
+
+	if err := s.ratingDAO.UpdateWriteBack(m); err != nil {
+		s.log.Error("UpdateWriteBack 保存评价回写状态失败",
+			logger.String("error", err.Error()),
+			logger.Uint64("rating_id", m.ID),
+		)
+	}
+}
+
+// ratingFields 需要回写的评价列，表格未配置评价列时为空
+func ratingFields(m *model.RecordRating, cfg domain.TableConfig) map[string]interface{} {
+	fields := make(map[string]interface{}, 2)
+	if cfg.RatingField != "" {
+		fields[cfg.RatingField] = m.Score
+	}
+	if cfg.RatingCommentField != "" && m.Comment != nil {
+		fields[cfg.RatingCommentField] = *m.Comment
+	}
+	return fields
+}
+
+// recordHandlers 获取记录的处理人，支持文本列和人员列，文本列中多人用逗号或顿号分隔，去重后每人超长时截断
+func recordHandlers(recordData map[string]any, field string) []string {
+	if field == "" {
+		return nil
+	}
+
+	var names []string
+	switch v := recordData[field].(type) {
+	case string:
+		names = strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == '，' || r == '、'
+		})
+	case []any:
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				if name, ok := m["name"].(string); ok {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	handlers := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = truncateHandler(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		handlers = append(handlers, name)
+	}
+	return handlers
+}
+
+// truncateHandler 按字符截断处理人，与数据库字段长度一致
+func truncateHandler(handler string) string {
+	if r := []rune(handler); len(r) > maxHandlerLength {
+		return string(r[:maxHandlerLength])
+	}
+	return handler
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordHandlers(t *testing.T) {
+	tests := []struct {
+		name   string
+		record map[string]any
+		field  string
+		want   []string
+	}{
+		{name: "field not configured", record: map[string]any{"处理人": "张三"}, want: nil},
+		{name: "text", record: map[string]any{"处理人": " 张三 "}, field: "处理人", want: []string{"张三"}},
+		{name: "text with separators", record: map[string]any{"处理人": "张三,李四，王五、张三"}, field: "处理人", want: []string{"张三", "李四", "王五"}},
+		{name: "person column", record: map[string]any{"处理人": []any{
+			map[string]any{"name": "张三", "id": "ou_1"},
+			map[string]any{"name": "李四", "id": "ou_2"},
+			map[string]any{"id": "ou_3"},
+		}}, field: "处理人", want: []string{"张三", "李四"}},
+		{name: "missing", record: map[string]any{}, field: "处理人", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, recordHandlers(tt.record, tt.field))
+		})
+	}
+}
+
+func TestRecordHandlersTruncatesEachHandler(t *testing.T) {
+	long := ""
+	for i := 0; i < maxHandlerLength+5; i++ {
+		long += "长"
+	}
+
+	got := recordHandlers(map[string]any{"处理人": long + ",李四"}, "处理人")
+	require.Len(t, got, 2)
+	assert.Equal(t, maxHandlerLength, len([]rune(got[0])))
+	assert.Equal(t, "李四", got[1])
+}
+
+func newTestRatingService(t *testing.T) (*SheetServiceImpl, *larkMock.MockClient, *daoMock.MockSheetDAO, *daoMock.MockRecordRatingDAO) {
+	ctrl := gomock.NewController(t)
+	client := larkMock.NewMockClient(ctrl)
+	sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+	ratingDAO := daoMock.NewMockRecordRatingDAO(ctrl)
+	s := &SheetServiceImpl{c: client, log: newTestLogger(), sheetDao: sheetDAO, ratingDAO: ratingDAO}
+	return s, client, sheetDAO, ratingDAO
+}
+
+func updateRecordResp(code int) *larkbitable.UpdateAppTableRecordResp {
+	return &larkbitable.UpdateAppTableRecordResp{
+		ApiResp:   &larkcore.ApiResp{Header: http.Header{}},
+		CodeError: larkcore.CodeError{Code: code},
+	}
+}
+
+// 评价按处理人逐个保存；回写失败时评价仍然成功，回写状态保持 pending 并安排重试
+func TestRateRecordSchedulesWriteBackRetry(t *testing.T) {
+	recordID, studentID := "rec-1", "2023001"
+
+	tests := []struct {
+		name       string
+		ratingCol  string
+		larkErr    error
+		wantUpdate bool
+		wantStatus string
+	}{
+		{name: "no rating column", wantStatus: model.OutboxStatusDone},
+		{name: "write back succeeds", ratingCol: "评分", wantUpdate: true, wantStatus: model.OutboxStatusDone},
+		{name: "write back fails", ratingCol: "评分", larkErr: errors.New("timeout"), wantUpdate: true, wantStatus: model.OutboxStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, client, sheetDAO, ratingDAO := newTestRatingService(t)
+			tc := newTestTableConfig()
+			tc.HandlerField = "处理人"
+			tc.RatingField = tt.ratingCol
+			setTestTables(t, tc)
+
+			sheetDAO.EXPECT().GetSheetRecordByRecordID(*tc.TableIdentity, studentID, recordID).
+				Return(&model.Sheet{Record: map[string]any{"进度": "已完成", "处理人": "张三,李四"}}, nil)
+			ratingDAO.EXPECT().CreateRating(gomock.Any(), []string{"张三", "李四"}).
+				DoAndReturn(func(m *model.RecordRating, _ []string) (bool, error) {
+					assert.Equal(t, "张三,李四", m.Handler)
+					if tt.ratingCol == "" {
+						assert.Equal(t, model.OutboxStatusDone, m.WriteBackStatus)
+					} else {
+						assert.Equal(t, model.OutboxStatusPending, m.WriteBackStatus)
+						assert.NotNil(t, m.NextRetryAt)
+					}
+					m.ID = 9
+					return true, nil
+				})
+			if tt.wantUpdate {
+				client.EXPECT().UpdateRecord(gomock.Any(), gomock.Any()).Return(updateRecordResp(0), tt.larkErr)
+				ratingDAO.EXPECT().UpdateWriteBack(gomock.Any()).
+					DoAndReturn(func(m *model.RecordRating) error {
+						assert.Equal(t, tt.wantStatus, m.WriteBackStatus)
+						if tt.larkErr != nil {
+							assert.Equal(t, 1, m.WriteBackAttempts)
+							require.NotNil(t, m.LastError)
+							assert.InDelta(t, outboxBaseBackoff.Seconds(), time.Until(*m.NextRetryAt).Seconds(), 1)
+						}
+						return nil
+					})
+			}
+
+			rating, err := s.RateRecord(recordID, studentID, 5, nil, &tc)
+			require.NoError(t, err)
+			assert.Equal(t, 5, rating.Score)
+		})
+	}
+}
+
+// 后台重试认领到期的回写任务，成功后标记为 done，达到最大次数后标记为 dead，表格已移除时直接标记为 dead
+func TestProcessDueRatingWriteBacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		attempts    int
+		claimed     bool
+		tableExists bool
+		larkCode    int
+		wantUpdate  bool
+		wantStatus  string
+	}{
+		{name: "retry succeeds", attempts: 2, claimed: true, tableExists: true, wantUpdate: true, wantStatus: model.OutboxStatusDone},
+		{name: "claimed by another instance", attempts: 2, tableExists: true},
+		{name: "last attempt fails", attempts: outboxMaxAttempts - 1, claimed: true, tableExists: true, larkCode: 1254000, wantUpdate: true, wantStatus: model.OutboxStatusDead},
+		{name: "table removed", claimed: true, wantStatus: model.OutboxStatusDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, client, _, ratingDAO := newTestRatingService(t)
+			tc := newTestTableConfig()
+			tc.RatingField = "评分"
+			if tt.tableExists {
+				setTestTables(t, tc)
+			} else {
+				setTestTables(t)
+			}
+
+			recordID, studentID := "rec-1", "2023001"
+			due := time.Now().Add(-time.Minute)
+			item := model.RecordRating{
+				ID:                9,
+				TableIdentify:     tc.TableIdentity,
+				RecordID:          &recordID,
+				UserID:            &studentID,
+				Score:             4,
+				WriteBackStatus:   model.OutboxStatusPending,
+				WriteBackAttempts: tt.attempts,
+				NextRetryAt:       &due,
+			}
+			ratingDAO.EXPECT().GetDueWriteBacks(gomock.Any(), outboxBatchSize).Return([]model.RecordRating{item}, nil)
+			ratingDAO.EXPECT().ClaimWriteBack(item.ID, due, gomock.Any()).Return(tt.claimed, nil)
+			if tt.wantUpdate {
+				client.EXPECT().UpdateRecord(gomock.Any(), gomock.Any()).Return(updateRecordResp(tt.larkCode), nil)
+			}
+			if tt.wantStatus != "" {
+				ratingDAO.EXPECT().UpdateWriteBack(gomock.Any()).
+					DoAndReturn(func(m *model.RecordRating) error {
+						assert.Equal(t, tt.wantStatus, m.WriteBackStatus)
+						return nil
+					})
+			}
+
+			s.processDueRatingWriteBacks(context.Background())
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+)
+
+const (
+	RecordActionEdit     = "edit"     // 学生修改记录
+	RecordActionWithdraw = "withdraw" // 学生撤回记录
+
+	progressPending   = "待处理"
+	progressWithdrawn = "已撤回"
+)
+
+// EditRecord 学生修改自己的反馈记录，只允许在表格配置的时长内、进度为待处理时修改
+// 修改的内容与新建记录一样经过内容审核，打码后写入飞书
+func (s *SheetServiceImpl) EditRecord(ctx context.Context, recordID, studentID string, edit domain.RecordEdit, tableConfig *domain.TableConfig) error {
+	fields := make(map[string]any, 3)
+	if edit.Content != nil {
+		if strings.TrimSpace(*edit.Content) == "" {
+			return errs.RecordEditInvalidError(errors.New("content is empty"))
+		}
+		fields["反馈内容"] = *edit.Content
+	}
+	if edit.Images != nil {
+		// 将图片文件 token 数组转换为 Feishu API 期望的对象数组: [{"file_token": "your_file_token"}]
+		// 使用 []any 保存，与飞书返回的格式一致，落库时可以直接按同步的方式简化
+		fileObjs := make([]any, 0, len(edit.Images))
+		for _, t := range edit.Images {
+			fileObjs = append(fileObjs, map[string]any{"file_token": t})
+		}
+		fields["截图"] = fileObjs
+	}
+	if edit.ContactInfo != nil {
+		fields["联系方式（QQ/邮箱）"] = *edit.ContactInfo
+	}
+	if len(fields) == 0 {
+		return errs.RecordEditInvalidError(errors.New("nothing to edit"))
+	}
+	if err := s.moderation.ModerateRecord(ctx, &domain.TableRecord{Record: fields}, tableConfig); err != nil {
+		return err
+	}
+
+	return s.reviseRecord(recordID, studentID, RecordActionEdit, fields, nil, tableConfig)
+}
+
+// WithdrawRecord 学生撤回自己的反馈记录，记录进度更新为已撤回，不删除记录，限制与修改相同
+func (s *SheetServiceImpl) WithdrawRecord(recordID, studentID string, reason *string, tableConfig *domain.TableConfig) error {
+	return s.reviseRecord(recordID, studentID, RecordActionWithdraw, map[string]any{"进度": progressWithdrawn}, reason, tableConfig)
+}
+
+// reviseRecord 校验记录是否可以修改，将修改写入飞书并同步到数据库
+// 审计记录在修改飞书之前以 pending 状态写入，写入失败时不修改，保证每次修改都有审计记录
+func (s *SheetServiceImpl) reviseRecord(recordID, studentID, action string, fields map[string]any, reason *string, tableConfig *domain.TableConfig) error {
+	// 学生接口传入的表格配置来自 token，修改时长以最新的表格配置为准
+	table, _ := getTableConfig(*tableConfig.TableIdentity)
+	window := table.EditWindow
+	if window <= 0 {
+		return errs.RecordNotEditableError(fmt.Errorf("table %s does not allow editing", *tableConfig.TableIdentity))
+	}
+
+	// 以飞书中的记录为准，提交后尚未落库的记录也可以修改
+	before, shareUrl, err := s.GetTableRecordReqByRecordID(&recordID, tableConfig)
+	if err != nil {
+		return err
+	}
+	if owner, _ := before["学号"].(string); owner != studentID {
+		return errs.TableRecordNotFoundError(fmt.Errorf("record %s does not belong to student", recordID))
+	}
+	if progress := recordProgress(before); progress != progressPending {
+		return errs.RecordNotEditableError(fmt.Errorf("record progress is %s", progress))
+	}
+	submitted, ok := recordSubmitTime(before)
+	if !ok {
+		return errs.RecordNotEditableError(errors.New("record has no submit time"))
+	}
+	if time.Since(submitted) > window {
+		return errs.RecordNotEditableError(fmt.Errorf("edit window of %s has passed", window))
+	}
+
+	after := make(map[string]any, len(before)+len(fields))
+	for k, v := range before {
+		after[k] = v
+	}
+	for k, v := range simplifyFields(fields) {
+		after[k] = v
+	}
+
+	revision := &model.RecordRevision{
+		TableIdentify: tableConfig.TableIdentity,
+		RecordID:      &recordID,
+		UserID:        &studentID,
+		Action:        action,
+		Before:        before,
+		After:         after,
+		Reason:        reason,
+		Status:        model.RecordRevisionStatusPending,
+	}
+	if err := s.revisionDAO.CreateRevision(revision); err != nil {
+		s.log.Error("CreateRevision 保存修改记录失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+			logger.String("action", action),
+		)
+		return errs.CreateRecordRevisionError(err)
+	}
+
+	req := larkbitable.NewUpdateAppTableRecordReqBuilder().
+		AppToken(*tableConfig.TableToken).
+		TableId(*tableConfig.TableID).
+		RecordId(recordID).
+		AppTableRecord(larkbitable.NewAppTableRecordBuilder().
+			Fields(fields).
+			Build()).
+		Build()
+
+	resp, err := s.c.UpdateRecord(context.Background(), req)
+	if err != nil {
+		s.log.Error("reviseRecord UpdateRecord 调用失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		s.markRevision(revision, model.RecordRevisionStatusFailed)
+		return errs.LarkRequestError(err)
+	}
+	if !resp.Success() {
+		s.log.Error("reviseRecord Lark 接口错误",
+			logger.String("request_id", resp.RequestId()),
+			logger.String("error", larkcore.Prettify(resp.CodeError)),
+		)
+		s.markRevision(revision, model.RecordRevisionStatusFailed)
+		return errs.LarkResponseError(err)
+	}
+	s.markRevision(revision, model.RecordRevisionStatusApplied)
+
+	// 飞书已更新成功，落库失败时只记录日志，数据库中的记录在下次同步时修正
+	if err := s.UpdateDBRecord(&recordID, shareUrl, after, *tableConfig); err != nil {
+		s.log.Error("reviseRecord 同步修改到数据库失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+	}
+
+	return nil
+}
+
+// markRevision 更新审计记录的状态，失败时只记录日志，记录保持 pending，可按飞书中的记录核对
+func (s *SheetServiceImpl) markRevision(revision *model.RecordRevision, status string) {
+	if err := s.revisionDAO.UpdateRevisionStatus(revision.ID, status); err != nil {
+		s.log.Error("UpdateRevisionStatus 更新修改记录状态失败",
+			logger.String("error", err.Error()),
+			logger.Uint64("revision_id", revision.ID),
+			logger.String("status", status),
+		)
+	}
+}
+
+// recordSubmitTime 获取记录的提交时间，飞书日期字段为毫秒时间戳
+func recordSubmitTime(recordData map[string]any) (time.Time, bool) {
+	switch v := recordData["提交时间"].(type) {
+	case float64:
+		return time.UnixMilli(int64(v)), true
+	case int64:
+		return time.UnixMilli(v), true
+	case int:
+		return time.UnixMilli(int64(v)), true
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 审计记录先以 pending 写入，飞书修改成功后标记为 applied，失败时标记为 failed；审计记录写入失败时不修改飞书
+func TestReviseRecordWritesRevisionFirst(t *testing.T) {
+	recordID, studentID := "rec-1", "2023001"
+	var revisionID uint64 = 7
+
+	tests := []struct {
+		name        string
+		revisionErr error
+		larkErr     error
+		larkCode    int
+		wantUpdate  bool
+		wantStatus  string
+		wantCode    int
+	}{
+		{name: "applied", wantUpdate: true, wantStatus: model.RecordRevisionStatusApplied},
+		{name: "revision write fails", revisionErr: errors.New("db down"), wantCode: errs.CreateRecordRevisionErrorCode},
+		{name: "lark request fails", larkErr: errors.New("timeout"), wantUpdate: true, wantStatus: model.RecordRevisionStatusFailed, wantCode: errs.LarkRequestErrorCode},
+		{name: "lark rejects update", larkCode: 1254000, wantUpdate: true, wantStatus: model.RecordRevisionStatusFailed, wantCode: errs.LarkResponseErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := larkMock.NewMockClient(ctrl)
+			sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+			revisionDAO := daoMock.NewMockRecordRevisionDAO(ctrl)
+			s := &SheetServiceImpl{c: client, log: newTestLogger(), sheetDao: sheetDAO, revisionDAO: revisionDAO}
+			tc := newTestTableConfig()
+			tc.EditWindow = time.Hour
+			setTestTables(t, tc)
+
+			shareURL := "https://share/rec-1"
+			client.EXPECT().GetRecordByRecordId(gomock.Any(), gomock.Any()).
+				Return(&larkbitable.BatchGetAppTableRecordResp{
+					Data: &larkbitable.BatchGetAppTableRecordRespData{
+						Records: []*larkbitable.AppTableRecord{{
+							RecordId:  &recordID,
+							SharedUrl: &shareURL,
+							Fields: map[string]any{
+								"学号":   studentID,
+								"进度":   progressPending,
+								"提交时间": float64(time.Now().Add(-time.Minute).UnixMilli()),
+							},
+						}},
+					},
+				}, nil)
+			revisionDAO.EXPECT().CreateRevision(gomock.Any()).
+				DoAndReturn(func(m *model.RecordRevision) error {
+					assert.Equal(t, model.RecordRevisionStatusPending, m.Status)
+					assert.Equal(t, RecordActionWithdraw, m.Action)
+					assert.Equal(t, progressPending, m.Before["进度"])
+					assert.Equal(t, progressWithdrawn, m.After["进度"])
+					m.ID = revisionID
+					return tt.revisionErr
+				})
+			if tt.wantUpdate {
+				client.EXPECT().UpdateRecord(gomock.Any(), gomock.Any()).
+					Return(&larkbitable.UpdateAppTableRecordResp{
+						ApiResp:   &larkcore.ApiResp{Header: http.Header{}},
+						CodeError: larkcore.CodeError{Code: tt.larkCode},
+					}, tt.larkErr)
+				revisionDAO.EXPECT().UpdateRevisionStatus(revisionID, tt.wantStatus).Return(nil)
+			}
+			if tt.wantStatus == model.RecordRevisionStatusApplied {
+				sheetDAO.EXPECT().CreateOrUpdateSheetRecord(gomock.Any()).Return(nil)
+			}
+
+			reason := "重复提交"
+			err := s.WithdrawRecord(recordID, studentID, &reason, &tc)
+			if tt.wantCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+		})
+	}
+}
+
+// 超过修改时长或进度不是待处理时不允许修改，也不写入审计记录
+func TestReviseRecordRejectsLockedRecord(t *testing.T) {
+	recordID, studentID := "rec-1", "2023001"
+
+	tests := []struct {
+		name      string
+		progress  string
+		submitted time.Time
+	}{
+		{name: "window passed", progress: progressPending, submitted: time.Now().Add(-2 * time.Hour)},
+		{name: "already processing", progress: "处理中", submitted: time.Now()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := larkMock.NewMockClient(ctrl)
+			s := &SheetServiceImpl{c: client, log: newTestLogger(), revisionDAO: daoMock.NewMockRecordRevisionDAO(ctrl), moderation: newTestModerationService(ModerationMask)}
+			tc := newTestTableConfig()
+			tc.EditWindow = time.Hour
+			setTestTables(t, tc)
+
+			client.EXPECT().GetRecordByRecordId(gomock.Any(), gomock.Any()).
+				Return(&larkbitable.BatchGetAppTableRecordResp{
+					Data: &larkbitable.BatchGetAppTableRecordRespData{
+						Records: []*larkbitable.AppTableRecord{{
+							RecordId: &recordID,
+							Fields: map[string]any{
+								"学号":   studentID,
+								"进度":   tt.progress,
+								"提交时间": float64(tt.submitted.UnixMilli()),
+							},
+						}},
+					},
+				}, nil)
+
+			content := "新的内容"
+			err := s.EditRecord(context.Background(), recordID, studentID, domain.RecordEdit{Content: &content}, &tc)
+			require.Error(t, err)
+			assert.Equal(t, errs.RecordNotEditableErrorCode, errorx.ToCustomError(err).Code)
+		})
+	}
+}
+
+// 修改内容未通过审核时不读取也不修改飞书记录
+func TestEditRecordModeratesContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := &SheetServiceImpl{
+		c:           larkMock.NewMockClient(ctrl),
+		log:         newTestLogger(),
+		revisionDAO: daoMock.NewMockRecordRevisionDAO(ctrl),
+		moderation:  newTestModerationService(ModerationReject),
+	}
+	tc := newTestTableConfig()
+	tc.EditWindow = time.Hour
+	setTestTables(t, tc)
+
+	content := "内容包含" + testModerationWord
+	err := s.EditRecord(context.Background(), "rec-1", "2023001", domain.RecordEdit{Content: &content}, &tc)
+	require.Error(t, err)
+	assert.Equal(t, errs.ContentRejectedErrorCode, errorx.ToCustomError(err).Code)
+}
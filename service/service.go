@@ -1,34 +1,91 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/wire"
 	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
 )
 
 var ProviderSet = wire.NewSet(
 	NewAuthService,
 	NewSheetService,
 	NewMessageService,
+	NewNotifierRegistry,
+	NewOutboxService,
+	NewLeaderElector,
+	NewWebhookService,
+	NewDigestService,
+	NewThreadService,
+	NewSubmissionService,
+	NewModerationService,
+)
+
+const (
+	SyncKindRecords     = "records"     // 按记录 ID 同步飞书记录到数据库
+	SyncKindTable       = "table"       // 扫描整张表格，同步未同步的记录或 FAQ 记录
+	SyncKindIncremental = "incremental" // 按修改时间增量同步表格
+	SyncKindReconcile   = "reconcile"   // 对账并软删除飞书中已删除的记录
 )
 
 var (
-	tableCfg    map[string]domain.TableConfig
-	noticeCh    chan domain.TableConfig // 通知通道，传递需要发送通知的表格配置
-	syncTableCh chan domain.TableConfig // 同步表格通道，传递需要同步的表格配置
-	syncCh      chan SyncMsg            // 同步通道，控制数据库与飞书数据的同步
-	once        sync.Once
+	tableCfg   map[string]domain.TableConfig // 基础配置表中的表格配置，只通过 getTableConfig 等函数加锁访问
+	tableCfgMu sync.RWMutex
+	noticeCh   chan domain.TableConfig // 通知通道，传递需要发送通知的表格配置
+	once       sync.Once
 )
 
+// getTableConfig 按表格标识读取最新的表格配置
+func getTableConfig(tableIdentity string) (domain.TableConfig, bool) {
+	tableCfgMu.RLock()
+	defer tableCfgMu.RUnlock()
+
+	table, ok := tableCfg[tableIdentity]
+	return table, ok
+}
+
+// listTableConfigs 返回当前全部表格配置的快照，遍历期间配置刷新不影响结果
+func listTableConfigs() []domain.TableConfig {
+	tableCfgMu.RLock()
+	defer tableCfgMu.RUnlock()
+
+	tables := make([]domain.TableConfig, 0, len(tableCfg))
+	for _, table := range tableCfg {
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// setTableConfigs 整体替换表格配置
+func setTableConfigs(tables map[string]domain.TableConfig) {
+	tableCfgMu.Lock()
+	tableCfg = tables
+	tableCfgMu.Unlock()
+}
+
 type ProgressMsg struct {
 	RecordID    string
 	TableConfig domain.TableConfig
 }
 
+// SyncMsg 同步队列中的消息，序列化后写入 cache.SyncQueue
 type SyncMsg struct {
-	RecordIDs   []string
-	TableConfig domain.TableConfig
+	Kind        string             `json:"kind"`
+	JobID       string             `json:"job_id,omitempty"`   // 所属同步任务，为空时不记录进度
+	BatchNo     int                `json:"batch_no,omitempty"` // 批次在任务中的序号，从 1 开始，重复投递时用于去重
+	RecordIDs   []string           `json:"record_ids,omitempty"`
+	TableConfig domain.TableConfig `json:"table_config"`
 }
 
 func init() {
@@ -36,11 +93,33 @@ func init() {
 	once.Do(func() {
 		tableCfg = make(map[string]domain.TableConfig)
 		noticeCh = make(chan domain.TableConfig, 10)
-		syncTableCh = make(chan domain.TableConfig, 10)
-		syncCh = make(chan SyncMsg, 50)
 	})
 }
 
+// pushSyncMsg 将同步消息写入持久化队列，队列已满时返回 cache.ErrSyncQueueFull
+func pushSyncMsg(q cache.SyncQueue, msg SyncMsg) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return q.Push(context.Background(), payload)
+}
+
+// isSyncQueueFull 判断入队失败是否是因为队列已满
+func isSyncQueueFull(err error) bool {
+	return errors.Is(err, cache.ErrSyncQueueFull)
+}
+
+// syncConsumerName 生成当前实例在同步队列消费者组中的名称，使用主机名（Pod 名）区分实例
+func syncConsumerName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return fmt.Sprintf("unknown-%d", os.Getpid())
+	}
+	return host
+}
+
 func simplifyFields(fields map[string]any) map[string]any {
 	result := make(map[string]any, len(fields))
 
@@ -97,3 +176,38 @@ func simplifyFields(fields map[string]any) map[string]any {
 
 	return result
 }
+
+// toLarkError 将分页查询返回的错误转换为业务错误，飞书返回的业务错误与请求失败区分开
+func toLarkError(err error) error {
+	var respErr *lark.ResponseError
+	if errors.As(err, &respErr) {
+		return errs.LarkResponseError(err)
+	}
+	return errs.LarkRequestError(err)
+}
+
+// truncateUTF8 将 s 截断到不超过 n 字节，截断位置回退到字符边界，并去掉无效的 UTF-8 字节，避免写入 utf8mb4 列时报错
+func truncateUTF8(s string, n int) string {
+	if len(s) > n {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n]
+	}
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "")
+	}
+	return s
+}
+
+// backoff 计算第 attempts 次失败后的重试等待时间，从 base 开始按指数增长，不超过 max
+func backoff(attempts int, base, max time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= max {
+			return max
+		}
+	}
+	return d
+}
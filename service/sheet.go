@@ -7,14 +7,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
 	larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
 	"github.com/muxi-Infra/FeedBack-Backend/domain"
 	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
@@ -28,11 +33,19 @@ const (
 	StatusUnresolved  = "未解决"
 	queueBatchSize    = 100
 	pageSize          = 100 // 数据库分页大小
+
+	syncReadCount     = 10               // 每次从同步队列读取的消息数
+	syncReadBlock     = 5 * time.Second  // 同步队列阻塞读取的超时时间
+	syncClaimInterval = time.Minute      // 认领未确认消息的检查间隔
+	syncClaimMinIdle  = 10 * time.Minute // 消息超过该时间未确认视为处理失败，可被重新认领
+	syncMaxDeliveries = 5                // 单条消息最大投递次数，超过后丢弃
+
+	incrementalOverlap = time.Minute // 高水位相对扫描开始时间的回退量，避免遗漏扫描期间修改的记录
 )
 
 //go:generate mockgen -destination=./mock/sheet_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service SheetService
 type SheetService interface {
-	CreateLarkRecord(record *domain.TableRecord, tableConfig *domain.TableConfig) (*string, error)
+	CreateLarkRecord(record *domain.TableRecord, clientToken string, tableConfig *domain.TableConfig) (*string, error)
 	CreateDBRecord(recordID, shareUrl *string, recordData map[string]any, tableConfig domain.TableConfig) error
 	UpdateDBRecord(recordID, shareUrl *string, recordData map[string]any, tableConfig domain.TableConfig) error
 	GetTableRecordReqByKey(keyField *domain.TableField, fieldNames []string, pageToken *string, tableConfig *domain.TableConfig) (*domain.TableRecords, error)
@@ -41,12 +54,26 @@ type SheetService interface {
 	GetFAQProblemTableRecord(studentID *string, fieldNames []string, tableConfig *domain.TableConfig) (*domain.FAQTableRecords, error)
 	UpdateFAQResolutionRecord(resolution *domain.FAQResolution, tableConfig *domain.TableConfig) error
 	GetPhotoUrl(fileTokens []string) ([]domain.File, error)
-	SyncUnsyncedTableRecords(tableConfig *domain.TableConfig) ([]string, int, bool, error)
-	ForceSyncUserTableRecords(studentID *string, tableConfig *domain.TableConfig) ([]string, int, bool, error)
-	ForceSyncTableRecords(tableConfig *domain.TableConfig) ([]string, int, bool, error)
+	SyncUnsyncedTableRecords(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error)
+	ForceSyncUserTableRecords(studentID *string, tableConfig *domain.TableConfig) (*domain.SyncSubmission, error)
+	ForceSyncTableRecords(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error)
+	IncrementalSyncTableRecords(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error)
 	GetFAQResolutionRecord(studentID *string, tableConfig *domain.TableConfig) ([]domain.FAQTableRecord, error)
 	UpdateFAQResolutionRecordV2(resolution *domain.FAQResolutionV2, tableConfig *domain.TableConfig) error
-	SyncFAQRecord(tableConfig *domain.TableConfig) error
+	SyncFAQRecord(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error)
+	SyncChangedRecords(recordIDs []string, tableConfig *domain.TableConfig) error
+	SyncDeletedRecords(recordIDs []string, tableConfig *domain.TableConfig) error
+	ReconcileDeletedRecords(tableConfig *domain.TableConfig) (int, error)
+	GetSyncJob(jobID string, tableConfig *domain.TableConfig) (*domain.SyncJob, error)
+	ListSyncJobs(pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.SyncJobs, error)
+	ListSyncFailures(status string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.SyncFailures, error)
+	GetSyncFailure(recordID string, tableConfig *domain.TableConfig) (*domain.SyncFailure, error)
+	RetrySyncFailure(recordID string, tableConfig *domain.TableConfig) (domain.SyncStats, error)
+	DiscardSyncFailure(recordID string, tableConfig *domain.TableConfig) error
+	EditRecord(ctx context.Context, recordID, studentID string, edit domain.RecordEdit, tableConfig *domain.TableConfig) error
+	WithdrawRecord(recordID, studentID string, reason *string, tableConfig *domain.TableConfig) error
+	RateRecord(recordID, studentID string, score int, comment *string, tableConfig *domain.TableConfig) (*domain.RecordRating, error)
+	GetRatingStats(tableConfig *domain.TableConfig) (*domain.RatingStats, error)
 }
 
 type SheetServiceImpl struct {
@@ -56,9 +83,20 @@ type SheetServiceImpl struct {
 	sheetDao      dao.SheetDAO
 	faqDAO        dao.FAQDAO
 	cache         cache.FAQResolutionStateCache
+	queue         cache.SyncQueue
+	syncJobDAO    dao.SyncJobDAO
+	watermarkDAO  dao.SyncWatermarkDAO
+	failureDAO    dao.SyncFailureDAO
+	webhook       WebhookService
+	thread        ThreadService
+	revisionDAO   dao.RecordRevisionDAO
+	ratingDAO     dao.RecordRatingDAO
+	moderation    ModerationService
+	syncCfg       *config.SyncConfig
+	life          *lifecycle.Lifecycle
 }
 
-func NewSheetService(c lark.Client, log logger.Logger, resolutionDAO dao.FAQResolutionDAO, sheetDAO dao.SheetDAO, faqDAO dao.FAQDAO, cache cache.FAQResolutionStateCache) SheetService {
+func NewSheetService(c lark.Client, log logger.Logger, resolutionDAO dao.FAQResolutionDAO, sheetDAO dao.SheetDAO, faqDAO dao.FAQDAO, cache cache.FAQResolutionStateCache, queue cache.SyncQueue, syncJobDAO dao.SyncJobDAO, watermarkDAO dao.SyncWatermarkDAO, failureDAO dao.SyncFailureDAO, webhook WebhookService, thread ThreadService, revisionDAO dao.RecordRevisionDAO, ratingDAO dao.RecordRatingDAO, moderation ModerationService, syncCfg *config.SyncConfig, life *lifecycle.Lifecycle, elector *LeaderElector) SheetService {
 	s := &SheetServiceImpl{
 		c:             c,
 		log:           log,
@@ -66,78 +104,249 @@ func NewSheetService(c lark.Client, log logger.Logger, resolutionDAO dao.FAQReso
 		sheetDao:      sheetDAO,
 		faqDAO:        faqDAO,
 		cache:         cache,
-	}
-
-	// 消费者，异步同步未同步的记录到数据库
-	go func() {
-		for {
-			select {
-			case msg := <-syncCh:
-				s.log.Info("received sync message",
-					logger.String("table_identity", *msg.TableConfig.TableIdentity),
-					logger.Int("record_count", len(msg.RecordIDs)),
-				)
-
-				// 同步反馈记录
-				// 飞书 -> 数据库
-				// 这是对 case table := <-syncTableCh 的聚合处理，减少 API 的使用量
-				err := s.SyncLarkRecords(msg.RecordIDs, msg.TableConfig)
-				if err != nil {
-					s.log.Error("SyncLarkRecords 同步记录到飞书表格失败",
+		queue:         queue,
+		syncJobDAO:    syncJobDAO,
+		watermarkDAO:  watermarkDAO,
+		failureDAO:    failureDAO,
+		webhook:       webhook,
+		thread:        thread,
+		revisionDAO:   revisionDAO,
+		ratingDAO:     ratingDAO,
+		moderation:    moderation,
+		syncCfg:       syncCfg,
+		life:          life,
+	}
+
+	// 消费者，从持久化队列中读取同步消息，处理成功后才确认
+	// 退出时处理完当前消息即停止，已读取但未处理的消息不确认，由其他实例超时后重新认领
+	life.Go("sync queue consumer", func(ctx context.Context) {
+		consumer := syncConsumerName()
+		lastClaim := time.Now()
+		for ctx.Err() == nil {
+			// 定期认领长时间未确认的消息（实例崩溃或处理失败），实现重新投递
+			if time.Since(lastClaim) >= syncClaimInterval {
+				lastClaim = time.Now()
+				msgs, err := s.queue.Claim(ctx, consumer, syncClaimMinIdle, syncReadCount)
+				if err != nil && ctx.Err() == nil {
+					s.log.Error("sync queue claim failed",
 						logger.String("error", err.Error()),
-						logger.String("table_identity", *msg.TableConfig.TableIdentity),
 					)
 				}
-			case table := <-syncTableCh:
-				s.log.Info("received sync table",
-					logger.String("table_identity", *table.TableIdentity),
-				)
+				s.handleSyncMessages(ctx, msgs)
+			}
 
-				// 获取带同步表格标识，区分常见问题表格和反馈记录表格
-				if bytes.Contains([]byte(*table.TableIdentity), []byte("-faq")) {
-					s.log.Info("received table faq record",
-						logger.String("table_identity", *table.TableIdentity),
-					)
-					// 同步常见问题中 解决/未解决 数量
-					// redis -> 飞书
-					err := s.SyncFAQRecord(&table)
-					if err != nil {
-						s.log.Error("SyncFAQResolutionCount 同步 FAQ 记录到飞书表格失败",
-							logger.String("error", err.Error()),
-							logger.String("table_identity", *table.TableIdentity),
-						)
-					}
-				} else {
-					s.log.Info("received table table record",
-						logger.String("table_identity", *table.TableIdentity),
-					)
-					// 同步反馈记录
-					// 飞书 -> 数据库
-					_, _, _, err := s.SyncUnsyncedTableRecords(&table)
-					if err != nil {
-						s.log.Error("SyncUnsyncedTableRecords 同步未同步记录到飞书表格失败",
-							logger.String("error", err.Error()),
-							logger.String("table_identity", *table.TableIdentity),
-						)
-					}
+			msgs, err := s.queue.Pop(ctx, consumer, syncReadCount, syncReadBlock)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				s.log.Error("sync queue pop failed",
+					logger.String("error", err.Error()),
+				)
+				select {
+				case <-ctx.Done():
+				case <-time.After(syncReadBlock):
 				}
+				continue
 			}
+			s.handleSyncMessages(ctx, msgs)
 		}
-	}()
+	})
+
+	// 重试失败的评价回写，使用独立的 worker，不受新增记录任务处理耗时的影响，只在主节点上运行
+	elector.Go("rating write-back worker", func(ctx context.Context) {
+		ticker := time.NewTicker(ratingWriteBackPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.processDueRatingWriteBacks(ctx)
+			}
+		}
+	})
 
 	return s
 }
 
-func (s *SheetServiceImpl) CreateLarkRecord(record *domain.TableRecord, tableConfig *domain.TableConfig) (*string, error) {
+// handleSyncMessages 依次处理读取到的消息，ctx 取消后剩余消息留在队列中等待重新认领
+func (s *SheetServiceImpl) handleSyncMessages(ctx context.Context, msgs []cache.SyncQueueMessage) {
+	for _, m := range msgs {
+		if ctx.Err() != nil {
+			return
+		}
+		s.handleSyncMessage(m)
+	}
+}
+
+// handleSyncMessage 处理一条同步消息，处理成功后确认；失败时不确认，等待超时后被重新认领
+func (s *SheetServiceImpl) handleSyncMessage(m cache.SyncQueueMessage) {
+	var msg SyncMsg
+	if err := json.Unmarshal(m.Payload, &msg); err != nil || msg.TableConfig.TableIdentity == nil {
+		s.log.Error("sync message invalid, dropped",
+			logger.String("message_id", m.ID),
+			logger.ByteString("payload", m.Payload),
+		)
+		s.ackSyncMessage(m.ID)
+		return
+	}
+
+	if m.RetryCount > syncMaxDeliveries {
+		s.log.Error("sync message exceeded max deliveries, dropped",
+			logger.String("message_id", m.ID),
+			logger.Int64("retry_count", m.RetryCount),
+			logger.ByteString("payload", m.Payload),
+		)
+		reason := "exceeded max deliveries"
+		s.recordSyncJobBatch(msg.JobID, msg.BatchNo, domain.SyncStats{
+			Failed: len(msg.RecordIDs),
+			Error:  &reason,
+		})
+		s.ackSyncMessage(m.ID)
+		return
+	}
+
+	var err error
+	switch msg.Kind {
+	case SyncKindRecords:
+		s.log.Info("received sync message",
+			logger.String("table_identity", *msg.TableConfig.TableIdentity),
+			logger.Int("record_count", len(msg.RecordIDs)),
+		)
+		s.markSyncJobRunning(msg.JobID)
+
+		// 同步反馈记录
+		// 飞书 -> 数据库
+		var stats domain.SyncStats
+		stats, err = s.SyncLarkRecords(msg.RecordIDs, msg.TableConfig)
+		if err != nil {
+			s.log.Error("SyncLarkRecords 同步记录到飞书表格失败",
+				logger.String("error", err.Error()),
+				logger.String("table_identity", *msg.TableConfig.TableIdentity),
+			)
+			break
+		}
+		s.recordSyncJobBatch(msg.JobID, msg.BatchNo, stats)
+	case SyncKindTable:
+		table := msg.TableConfig
+		s.log.Info("received sync table",
+			logger.String("table_identity", *table.TableIdentity),
+		)
+
+		// 获取带同步表格标识，区分常见问题表格和反馈记录表格
+		if bytes.Contains([]byte(*table.TableIdentity), []byte("-faq")) {
+			s.log.Info("received table faq record",
+				logger.String("table_identity", *table.TableIdentity),
+			)
+			// 同步常见问题中 解决/未解决 数量
+			// redis -> 飞书
+			_, err = s.SyncFAQRecord(&table)
+			if err != nil {
+				s.log.Error("SyncFAQResolutionCount 同步 FAQ 记录到飞书表格失败",
+					logger.String("error", err.Error()),
+					logger.String("table_identity", *table.TableIdentity),
+				)
+			}
+		} else {
+			s.log.Info("received table table record",
+				logger.String("table_identity", *table.TableIdentity),
+			)
+			// 同步反馈记录
+			// 飞书 -> 数据库
+			_, err = s.SyncUnsyncedTableRecords(&table)
+			if err != nil {
+				s.log.Error("SyncUnsyncedTableRecords 同步未同步记录到飞书表格失败",
+					logger.String("error", err.Error()),
+					logger.String("table_identity", *table.TableIdentity),
+				)
+			}
+		}
+	case SyncKindIncremental:
+		s.log.Info("received incremental sync table",
+			logger.String("table_identity", *msg.TableConfig.TableIdentity),
+		)
+		// 增量同步反馈记录
+		// 飞书 -> 数据库
+		table := msg.TableConfig
+		_, err = s.IncrementalSyncTableRecords(&table)
+		if err != nil {
+			s.log.Error("IncrementalSyncTableRecords 增量同步记录失败",
+				logger.String("error", err.Error()),
+				logger.String("table_identity", *table.TableIdentity),
+			)
+		}
+	case SyncKindReconcile:
+		table := msg.TableConfig
+		s.log.Info("received reconcile table",
+			logger.String("table_identity", *table.TableIdentity),
+		)
+		// 软删除飞书中已删除的反馈记录
+		_, err = s.ReconcileDeletedRecords(&table)
+		if err != nil {
+			s.log.Error("ReconcileDeletedRecords 对账已删除记录失败",
+				logger.String("error", err.Error()),
+				logger.String("table_identity", *table.TableIdentity),
+			)
+			// 超过删除上限时重试也不会成功，直接确认
+			if errorx.ToCustomError(err).Code == errs.ReconcileAbortedErrorCode {
+				err = nil
+			}
+		}
+	default:
+		s.log.Error("unsupported sync message kind, dropped",
+			logger.String("message_id", m.ID),
+			logger.String("kind", msg.Kind),
+		)
+	}
+
+	if err != nil {
+		return
+	}
+	s.ackSyncMessage(m.ID)
+}
+
+func (s *SheetServiceImpl) ackSyncMessage(id string) {
+	if err := s.queue.Ack(context.Background(), id); err != nil {
+		s.log.Error("sync queue ack failed",
+			logger.String("message_id", id),
+			logger.String("error", err.Error()),
+		)
+	}
+}
+
+// enqueueSync 将同步消息写入队列，返回队列是否已满
+func (s *SheetServiceImpl) enqueueSync(msg SyncMsg) (bool, error) {
+	err := pushSyncMsg(s.queue, msg)
+	if err == nil {
+		return false, nil
+	}
+	if isSyncQueueFull(err) {
+		return true, nil
+	}
+
+	s.log.Error("sync queue push failed",
+		logger.String("error", err.Error()),
+		logger.String("table_identity", *msg.TableConfig.TableIdentity),
+	)
+	return false, errs.SyncQueueEnqueueError(err)
+}
+
+// CreateLarkRecord 创建飞书记录，clientToken 不为空时重复调用只会创建一条记录
+func (s *SheetServiceImpl) CreateLarkRecord(record *domain.TableRecord, clientToken string, tableConfig *domain.TableConfig) (*string, error) {
 	// 创建请求对象
-	req := larkbitable.NewCreateAppTableRecordReqBuilder().
+	builder := larkbitable.NewCreateAppTableRecordReqBuilder().
 		AppToken(*tableConfig.TableToken).
 		TableId(*tableConfig.TableID).
 		IgnoreConsistencyCheck(true). // 忽略一致性检查，提高性能，但可能会导致某些节点的数据不同步，出现暂时不一致
 		AppTableRecord(larkbitable.NewAppTableRecordBuilder().
 			Fields(record.Record).
-			Build()).
-		Build()
+			Build())
+	if clientToken != "" {
+		builder = builder.ClientToken(clientToken)
+	}
+	req := builder.Build()
 
 	// 发起请求
 	ctx := context.Background()
@@ -171,6 +380,7 @@ func (s *SheetServiceImpl) CreateDBRecord(recordID, shareUrl *string, recordData
 		)
 		return errs.CreateRecordDBError(errors.New("学号字段类型断言失败"))
 	}
+	progress := recordProgress(recordData)
 
 	m := &model.Sheet{
 		TableIdentify: tableConfig.TableIdentity,
@@ -178,6 +388,7 @@ func (s *SheetServiceImpl) CreateDBRecord(recordID, shareUrl *string, recordData
 		UserID:        &studentID,
 		Record:        recordData,
 		ShareUrl:      shareUrl,
+		Progress:      &progress,
 		IsSynced:      false,
 	}
 
@@ -203,10 +414,10 @@ func (s *SheetServiceImpl) UpdateDBRecord(recordID, shareUrl *string, recordData
 	}
 
 	synced := false
-	finish, ok := recordData["进度"].(string)
-	if ok && finish == "已完成" {
+	if isRecordCompleted(recordData) {
 		synced = true
 	}
+	progress := recordProgress(recordData)
 
 	m := &model.Sheet{
 		TableIdentify: tableConfig.TableIdentity,
@@ -214,6 +425,7 @@ func (s *SheetServiceImpl) UpdateDBRecord(recordID, shareUrl *string, recordData
 		UserID:        &studentID,
 		Record:        recordData,
 		ShareUrl:      shareUrl,
+		Progress:      &progress,
 		IsSynced:      synced,
 	}
 
@@ -391,7 +603,7 @@ func (s *SheetServiceImpl) GetFAQProblemTableRecord(studentID *string, fieldName
 	g, ctx := errgroup.WithContext(ctx)
 
 	var resolutionMap map[string]*bool
-	var feishuResp *larkbitable.SearchAppTableRecordResp
+	var feishuItems []*larkbitable.AppTableRecord
 
 	// 并发查数据库
 	g.Go(func() error {
@@ -420,36 +632,26 @@ func (s *SheetServiceImpl) GetFAQProblemTableRecord(studentID *string, fieldName
 
 	// 并发查飞书表格
 	g.Go(func() error {
-		// 创建请求对象
-		req := larkbitable.NewSearchAppTableRecordReqBuilder().
-			AppToken(*tableConfig.TableToken).
-			TableId(*tableConfig.TableID).
-			PageToken("").
-			PageSize(100). // 分页大小，拿全部，有更大的需求再改大
-			Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
-				ViewId(*tableConfig.ViewID).
-				FieldNames(fieldNames).
-				Build()).
-			Build()
-
-		// 发起请求
-		resp, err := s.c.GetAppTableRecord(ctx, req)
+		// 拉取全部分页
+		items, err := lark.CollectSearchRecords(ctx, s.c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+			return larkbitable.NewSearchAppTableRecordReqBuilder().
+				AppToken(*tableConfig.TableToken).
+				TableId(*tableConfig.TableID).
+				PageToken(pageToken).
+				PageSize(lark.MaxSearchPageSize).
+				Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
+					ViewId(*tableConfig.ViewID).
+					FieldNames(fieldNames).
+					Build()).
+				Build()
+		})
 		if err != nil {
-			s.log.Error("GetFAQProblemTableRecord 调用失败",
+			s.log.Error("GetFAQProblemTableRecord 查询飞书记录失败",
 				logger.String("error", err.Error()),
 			)
-			return errs.LarkRequestError(err)
+			return toLarkError(err)
 		}
-
-		// 服务端错误处理
-		if !resp.Success() {
-			s.log.Error("GetFAQProblemTableRecord Lark 接口错误",
-				logger.String("request_id", resp.RequestId()),
-				logger.String("error", larkcore.Prettify(resp.CodeError)),
-			)
-			return errs.LarkResponseError(err)
-		}
-		feishuResp = resp
+		feishuItems = items
 		return nil
 	})
 
@@ -460,7 +662,7 @@ func (s *SheetServiceImpl) GetFAQProblemTableRecord(studentID *string, fieldName
 
 	// 组装记录
 	var records []domain.FAQTableRecord
-	for _, r := range feishuResp.Data.Items {
+	for _, r := range feishuItems {
 		var isResolved *bool
 
 		if resolutionMap != nil {
@@ -477,9 +679,10 @@ func (s *SheetServiceImpl) GetFAQProblemTableRecord(studentID *string, fieldName
 	}
 
 	// 组装返回值
+	total := len(records)
 	res := &domain.FAQTableRecords{
 		Records: records,
-		Total:   feishuResp.Data.Total,
+		Total:   &total,
 	}
 	return res, nil
 }
@@ -542,8 +745,10 @@ func (s *SheetServiceImpl) UpdateFAQResolutionRecord(resolution *domain.FAQResol
 		return errs.FAQResolutionCountGetError(err)
 	}
 
-	// 只能保证最终一致性
-	go func(rName, uName string, rNum, uNum uint64) {
+	// 只能保证最终一致性，退出时会等待写入完成
+	rName, uName := *resolution.ResolvedFieldName, *resolution.UnresolvedFieldName
+	rNum, uNum := resolvedCount, unresolvedCount
+	s.life.Go("update faq resolution count", func(context.Context) {
 		// 创建请求对象
 		req := larkbitable.NewUpdateAppTableRecordReqBuilder().
 			AppToken(*tableConfig.TableToken).
@@ -562,6 +767,7 @@ func (s *SheetServiceImpl) UpdateFAQResolutionRecord(resolution *domain.FAQResol
 		if err != nil {
 			s.log.Error("UpdateFAQResolutionRecord 调用失败",
 				logger.String("error", err.Error()))
+			return
 		}
 
 		// 服务端错误处理
@@ -570,7 +776,7 @@ func (s *SheetServiceImpl) UpdateFAQResolutionRecord(resolution *domain.FAQResol
 				logger.String("request_id", resp.RequestId()),
 				logger.String("error", larkcore.Prettify(resp.CodeError)))
 		}
-	}(*resolution.ResolvedFieldName, *resolution.UnresolvedFieldName, resolvedCount, unresolvedCount)
+	})
 
 	// 更新或插入数据库记录
 	m := &model.FAQResolution{
@@ -674,71 +880,55 @@ func (s *SheetServiceImpl) UpdateRecordProgress(recordID *string, tableConfig *d
 	return nil
 }
 
-// SyncUnsyncedTableRecords 同步未同步的记录到飞书表格，返回成功同步的 recordID 列表
-func (s *SheetServiceImpl) SyncUnsyncedTableRecords(tableConfig *domain.TableConfig) ([]string, int, bool, error) {
+// SyncUnsyncedTableRecords 同步未同步的记录到飞书表格，返回同步任务与成功入队的 recordID 列表
+func (s *SheetServiceImpl) SyncUnsyncedTableRecords(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error) {
 	// 获取未同步 recordID 列表
 	recordIDs, err := s.sheetDao.GetUnsyncedRecordsByTable(*tableConfig.TableIdentity)
 	if err != nil {
-		return nil, 0, false, errs.GetUnsyncedRecordsByTableError(err)
+		return nil, errs.GetUnsyncedRecordsByTableError(err)
 	}
 
-	if len(recordIDs) == 0 {
-		return []string{}, 0, false, nil
-	}
-
-	// 过滤空 ID
-	filtered := make([]string, 0, len(recordIDs))
-	for _, rid := range recordIDs {
-		if rid != "" {
-			filtered = append(filtered, rid)
-		}
-	}
-
-	if len(filtered) == 0 {
-		return []string{}, 0, false, nil
+	jobID, err := s.createSyncJob(model.SyncJobKindUnsynced, tableConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	// 拆分入队
-	totalEnqueued := 0
-	queueFull := false
-
-	for i := 0; i < len(filtered); i += queueBatchSize {
-		end := i + queueBatchSize
-		if end > len(filtered) {
-			end = len(filtered)
-		}
-
-		subBatch := filtered[i:end]
-
-		msg := SyncMsg{
-			RecordIDs:   subBatch,
-			TableConfig: *tableConfig,
-		}
-
-		select {
-		case syncCh <- msg:
-			totalEnqueued += len(subBatch)
-		default:
-			queueFull = true
-			// 返回已经成功入队的部分
-			return filtered[:totalEnqueued], totalEnqueued, true, nil
-		}
+	submission := &domain.SyncSubmission{
+		JobID:     jobID,
+		RecordIDs: []string{},
+	}
+	batches, err := s.enqueueRecordBatches(submission, recordIDs, tableConfig)
+	s.setSyncJobBatches(jobID, batches, submission.Total, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return filtered, len(filtered), queueFull, nil
+	return submission, nil
 }
 
-func (s *SheetServiceImpl) ForceSyncUserTableRecords(studentID *string, tableConfig *domain.TableConfig) ([]string, int, bool, error) {
+func (s *SheetServiceImpl) ForceSyncUserTableRecords(studentID *string, tableConfig *domain.TableConfig) (*domain.SyncSubmission, error) {
 	var lastID *uint64
 
-	enqueuedIDs := make([]string, 0)
-	queueFull := false
+	jobID, err := s.createSyncJob(model.SyncJobKindUser, tableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &domain.SyncSubmission{
+		JobID:     jobID,
+		RecordIDs: []string{},
+	}
+	totalBatches := 0
+	// 入队结束后写入批次数，任务才能结束
+	defer func() {
+		s.setSyncJobBatches(jobID, totalBatches, submission.Total, nil)
+	}()
 
-loop:
 	for {
 		records, hasMore, err := s.sheetDao.GetSheetRecordByUser(*tableConfig.TableIdentity, *studentID, lastID, pageSize)
 		if err != nil {
-			return nil, 0, false, err
+			return nil, err
 		}
 
 		if len(records) == 0 {
@@ -752,19 +942,13 @@ loop:
 			}
 		}
 
-		if len(batchIDs) > 0 {
-			msg := SyncMsg{
-				RecordIDs:   batchIDs,
-				TableConfig: *tableConfig,
-			}
-
-			select {
-			case syncCh <- msg:
-				enqueuedIDs = append(enqueuedIDs, batchIDs...)
-			default:
-				queueFull = true
-				break loop
-			}
+		batches, err := s.enqueueRecordBatches(submission, batchIDs, tableConfig)
+		totalBatches += batches
+		if err != nil {
+			return nil, err
+		}
+		if submission.QueueFull {
+			break
 		}
 
 		// 更新游标（因为 DESC）
@@ -775,90 +959,256 @@ loop:
 		}
 	}
 
-	return enqueuedIDs, len(enqueuedIDs), queueFull, nil
+	return submission, nil
 }
 
-func (s *SheetServiceImpl) ForceSyncTableRecords(tableConfig *domain.TableConfig) ([]string, int, bool, error) {
+func (s *SheetServiceImpl) ForceSyncTableRecords(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error) {
 	var allRecordIDs []string
-	pageToken := new(string)
 
-	for {
-		reqBuilder := larkbitable.NewSearchAppTableRecordReqBuilder().
+	it := lark.NewSearchRecordIterator(context.Background(), s.c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+		return larkbitable.NewSearchAppTableRecordReqBuilder().
 			AppToken(*tableConfig.TableToken).
 			TableId(*tableConfig.TableID).
-			PageSize(500) // 建议用飞书允许的最大值
-
-		if pageToken != nil {
-			reqBuilder.PageToken(*pageToken)
-		}
-
-		req := reqBuilder.
+			PageToken(pageToken).
+			PageSize(lark.MaxSearchPageSize).
 			Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
 				ViewId(*tableConfig.ViewID).
 				AutomaticFields(false).
 				Build()).
 			Build()
+	})
+	// 只提取 RecordID
+	for it.Next() {
+		allRecordIDs = append(allRecordIDs, *it.Record().RecordId)
+	}
+	if err := it.Err(); err != nil {
+		s.log.Error("ForceSyncTableRecords 查询飞书记录失败",
+			logger.String("error", err.Error()),
+		)
+		return nil, toLarkError(err)
+	}
 
-		ctx := context.Background()
-		resp, err := s.c.GetAppTableRecord(ctx, req)
-		if err != nil {
-			s.log.Error("ForceSyncTableRecords 调用失败",
-				logger.String("error", err.Error()),
-			)
-			return nil, 0, false, errs.LarkRequestError(err)
-		}
+	jobID, err := s.createSyncJob(model.SyncJobKindForce, tableConfig)
+	if err != nil {
+		return nil, err
+	}
 
-		if !resp.Success() {
-			s.log.Error("ForceSyncTableRecords Lark 接口错误",
-				logger.String("request_id", resp.RequestId()),
-				logger.String("error", larkcore.Prettify(resp.CodeError)),
-			)
-			return nil, 0, false, errs.LarkResponseError(err)
-		}
+	// 分批入队
+	submission := &domain.SyncSubmission{
+		JobID:     jobID,
+		RecordIDs: []string{},
+	}
+	batches, err := s.enqueueRecordBatches(submission, allRecordIDs, tableConfig)
+	s.setSyncJobBatches(jobID, batches, submission.Total, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// IncrementalSyncTableRecords 增量同步表格记录
+// 从飞书拉取最后修改时间不早于高水位的记录并入队，由 SyncLarkRecords 写入数据库
+// 高水位记录在同步任务上，全部批次同步成功后才推进，有批次失败时下一轮从原高水位重新拉取
+func (s *SheetServiceImpl) IncrementalSyncTableRecords(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error) {
+	wm, err := s.watermarkDAO.GetWatermark(*tableConfig.TableIdentity)
+	if err != nil {
+		s.log.Error("GetWatermark 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetSyncWatermarkError(err)
+	}
+
+	scanStart := time.Now()
+	// 首次同步时从 initialLookback 之前开始
+	since := scanStart.Add(-time.Duration(s.syncCfg.InitialLookback) * time.Second).UnixMilli()
+	if wm != nil {
+		since = wm.LastModifiedTime
+	}
+
+	kind := model.SyncJobKindIncremental
+	if s.syncCfg.ModifiedTimeField == "" {
+		kind = model.SyncJobKindIncrementalScan
+		s.log.Warn("IncrementalSyncTableRecords 未配置修改时间字段，将扫描全表后按修改时间过滤",
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+	}
 
-		// 只提取 RecordID
-		for _, r := range resp.Data.Items {
-			allRecordIDs = append(allRecordIDs, *r.RecordId)
+	var recordIDs []string
+	maxModified := since
+
+	it := lark.NewSearchRecordIterator(context.Background(), s.c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+		body := larkbitable.NewSearchAppTableRecordReqBodyBuilder().
+			ViewId(*tableConfig.ViewID).
+			AutomaticFields(true) // 返回 last_modified_time
+		if s.syncCfg.ModifiedTimeField != "" {
+			// 日期过滤只精确到天，向前多取一天，再按 last_modified_time 精确过滤
+			day := time.UnixMilli(since).Add(-24 * time.Hour).UnixMilli()
+			body.Filter(larkbitable.NewFilterInfoBuilder().
+				Conjunction("and").
+				Conditions([]*larkbitable.Condition{
+					larkbitable.NewConditionBuilder().
+						FieldName(s.syncCfg.ModifiedTimeField).
+						Operator("isGreater").
+						Value([]string{"ExactDate", strconv.FormatInt(day, 10)}).
+						Build(),
+				}).
+				Build())
 		}
 
-		if !*resp.Data.HasMore {
-			break
+		return larkbitable.NewSearchAppTableRecordReqBuilder().
+			AppToken(*tableConfig.TableToken).
+			TableId(*tableConfig.TableID).
+			PageToken(pageToken).
+			PageSize(lark.MaxSearchPageSize).
+			Body(body.Build()).
+			Build()
+	})
+	for it.Next() {
+		r := it.Record()
+		if r.RecordId == nil || r.LastModifiedTime == nil || *r.LastModifiedTime < since {
+			continue
 		}
+		recordIDs = append(recordIDs, *r.RecordId)
+		if *r.LastModifiedTime > maxModified {
+			maxModified = *r.LastModifiedTime
+		}
+	}
+	if err := it.Err(); err != nil {
+		s.log.Error("IncrementalSyncTableRecords 查询飞书记录失败",
+			logger.String("error", err.Error()),
+		)
+		return nil, toLarkError(err)
+	}
 
-		pageToken = resp.Data.PageToken
+	jobID, err := s.createSyncJob(kind, tableConfig)
+	if err != nil {
+		return nil, err
 	}
 
-	// 分批入队
-	totalEnqueued := 0
-	queueFull := false
+	submission := &domain.SyncSubmission{
+		JobID:     jobID,
+		RecordIDs: []string{},
+	}
+	batches, err := s.enqueueRecordBatches(submission, recordIDs, tableConfig)
+	// 未全部入队时不推进高水位，下一轮重新拉取
+	var watermark *int64
+	if err == nil && !submission.QueueFull {
+		// 扫描期间被修改的记录可能已经读过旧版本，高水位不超过扫描开始时间
+		next := maxModified
+		if limit := scanStart.Add(-incrementalOverlap).UnixMilli(); next > limit {
+			next = limit
+		}
+		if next < since {
+			next = since
+		}
+		watermark = &next
+	}
+	s.setSyncJobBatches(jobID, batches, submission.Total, watermark)
+	if err != nil {
+		return nil, err
+	}
 
-	for i := 0; i < len(allRecordIDs); i += queueBatchSize {
+	return submission, nil
+}
+
+// enqueueRecordBatches 将 recordIDs 按 queueBatchSize 拆分入队，入队结果累加到 submission，返回成功入队的批次数
+// 多次同步失败进入死信或已放弃的记录不再自动重试，入队前逐批过滤
+// 队列已满时停止入队并设置 submission.QueueFull
+func (s *SheetServiceImpl) enqueueRecordBatches(submission *domain.SyncSubmission, recordIDs []string, tableConfig *domain.TableConfig) (int, error) {
+	batches := 0
+	for i := 0; i < len(recordIDs); i += queueBatchSize {
 		end := i + queueBatchSize
-		if end > len(allRecordIDs) {
-			end = len(allRecordIDs)
+		if end > len(recordIDs) {
+			end = len(recordIDs)
 		}
 
-		subBatch := allRecordIDs[i:end]
+		subBatch, err := s.filterRetryableRecordIDs(recordIDs[i:end], tableConfig)
+		if err != nil {
+			return batches, err
+		}
+		if len(subBatch) == 0 {
+			continue
+		}
 
 		msg := SyncMsg{
+			Kind:        SyncKindRecords,
+			JobID:       submission.JobID,
+			BatchNo:     submission.Batches + 1,
 			RecordIDs:   subBatch,
 			TableConfig: *tableConfig,
 		}
 
-		select {
-		case syncCh <- msg:
-			totalEnqueued += len(subBatch)
+		full, err := s.enqueueSync(msg)
+		if err != nil {
+			return batches, err
+		}
+		if full {
+			submission.QueueFull = true
+			return batches, nil
+		}
+		batches++
+		submission.Batches++
+		submission.RecordIDs = append(submission.RecordIDs, subBatch...)
+		submission.Total += len(subBatch)
+	}
+
+	return batches, nil
+}
+
+// SyncChangedRecords 将飞书事件推送的变更记录写入同步队列，FAQ 表格则整体同步
+func (s *SheetServiceImpl) SyncChangedRecords(recordIDs []string, tableConfig *domain.TableConfig) error {
+	if bytes.Contains([]byte(*tableConfig.TableIdentity), []byte("-faq")) {
+		full, err := s.enqueueSync(SyncMsg{
+			Kind:        SyncKindTable,
+			TableConfig: *tableConfig,
+		})
+		if err != nil {
+			return err
+		}
+		if full {
+			return errs.SyncQueueFullError(errors.New("sync queue is full"))
+		}
+		return nil
+	}
+
+	for i := 0; i < len(recordIDs); i += queueBatchSize {
+		end := i + queueBatchSize
+		if end > len(recordIDs) {
+			end = len(recordIDs)
+		}
+
+		// 事件推送的记录同样跳过死信
+		subBatch, err := s.filterRetryableRecordIDs(recordIDs[i:end], tableConfig)
+		if err != nil {
+			return err
+		}
+		if len(subBatch) == 0 {
+			continue
+		}
 
-		default:
-			queueFull = true
-			return allRecordIDs[:totalEnqueued], totalEnqueued, true, nil
+		full, err := s.enqueueSync(SyncMsg{
+			Kind:        SyncKindRecords,
+			RecordIDs:   subBatch,
+			TableConfig: *tableConfig,
+		})
+		if err != nil {
+			return err
+		}
+		if full {
+			return errs.SyncQueueFullError(errors.New("sync queue is full"))
 		}
 	}
 
-	return allRecordIDs, totalEnqueued, queueFull, nil
+	return nil
 }
 
-func (s *SheetServiceImpl) SyncLarkRecords(recordIDs []string, tableConfig domain.TableConfig) error {
+// SyncLarkRecords 从飞书获取指定记录并写入数据库，返回本批次的同步结果
+// 单条记录写入失败不会返回错误，只计入失败数；只有飞书请求失败时返回错误，由队列重新投递
+func (s *SheetServiceImpl) SyncLarkRecords(recordIDs []string, tableConfig domain.TableConfig) (domain.SyncStats, error) {
+	var stats domain.SyncStats
+
 	// 创建请求对象
 	req := larkbitable.NewBatchGetAppTableRecordReqBuilder().
 		AppToken(*tableConfig.TableToken).
@@ -866,6 +1216,7 @@ func (s *SheetServiceImpl) SyncLarkRecords(recordIDs []string, tableConfig domai
 		Body(larkbitable.NewBatchGetAppTableRecordReqBodyBuilder().
 			RecordIds(recordIDs).
 			WithSharedUrl(true).
+			AutomaticFields(true). // 返回 last_modified_time，作为导入回复的版本
 			Build()).
 		Build()
 
@@ -878,7 +1229,7 @@ func (s *SheetServiceImpl) SyncLarkRecords(recordIDs []string, tableConfig domai
 		s.log.Error("GetTableRecordReqByID 调用失败",
 			logger.String("error", err.Error()),
 		)
-		return errs.LarkRequestError(err)
+		return stats, errs.LarkRequestError(err)
 	}
 
 	// 服务端错误处理
@@ -887,14 +1238,50 @@ func (s *SheetServiceImpl) SyncLarkRecords(recordIDs []string, tableConfig domai
 			logger.String("request_id", resp.RequestId()),
 			logger.String("error", larkcore.Prettify(resp.CodeError)),
 		)
-		return errs.LarkResponseError(err)
+		return stats, errs.LarkResponseError(err)
 	}
 
-	if len(resp.Data.Records) == 0 {
-		s.log.Error("GetTableRecordReqByID no record found")
-		return errs.TableRecordNotFoundError(errors.New("未找到记录"))
+	stats.Fetched = len(resp.Data.Records)
+	if len(resp.Data.AbsentRecordIds) > 0 {
+		// 飞书中已删除的记录，从数据库中软删除
+		s.log.Info("SyncLarkRecords some records deleted in lark",
+			logger.Int("absent_count", len(resp.Data.AbsentRecordIds)),
+		)
+		deleted, err := s.softDeleteRecords(resp.Data.AbsentRecordIds, tableConfig)
+		if err != nil {
+			stats.Failed += len(resp.Data.AbsentRecordIds)
+			reason := fmt.Sprintf("%d 条已删除记录软删除失败", len(resp.Data.AbsentRecordIds))
+			stats.Error = &reason
+		}
+		stats.Deleted = deleted
+	}
+	if forbidden := len(resp.Data.ForbiddenRecordIds); forbidden > 0 {
+		// 无权限访问的记录，重试也无法成功，直接计入失败
+		s.log.Warn("SyncLarkRecords some records forbidden",
+			logger.Int("forbidden_count", forbidden),
+		)
+		stats.Failed += forbidden
+		reason := fmt.Sprintf("%d 条记录无权限访问", forbidden)
+		stats.Error = &reason
 	}
 
+	// 同步前保存的记录，用于判断记录内容与进度在本次同步中是否变化
+	ids := make([]string, 0, len(resp.Data.Records))
+	for _, r := range resp.Data.Records {
+		ids = append(ids, *r.RecordId)
+	}
+	snapshots, err := s.sheetDao.GetRecordSnapshots(*tableConfig.TableIdentity, ids)
+	if err != nil {
+		s.log.Error("GetRecordSnapshots 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+	}
+	// 查询同步前状态失败时无法判断是否变化，不发送事件，避免重复推送
+	publishEvents := err == nil
+
+	succeeded := make([]string, 0, len(resp.Data.Records))
+	var updated, completed []domain.WebhookRecord
 	for _, r := range resp.Data.Records {
 		recordData := simplifyFields(r.Fields)
 
@@ -904,11 +1291,55 @@ func (s *SheetServiceImpl) SyncLarkRecords(recordIDs []string, tableConfig domai
 				logger.String("error", err.Error()),
 				logger.String("record_id", *r.RecordId),
 			)
-			// 同步失败不返回错误，继续同步其他记录，最终通过定时任务再次尝试同步未同步的记录
+			// 同步失败不返回错误，继续同步其他记录，记录失败次数，达到上限后进入死信
+			s.recordSyncFailure(tableConfig, *r.RecordId, err)
+			stats.Failed++
+			if stats.Error == nil {
+				reason := fmt.Sprintf("record %s: %s", *r.RecordId, err.Error())
+				stats.Error = &reason
+			}
+			continue
+		}
+		stats.Updated++
+		succeeded = append(succeeded, *r.RecordId)
+		s.importLarkReply(r, recordData, tableConfig)
+
+		if !publishEvents {
+			continue
+		}
+		snapshot := snapshots[*r.RecordId]
+		if !recordChanged(snapshot, recordData, r.SharedUrl) {
+			continue
+		}
+
+		wr := domain.WebhookRecord{RecordID: *r.RecordId, Record: recordData}
+		if r.SharedUrl != nil {
+			wr.ShareURL = *r.SharedUrl
+		}
+		updated = append(updated, wr)
+
+		// 进度变化时数据库会重置通知状态，由通知消费者按进度通知学生
+		before, after := "", recordProgress(recordData)
+		if snapshot != nil && snapshot.Progress != nil {
+			before = *snapshot.Progress
+		}
+		if before != after {
+			s.log.Info("SyncLarkRecords record progress changed",
+				logger.String("table_identity", *tableConfig.TableIdentity),
+				logger.String("record_id", *r.RecordId),
+				logger.String("from", before),
+				logger.String("to", after),
+			)
+			if isRecordCompleted(recordData) {
+				completed = append(completed, wr)
+			}
 		}
 	}
+	s.clearSyncFailures(tableConfig, succeeded)
+	s.publishRecordEvents(WebhookEventRecordUpdated, updated, tableConfig)
+	s.publishRecordEvents(WebhookEventRecordCompleted, completed, tableConfig)
 
-	return nil
+	return stats, nil
 }
 
 // GetFAQResolutionRecord 获取 FAQ 记录，全部从 DB 获取
@@ -1060,47 +1491,66 @@ func (s *SheetServiceImpl) UpdateFAQResolutionRecordV2(resolution *domain.FAQRes
 	return nil
 }
 
-// SyncFAQRecord 同步飞书表格和数据库中的 FAQ 记录，保证两者的一致性
+// SyncFAQRecord 同步飞书表格和数据库中的 FAQ 记录，并记录为一个同步任务
+// FAQ 记录数量较少，直接同步执行，返回时任务已结束
+func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) (*domain.SyncSubmission, error) {
+	jobID, err := s.createSyncJob(model.SyncJobKindFAQ, tableConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.markSyncJobRunning(jobID)
+
+	stats, err := s.syncFAQRecord(tableConfig)
+	if err != nil && stats.Error == nil {
+		reason := err.Error()
+		stats.Error = &reason
+	}
+	s.recordSyncJobBatch(jobID, 1, stats)
+	s.setSyncJobBatches(jobID, 1, stats.Fetched, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SyncSubmission{
+		JobID:     jobID,
+		RecordIDs: []string{},
+		Total:     stats.Fetched,
+	}, nil
+}
+
+// syncFAQRecord 同步飞书表格和数据库中的 FAQ 记录，保证两者的一致性
 // 这个过程需要 redis <-> mysql <-> 飞书表格 三者的配合，保证最终一致性
-func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) error {
+func (s *SheetServiceImpl) syncFAQRecord(tableConfig *domain.TableConfig) (domain.SyncStats, error) {
+	var stats domain.SyncStats
+
 	g, ctx := errgroup.WithContext(context.Background())
 
 	var larkResp map[string]map[string]interface{}
 	var dbResp map[string]struct{}
 	// 1 获取飞书数据
 	g.Go(func() error {
-		// 创建请求对象
-		req := larkbitable.NewSearchAppTableRecordReqBuilder().
-			AppToken(*tableConfig.TableToken).
-			TableId(*tableConfig.TableID).
-			PageToken("").
-			PageSize(100). // 分页大小，拿全部，有更大的需求再改大
-			Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
-				ViewId(*tableConfig.ViewID).
-				FieldNames(nil). //返回所有字段
-				Build()).
-			Build()
-
-		// 发起请求
-		resp, err := s.c.GetAppTableRecord(ctx, req)
+		// 拉取全部分页，只拿到部分记录时会误删数据库中的 FAQ 记录
+		items, err := lark.CollectSearchRecords(ctx, s.c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+			return larkbitable.NewSearchAppTableRecordReqBuilder().
+				AppToken(*tableConfig.TableToken).
+				TableId(*tableConfig.TableID).
+				PageToken(pageToken).
+				PageSize(lark.MaxSearchPageSize).
+				Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
+					ViewId(*tableConfig.ViewID).
+					FieldNames(nil). //返回所有字段
+					Build()).
+				Build()
+		})
 		if err != nil {
-			s.log.Error("SyncFAQRecord 调用失败",
+			s.log.Error("SyncFAQRecord 查询飞书记录失败",
 				logger.String("error", err.Error()),
 			)
-			return errs.LarkRequestError(err)
-		}
-
-		// 服务端错误处理
-		if !resp.Success() {
-			s.log.Error("SyncFAQRecord Lark 接口错误",
-				logger.String("request_id", resp.RequestId()),
-				logger.String("error", larkcore.Prettify(resp.CodeError)),
-			)
-			return errs.LarkResponseError(err)
+			return toLarkError(err)
 		}
 
 		larkResp = make(map[string]map[string]interface{})
-		for _, r := range resp.Data.Items {
+		for _, r := range items {
 			larkResp[*r.RecordId] = simplifyFields(r.Fields)
 		}
 
@@ -1124,11 +1574,20 @@ func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) error
 	})
 
 	if err := g.Wait(); err != nil {
-		return err
+		return stats, err
 	}
+	stats.Fetched = len(larkResp)
 
 	sem := make(chan struct{}, 10)
 	var wg sync.WaitGroup
+	// 记录同步失败的 recordID，同一条记录在数据库和飞书中都失败时只计一次
+	var failedMu sync.Mutex
+	failedIDs := make(map[string]struct{})
+	markFailed := func(rID string) {
+		failedMu.Lock()
+		failedIDs[rID] = struct{}{}
+		failedMu.Unlock()
+	}
 	flag := true
 
 	// 3 同步 record + 更新 Redis 计数器
@@ -1151,6 +1610,7 @@ func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) error
 		err := s.faqDAO.CreateOrUpdateSheetRecord(m)
 		if err != nil {
 			flag = false
+			markFailed(recordID)
 			s.log.Error("mysql upsert err",
 				logger.String("record_id", recordID),
 				logger.String("error", err.Error()),
@@ -1186,6 +1646,7 @@ func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) error
 					logger.String("error", err.Error()),
 					logger.String("record_id", rID),
 				)
+				markFailed(rID)
 				return
 			}
 
@@ -1196,6 +1657,7 @@ func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) error
 					logger.String("error", larkcore.Prettify(resp.CodeError)),
 					logger.String("record_id", rID),
 				)
+				markFailed(rID)
 				return
 			}
 		}(recordID, resolvedNum, unresolvedNum)
@@ -1234,10 +1696,12 @@ func (s *SheetServiceImpl) SyncFAQRecord(tableConfig *domain.TableConfig) error
 
 	wg.Wait()
 
+	stats.Failed = len(failedIDs)
+	stats.Updated = stats.Fetched - stats.Failed
 	if !flag {
-		return errs.SyncFAQRecordPartialFailedError(errors.New("部分 FAQ 记录同步失败"))
+		return stats, errs.SyncFAQRecordPartialFailedError(errors.New("部分 FAQ 记录同步失败"))
 	}
-	return nil
+	return stats, nil
 }
 
 func stringIsResolved(isResolved *bool) *string {
@@ -1282,3 +1746,71 @@ func decodePageToken(token string) (*uint64, error) {
 
 	return &pt.LastID, nil
 }
+
+// recordProgress 获取记录的进度，未填写时为空
+func recordProgress(recordData map[string]any) string {
+	progress, _ := recordData["进度"].(string)
+	return progress
+}
+
+// isRecordCompleted 判断记录的进度是否为已完成
+func isRecordCompleted(recordData map[string]any) bool {
+	return recordProgress(recordData) == "已完成"
+}
+
+// importLarkReply 将表格回复列的内容导入为工作人员的回复，以记录的最后修改时间作为回复版本，失败时只记录日志，不影响同步
+func (s *SheetServiceImpl) importLarkReply(r *larkbitable.AppTableRecord, recordData map[string]any, tableConfig domain.TableConfig) {
+	if tableConfig.ReplyField == "" {
+		return
+	}
+	reply, _ := recordData[tableConfig.ReplyField].(string)
+	studentID, _ := recordData["学号"].(string)
+	if reply == "" || studentID == "" {
+		return
+	}
+
+	var revision int64
+	if r.LastModifiedTime != nil {
+		revision = *r.LastModifiedTime
+	}
+
+	if err := s.thread.ImportLarkReply(*r.RecordId, studentID, reply, revision, &tableConfig); err != nil {
+		s.log.Error("ImportLarkReply 导入飞书回复失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", *r.RecordId),
+		)
+	}
+}
+
+// recordChanged 判断飞书中的记录与数据库中保存的版本是否不同，数据库中不存在时视为变化
+// 数据库中的记录经过 JSON 序列化，按序列化结果比较，避免数值类型不同导致误判
+func recordChanged(snapshot *model.Sheet, record map[string]any, shareURL *string) bool {
+	if snapshot == nil {
+		return true
+	}
+	if (snapshot.ShareUrl == nil) != (shareURL == nil) ||
+		(shareURL != nil && *snapshot.ShareUrl != *shareURL) {
+		return true
+	}
+
+	before, err := json.Marshal(snapshot.Record)
+	if err != nil {
+		return true
+	}
+	after, err := json.Marshal(record)
+	if err != nil {
+		return true
+	}
+	return !bytes.Equal(before, after)
+}
+
+// publishRecordEvents 推送记录事件到 webhook，失败时只记录日志，不影响同步
+func (s *SheetServiceImpl) publishRecordEvents(event string, records []domain.WebhookRecord, tableConfig domain.TableConfig) {
+	if err := s.webhook.PublishRecordEvents(event, records, tableConfig); err != nil {
+		s.log.Error("PublishRecordEvents 登记 webhook 事件失败",
+			logger.String("error", err.Error()),
+			logger.String("event", event),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+	}
+}
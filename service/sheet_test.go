@@ -0,0 +1,141 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	serviceMock "github.com/muxi-Infra/FeedBack-Backend/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func larkRecord(id, progress string) *larkbitable.AppTableRecord {
+	shareURL := "https://share/" + id
+	return &larkbitable.AppTableRecord{
+		RecordId:  &id,
+		SharedUrl: &shareURL,
+		Fields:    map[string]any{"学号": "2023001", "进度": progress},
+	}
+}
+
+func recordSnapshot(id, progress string) *model.Sheet {
+	shareURL := "https://share/" + id
+	return &model.Sheet{
+		RecordID: &id,
+		ShareUrl: &shareURL,
+		Record:   map[string]any{"学号": "2023001", "进度": progress},
+		Progress: &progress,
+	}
+}
+
+// 周期同步时只为内容真正变化的记录推送 record.updated，进度变为已完成时额外推送 record.completed
+func TestSyncLarkRecordsPublishesOnlyChangedRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := larkMock.NewMockClient(ctrl)
+	sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+	failureDAO := daoMock.NewMockSyncFailureDAO(ctrl)
+	webhook := serviceMock.NewMockWebhookService(ctrl)
+	s := &SheetServiceImpl{
+		c:          client,
+		log:        newTestLogger(),
+		sheetDao:   sheetDAO,
+		failureDAO: failureDAO,
+		webhook:    webhook,
+	}
+	tc := newTestTableConfig()
+	ids := []string{"rec-same", "rec-done", "rec-new"}
+
+	client.EXPECT().GetRecordByRecordId(gomock.Any(), gomock.Any()).
+		Return(&larkbitable.BatchGetAppTableRecordResp{
+			Data: &larkbitable.BatchGetAppTableRecordRespData{
+				Records: []*larkbitable.AppTableRecord{
+					larkRecord("rec-same", "处理中"),
+					larkRecord("rec-done", "已完成"),
+					larkRecord("rec-new", "待处理"),
+				},
+			},
+		}, nil)
+	sheetDAO.EXPECT().GetRecordSnapshots(*tc.TableIdentity, ids).
+		Return(map[string]*model.Sheet{
+			"rec-same": recordSnapshot("rec-same", "处理中"),
+			"rec-done": recordSnapshot("rec-done", "处理中"),
+		}, nil)
+	sheetDAO.EXPECT().CreateOrUpdateSheetRecord(gomock.Any()).Return(nil).Times(3)
+	failureDAO.EXPECT().ClearSyncFailures(*tc.TableIdentity, ids).Return(nil)
+
+	webhook.EXPECT().PublishRecordEvents(WebhookEventRecordUpdated, gomock.Any(), tc).
+		DoAndReturn(func(_ string, records []domain.WebhookRecord, _ domain.TableConfig) error {
+			got := make([]string, 0, len(records))
+			for _, r := range records {
+				got = append(got, r.RecordID)
+			}
+			assert.Equal(t, []string{"rec-done", "rec-new"}, got)
+			return nil
+		})
+	webhook.EXPECT().PublishRecordEvents(WebhookEventRecordCompleted, gomock.Any(), tc).
+		DoAndReturn(func(_ string, records []domain.WebhookRecord, _ domain.TableConfig) error {
+			require.Len(t, records, 1)
+			assert.Equal(t, "rec-done", records[0].RecordID)
+			return nil
+		})
+
+	stats, err := s.SyncLarkRecords(ids, tc)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Updated)
+}
+
+func TestRecordChanged(t *testing.T) {
+	other := "https://share/other"
+
+	tests := []struct {
+		name     string
+		snapshot *model.Sheet
+		record   map[string]any
+		shareURL *string
+		want     bool
+	}{
+		{
+			name:   "not in db",
+			record: map[string]any{"进度": "处理中"},
+			want:   true,
+		},
+		{
+			name:     "same content",
+			snapshot: recordSnapshot("rec", "处理中"),
+			record:   map[string]any{"学号": "2023001", "进度": "处理中"},
+			shareURL: recordSnapshot("rec", "处理中").ShareUrl,
+			want:     false,
+		},
+		{
+			name:     "int and float64 with same value",
+			snapshot: &model.Sheet{Record: map[string]any{"评分": float64(5)}},
+			record:   map[string]any{"评分": 5},
+			want:     false,
+		},
+		{
+			name:     "field changed",
+			snapshot: recordSnapshot("rec", "处理中"),
+			record:   map[string]any{"学号": "2023001", "进度": "已完成"},
+			shareURL: recordSnapshot("rec", "处理中").ShareUrl,
+			want:     true,
+		},
+		{
+			name:     "share url changed",
+			snapshot: recordSnapshot("rec", "处理中"),
+			record:   map[string]any{"学号": "2023001", "进度": "处理中"},
+			shareURL: &other,
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, recordChanged(tt.snapshot, tt.record, tt.shareURL))
+		})
+	}
+}
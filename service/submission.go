@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+)
+
+const (
+	DuplicatePolicyReject = "reject" // 拒绝重复提交
+	DuplicatePolicyMerge  = "merge"  // 合并为原记录下的追问，不创建新记录
+	DuplicatePolicyFlag   = "flag"   // 正常创建记录，并在标记列注明疑似重复
+
+	recentSubmissionLimit = 20 // 查重时最多比较的近期记录数
+)
+
+//go:generate mockgen -destination=./mock/submission_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service SubmissionService
+type SubmissionService interface {
+	CheckSubmission(studentID string, record *domain.TableRecord, tableConfig *domain.TableConfig) (*domain.SubmissionCheck, error)
+	RememberSubmission(studentID, recordID, content string, tableConfig *domain.TableConfig)
+}
+
+type SubmissionServiceImpl struct {
+	log      logger.Logger
+	cfg      *config.DuplicateConfig
+	sheetDao dao.SheetDAO
+	cache    cache.SubmissionCache
+}
+
+func NewSubmissionService(log logger.Logger, cfg *config.DuplicateConfig, sheetDao dao.SheetDAO, cache cache.SubmissionCache) SubmissionService {
+	return &SubmissionServiceImpl{
+		log:      log,
+		cfg:      cfg,
+		sheetDao: sheetDao,
+		cache:    cache,
+	}
+}
+
+// CheckSubmission 创建记录前检查提交频率，并与该学生近期在同一表格的提交查重，除 flag 策略写入标记列外没有其他副作用
+// 超出提交次数上限或按 reject 策略拒绝时返回错误；merge 策略下由调用方将内容追加为原记录的追问，追问失败时照常创建记录；
+// flag 策略下在 record 中写入标记列，调用方照常创建记录
+// 查重依赖的 Redis、数据库出错时只记录日志，不影响正常提交
+func (s *SubmissionServiceImpl) CheckSubmission(studentID string, record *domain.TableRecord, tableConfig *domain.TableConfig) (*domain.SubmissionCheck, error) {
+	check := &domain.SubmissionCheck{}
+
+	// 提交次数按请求计数，被拒绝的重复提交同样计入，避免反复重试刷接口
+	if err := s.checkQuota(studentID, tableConfig); err != nil {
+		return nil, err
+	}
+
+	if !s.cfg.Enabled {
+		return check, nil
+	}
+	content, _ := record.Record["反馈内容"].(string)
+	normalized := normalizeContent(content)
+	if normalized == "" {
+		return check, nil
+	}
+
+	dup, similarity := s.findDuplicate(studentID, normalized, tableConfig)
+	if dup == "" {
+		return check, nil
+	}
+	check.Policy = s.cfg.Policy
+	check.DuplicateOf = dup
+	check.Similarity = similarity
+
+	switch s.cfg.Policy {
+	case DuplicatePolicyMerge:
+		s.log.Info("重复提交将合并为追问",
+			logger.String("table_identify", *tableConfig.TableIdentity),
+			logger.String("duplicate_of", dup),
+			logger.Float64("similarity", similarity),
+		)
+	case DuplicatePolicyFlag:
+		s.log.Info("疑似重复提交",
+			logger.String("table_identify", *tableConfig.TableIdentity),
+			logger.String("duplicate_of", dup),
+			logger.Float64("similarity", similarity),
+		)
+		if s.cfg.FlagField != "" {
+			record.Record[s.cfg.FlagField] = fmt.Sprintf("与 %s 相似度 %.0f%%", dup, similarity*100)
+		}
+	default:
+		return nil, errs.DuplicateSubmissionError(fmt.Errorf("content duplicates record %s (similarity %.2f)", dup, similarity))
+	}
+
+	return check, nil
+}
+
+// RememberSubmission 记录创建成功后缓存提交内容，在记录落库前也能识别重复提交
+func (s *SubmissionServiceImpl) RememberSubmission(studentID, recordID, content string, tableConfig *domain.TableConfig) {
+	if !s.cfg.Enabled {
+		return
+	}
+	normalized := normalizeContent(content)
+	if normalized == "" {
+		return
+	}
+
+	err := s.cache.AddRecentSubmission(context.Background(), *tableConfig.TableIdentity, studentID, recordID, normalized, s.window())
+	if err != nil {
+		s.log.Warn("缓存提交内容失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+	}
+}
+
+func (s *SubmissionServiceImpl) checkQuota(studentID string, tableConfig *domain.TableConfig) error {
+	if s.cfg.QuotaLimit <= 0 {
+		return nil
+	}
+
+	n, err := s.cache.IncrSubmissionCount(context.Background(), *tableConfig.TableIdentity, studentID, time.Duration(s.cfg.QuotaWindow)*time.Second)
+	if err != nil {
+		s.log.Warn("统计提交次数失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identify", *tableConfig.TableIdentity),
+		)
+		return nil
+	}
+	if n > int64(s.cfg.QuotaLimit) {
+		return errs.SubmissionQuotaExceededError(fmt.Errorf("student %s submitted %d times within %ds", studentID, n, s.cfg.QuotaWindow))
+	}
+
+	return nil
+}
+
+// findDuplicate 在缓存的近期提交与数据库中窗口期内的记录里查找相似度最高且不低于阈值的记录
+func (s *SubmissionServiceImpl) findDuplicate(studentID, normalized string, tableConfig *domain.TableConfig) (string, float64) {
+	candidates := make(map[string]string)
+
+	recent, err := s.cache.GetRecentSubmissions(context.Background(), *tableConfig.TableIdentity, studentID)
+	if err != nil {
+		s.log.Warn("获取近期提交内容失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identify", *tableConfig.TableIdentity),
+		)
+	}
+	for recordID, content := range recent {
+		candidates[recordID] = content
+	}
+
+	records, err := s.sheetDao.ListRecentRecordsByUser(*tableConfig.TableIdentity, studentID, time.Now().Add(-s.window()), recentSubmissionLimit)
+	if err != nil {
+		s.log.Warn("ListRecentRecordsByUser 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identify", *tableConfig.TableIdentity),
+		)
+	}
+	for _, r := range records {
+		if r.RecordID == nil {
+			continue
+		}
+		// 已撤回的记录不参与查重，学生撤回后可以重新提交
+		if recordProgress(r.Record) == progressWithdrawn {
+			delete(candidates, *r.RecordID)
+			continue
+		}
+		content, _ := r.Record["反馈内容"].(string)
+		candidates[*r.RecordID] = normalizeContent(content)
+	}
+
+	var (
+		best           string
+		bestSimilarity float64
+	)
+	for recordID, content := range candidates {
+		similarity := contentSimilarity(normalized, content)
+		if similarity >= s.cfg.Threshold && similarity > bestSimilarity {
+			best, bestSimilarity = recordID, similarity
+		}
+	}
+
+	return best, bestSimilarity
+}
+
+func (s *SubmissionServiceImpl) window() time.Duration {
+	return time.Duration(s.cfg.Window) * time.Second
+}
+
+// normalizeContent 归一化反馈内容：转为小写并去掉空白、标点和符号，避免只改动格式的重复提交绕过查重
+func normalizeContent(content string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, content)
+}
+
+// contentSimilarity 按字符二元组计算两段内容的 Dice 系数，对中文短文本比按词切分更稳定
+func contentSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 2 || len(rb) < 2 {
+		return 0
+	}
+
+	grams := make(map[[2]rune]int, len(ra)-1)
+	for i := 0; i+1 < len(ra); i++ {
+		grams[[2]rune{ra[i], ra[i+1]}]++
+	}
+	overlap := 0
+	for i := 0; i+1 < len(rb); i++ {
+		g := [2]rune{rb[i], rb[i+1]}
+		if grams[g] > 0 {
+			grams[g]--
+			overlap++
+		}
+	}
+
+	return 2 * float64(overlap) / float64(len(ra)+len(rb)-2)
+}
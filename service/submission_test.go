@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	cacheMock "github.com/muxi-Infra/FeedBack-Backend/repository/cache/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty", content: "", want: ""},
+		{name: "only spaces and punctuation", content: " ，。！\n\t...", want: ""},
+		{name: "chinese punctuation removed", content: "课表打不开，怎么办？", want: "课表打不开怎么办"},
+		{name: "ascii lowered", content: "App Crash!!", want: "appcrash"},
+		{name: "full width spaces and symbols", content: "成绩　查询 ~~ 失败 😭", want: "成绩查询失败"},
+		{name: "digits kept", content: "第 3 节课", want: "第3节课"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeContent(tt.content))
+		})
+	}
+}
+
+func TestContentSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "identical", a: "课表打不开", b: "课表打不开", want: 1},
+		{name: "identical single rune", a: "好", b: "好", want: 1},
+		{name: "single rune differs", a: "好", b: "坏", want: 0},
+		{name: "too short to compare", a: "好", b: "好的", want: 0},
+		{name: "no overlap", a: "课表打不开", b: "成绩查询失败", want: 0},
+		// 课表 表打 打不 不开 与 课表 表打 打不 不开 开了，重叠 4 个二元组
+		{name: "appended rune", a: "课表打不开", b: "课表打不开了", want: 8.0 / 9},
+		{name: "repeated grams counted once each", a: "哈哈哈", b: "哈哈", want: 2.0 / 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, contentSimilarity(tt.a, tt.b), 1e-9)
+			assert.InDelta(t, tt.want, contentSimilarity(tt.b, tt.a), 1e-9, "similarity should be symmetric")
+		})
+	}
+}
+
+func newTestSubmissionService(t *testing.T, cfg config.DuplicateConfig) (*SubmissionServiceImpl, *cacheMock.MockSubmissionCache, *daoMock.MockSheetDAO) {
+	ctrl := gomock.NewController(t)
+	submissionCache := cacheMock.NewMockSubmissionCache(ctrl)
+	sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+	s := &SubmissionServiceImpl{log: newTestLogger(), cfg: &cfg, sheetDao: sheetDAO, cache: submissionCache}
+	return s, submissionCache, sheetDAO
+}
+
+func newSubmissionRecord(content string) *domain.TableRecord {
+	return &domain.TableRecord{Record: map[string]any{"反馈内容": content}}
+}
+
+// 查重命中后按策略处理：reject 返回错误，merge 只返回原记录交给调用方追问，flag 写入标记列；检查本身不发送任何消息
+func TestCheckSubmissionPolicies(t *testing.T) {
+	studentID := "2023001"
+	tc := newTestTableConfig()
+	withdrawnID := "rec-withdrawn"
+
+	tests := []struct {
+		name       string
+		policy     string
+		content    string
+		wantErr    int
+		wantPolicy string
+		wantDup    string
+		wantFlag   string
+	}{
+		{name: "reject", policy: DuplicatePolicyReject, content: "课表打不开！", wantErr: errs.DuplicateSubmissionErrorCode},
+		{name: "merge", policy: DuplicatePolicyMerge, content: "课表打不开了", wantPolicy: DuplicatePolicyMerge, wantDup: "rec-1"},
+		{name: "flag", policy: DuplicatePolicyFlag, content: "课表 打不开", wantPolicy: DuplicatePolicyFlag, wantDup: "rec-1", wantFlag: "与 rec-1 相似度 100%"},
+		{name: "below threshold", policy: DuplicatePolicyReject, content: "成绩查询失败"},
+		{name: "withdrawn record ignored", policy: DuplicatePolicyReject, content: "图书馆座位预约失败"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, submissionCache, sheetDAO := newTestSubmissionService(t, config.DuplicateConfig{
+				Enabled:   true,
+				Window:    600,
+				Threshold: 0.8,
+				Policy:    tt.policy,
+				FlagField: "疑似重复",
+			})
+
+			// 记录尚未落库时只在缓存中，撤回的记录以数据库中的进度为准
+			submissionCache.EXPECT().GetRecentSubmissions(gomock.Any(), *tc.TableIdentity, studentID).
+				Return(map[string]string{"rec-1": "课表打不开", withdrawnID: "图书馆座位预约失败"}, nil)
+			sheetDAO.EXPECT().ListRecentRecordsByUser(*tc.TableIdentity, studentID, gomock.Any(), recentSubmissionLimit).
+				Return([]*model.Sheet{{RecordID: &withdrawnID, Record: map[string]any{"反馈内容": "图书馆座位预约失败", "进度": progressWithdrawn}}}, nil)
+
+			record := newSubmissionRecord(tt.content)
+			check, err := s.CheckSubmission(studentID, record, &tc)
+			if tt.wantErr != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, errorx.ToCustomError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPolicy, check.Policy)
+			assert.Equal(t, tt.wantDup, check.DuplicateOf)
+			if tt.wantFlag != "" {
+				assert.Equal(t, tt.wantFlag, record.Record["疑似重复"])
+			} else {
+				assert.NotContains(t, record.Record, "疑似重复")
+			}
+		})
+	}
+}
+
+// 超过提交次数上限时拒绝；统计失败时不影响提交；查重关闭时只检查次数
+func TestCheckSubmissionQuota(t *testing.T) {
+	studentID := "2023001"
+	tc := newTestTableConfig()
+
+	tests := []struct {
+		name    string
+		count   int64
+		incrErr error
+		wantErr bool
+	}{
+		{name: "within quota", count: 3},
+		{name: "quota exceeded", count: 4, wantErr: true},
+		{name: "redis unavailable", incrErr: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, submissionCache, _ := newTestSubmissionService(t, config.DuplicateConfig{QuotaLimit: 3, QuotaWindow: 60})
+			submissionCache.EXPECT().IncrSubmissionCount(gomock.Any(), *tc.TableIdentity, studentID, gomock.Any()).Return(tt.count, tt.incrErr)
+
+			check, err := s.CheckSubmission(studentID, newSubmissionRecord("课表打不开"), &tc)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, errs.SubmissionQuotaExceededErrorCode, errorx.ToCustomError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, check.Policy)
+		})
+	}
+}
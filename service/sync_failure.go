@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+// ListSyncFailures 查询表格下同步失败的记录，status 为空时返回全部状态
+func (s *SheetServiceImpl) ListSyncFailures(status string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.SyncFailures, error) {
+	lastID := new(uint64)
+	if pageToken != nil && *pageToken != "" {
+		ld, err := decodePageToken(*pageToken)
+		if err != nil {
+			return nil, errs.PageTokenInvalidError(err)
+		}
+		lastID = ld
+	}
+
+	list, hasMore, err := s.failureDAO.ListSyncFailures(*tableConfig.TableIdentity, status, lastID, limitSize)
+	if err != nil {
+		s.log.Error("ListSyncFailures 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetSyncFailureError(err)
+	}
+
+	ans := make([]domain.SyncFailure, 0, len(list))
+	for _, f := range list {
+		ans = append(ans, toDomainSyncFailure(f))
+	}
+
+	// 生成 nextPageToken
+	var nextToken *string
+	if hasMore && len(list) > 0 {
+		token, _ := encodePageToken(list[len(list)-1].ID)
+		nextToken = &token
+	}
+
+	return &domain.SyncFailures{
+		Failures:  ans,
+		HasMore:   &hasMore,
+		PageToken: nextToken,
+	}, nil
+}
+
+// GetSyncFailure 查询单条记录的同步失败信息
+func (s *SheetServiceImpl) GetSyncFailure(recordID string, tableConfig *domain.TableConfig) (*domain.SyncFailure, error) {
+	f, err := s.failureDAO.GetSyncFailure(*tableConfig.TableIdentity, recordID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.SyncFailureNotFoundError(err)
+	}
+	if err != nil {
+		s.log.Error("GetSyncFailure 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return nil, errs.GetSyncFailureError(err)
+	}
+
+	res := toDomainSyncFailure(f)
+	return &res, nil
+}
+
+// RetrySyncFailure 立即重新同步一条失败的记录，成功后失败记录被清除
+// 重试前将记录恢复为重试中并清零失败次数，死信或已放弃的记录再次失败时重新开始自动重试，达到上限后再次进入死信
+func (s *SheetServiceImpl) RetrySyncFailure(recordID string, tableConfig *domain.TableConfig) (domain.SyncStats, error) {
+	ok, err := s.failureDAO.ResetSyncFailure(*tableConfig.TableIdentity, recordID)
+	if err != nil {
+		s.log.Error("ResetSyncFailure 数据库更新失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return domain.SyncStats{}, errs.UpdateSyncFailureError(err)
+	}
+	if !ok {
+		return domain.SyncStats{}, errs.SyncFailureNotFoundError(fmt.Errorf("sync failure not found: %s", recordID))
+	}
+
+	return s.SyncLarkRecords([]string{recordID}, *tableConfig)
+}
+
+// DiscardSyncFailure 放弃同步一条失败的记录，之后不再自动重试
+func (s *SheetServiceImpl) DiscardSyncFailure(recordID string, tableConfig *domain.TableConfig) error {
+	ok, err := s.failureDAO.DiscardSyncFailure(*tableConfig.TableIdentity, recordID)
+	if err != nil {
+		s.log.Error("DiscardSyncFailure 数据库更新失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return errs.UpdateSyncFailureError(err)
+	}
+	if !ok {
+		return errs.SyncFailureNotFoundError(fmt.Errorf("sync failure not found: %s", recordID))
+	}
+
+	return nil
+}
+
+// 以下方法只维护失败记录，失败时记录日志，不影响同步本身
+
+func (s *SheetServiceImpl) recordSyncFailure(tableConfig domain.TableConfig, recordID string, cause error) {
+	f, err := s.failureDAO.RecordSyncFailure(*tableConfig.TableIdentity, recordID, syncErrorMessage(cause), s.syncCfg.MaxRecordAttempts)
+	if err != nil {
+		s.log.Error("RecordSyncFailure 记录同步失败次数失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return
+	}
+
+	if f.Status == model.SyncFailureStatusDead && f.Attempts == s.syncCfg.MaxRecordAttempts {
+		s.log.Warn("sync record moved to dead letter",
+			logger.String("table_identity", *tableConfig.TableIdentity),
+			logger.String("record_id", recordID),
+			logger.Int("attempts", f.Attempts),
+		)
+	}
+}
+
+func (s *SheetServiceImpl) clearSyncFailures(tableConfig domain.TableConfig, recordIDs []string) {
+	if err := s.failureDAO.ClearSyncFailures(*tableConfig.TableIdentity, recordIDs); err != nil {
+		s.log.Error("ClearSyncFailures 清除同步失败记录失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+	}
+}
+
+// filterRetryableRecordIDs 去掉空 ID 以及进入死信或已放弃、不再自动重试的记录
+// 手动重试（RetrySyncFailure）先恢复为重试中再调用 SyncLarkRecords，不经过该过滤
+func (s *SheetServiceImpl) filterRetryableRecordIDs(recordIDs []string, tableConfig *domain.TableConfig) ([]string, error) {
+	ids, err := s.failureDAO.GetExcludedRecordIDs(*tableConfig.TableIdentity, recordIDs)
+	if err != nil {
+		s.log.Error("GetExcludedRecordIDs 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetSyncFailureError(err)
+	}
+
+	excluded := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		excluded[id] = struct{}{}
+	}
+
+	res := make([]string, 0, len(recordIDs))
+	for _, rid := range recordIDs {
+		if _, ok := excluded[rid]; rid != "" && !ok {
+			res = append(res, rid)
+		}
+	}
+	return res, nil
+}
+
+// syncErrorMessage 提取便于排查的错误信息，不包含调用栈位置
+func syncErrorMessage(err error) string {
+	var customErr *errorx.CustomError
+	if errors.As(err, &customErr) {
+		if customErr.Err != nil {
+			return fmt.Sprintf("%s: %v", customErr.Msg, customErr.Err)
+		}
+		return customErr.Msg
+	}
+	return err.Error()
+}
+
+func toDomainSyncFailure(m *model.SyncFailure) domain.SyncFailure {
+	f := domain.SyncFailure{
+		Status:    m.Status,
+		Attempts:  m.Attempts,
+		LastError: m.LastError,
+		DeadAt:    m.DeadAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.TableIdentify != nil {
+		f.TableIdentify = *m.TableIdentify
+	}
+	if m.RecordID != nil {
+		f.RecordID = *m.RecordID
+	}
+
+	return f
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	serviceMock "github.com/muxi-Infra/FeedBack-Backend/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type syncFailureMocks struct {
+	client  *larkMock.MockClient
+	sheet   *daoMock.MockSheetDAO
+	failure *daoMock.MockSyncFailureDAO
+}
+
+func newTestSyncFailureService(t *testing.T) (*SheetServiceImpl, syncFailureMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := syncFailureMocks{
+		client:  larkMock.NewMockClient(ctrl),
+		sheet:   daoMock.NewMockSheetDAO(ctrl),
+		failure: daoMock.NewMockSyncFailureDAO(ctrl),
+	}
+	// 记录变更事件不是这里的测试对象
+	webhook := serviceMock.NewMockWebhookService(ctrl)
+	webhook.EXPECT().PublishRecordEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return &SheetServiceImpl{
+		c:          mocks.client,
+		log:        newTestLogger(),
+		sheetDao:   mocks.sheet,
+		failureDAO: mocks.failure,
+		webhook:    webhook,
+		syncCfg:    &config.SyncConfig{MaxRecordAttempts: 5},
+	}, mocks
+}
+
+// 下一页的 pageToken 指向本页最后一条失败记录，按状态过滤
+func TestListSyncFailuresPaging(t *testing.T) {
+	s, mocks := newTestSyncFailureService(t)
+	tc := newTestTableConfig()
+	rec1, rec2 := "rec-1", "rec-2"
+
+	mocks.failure.EXPECT().ListSyncFailures(*tc.TableIdentity, model.SyncFailureStatusDead, new(uint64), 2).
+		Return([]*model.SyncFailure{
+			{ID: 9, TableIdentify: tc.TableIdentity, RecordID: &rec1, Status: model.SyncFailureStatusDead, Attempts: 5},
+			{ID: 7, TableIdentify: tc.TableIdentity, RecordID: &rec2, Status: model.SyncFailureStatusDead, Attempts: 5},
+		}, true, nil)
+	first, err := s.ListSyncFailures(model.SyncFailureStatusDead, nil, 2, &tc)
+	require.NoError(t, err)
+	require.Len(t, first.Failures, 2)
+	assert.Equal(t, rec1, first.Failures[0].RecordID)
+	assert.Equal(t, 5, first.Failures[0].Attempts)
+	assert.True(t, *first.HasMore)
+	require.NotNil(t, first.PageToken)
+
+	lastID := uint64(7)
+	mocks.failure.EXPECT().ListSyncFailures(*tc.TableIdentity, model.SyncFailureStatusDead, &lastID, 2).
+		Return(nil, false, nil)
+	second, err := s.ListSyncFailures(model.SyncFailureStatusDead, first.PageToken, 2, &tc)
+	require.NoError(t, err)
+	assert.Empty(t, second.Failures)
+	assert.Nil(t, second.PageToken)
+
+	invalid := "not-a-token"
+	_, err = s.ListSyncFailures("", &invalid, 2, &tc)
+	require.Error(t, err)
+	assert.Equal(t, errs.PageTokenInvalidCode, errorx.ToCustomError(err).Code)
+
+	mocks.failure.EXPECT().ListSyncFailures(*tc.TableIdentity, "", new(uint64), 2).Return(nil, false, errors.New("db down"))
+	_, err = s.ListSyncFailures("", nil, 2, &tc)
+	require.Error(t, err)
+	assert.Equal(t, errs.GetSyncFailureErrorCode, errorx.ToCustomError(err).Code)
+}
+
+// 手动重试前恢复为重试中并清零失败次数，死信记录再次失败时重新开始自动重试
+func TestRetrySyncFailure(t *testing.T) {
+	recordID := "rec-1"
+
+	tests := []struct {
+		name       string
+		resetOK    bool
+		resetErr   error
+		syncErr    error
+		wantCode   int
+		wantFailed int
+	}{
+		{name: "dead letter retried successfully", resetOK: true},
+		{name: "dead letter fails again", resetOK: true, syncErr: errors.New("db down"), wantFailed: 1},
+		{name: "not found", wantCode: errs.SyncFailureNotFoundErrorCode},
+		{name: "reset failed", resetErr: errors.New("db down"), wantCode: errs.UpdateSyncFailureErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mocks := newTestSyncFailureService(t)
+			tc := newTestTableConfig()
+
+			reset := mocks.failure.EXPECT().ResetSyncFailure(*tc.TableIdentity, recordID).Return(tt.resetOK, tt.resetErr)
+			if tt.wantCode == 0 {
+				mocks.client.EXPECT().GetRecordByRecordId(gomock.Any(), gomock.Any()).
+					Return(&larkbitable.BatchGetAppTableRecordResp{
+						Data: &larkbitable.BatchGetAppTableRecordRespData{
+							Records: []*larkbitable.AppTableRecord{larkRecord(recordID, "处理中")},
+						},
+					}, nil).After(reset)
+				mocks.sheet.EXPECT().GetRecordSnapshots(*tc.TableIdentity, []string{recordID}).Return(nil, nil)
+				mocks.sheet.EXPECT().CreateOrUpdateSheetRecord(gomock.Any()).Return(tt.syncErr)
+				if tt.syncErr != nil {
+					// 重置后的第一次失败，仍处于重试中
+					mocks.failure.EXPECT().RecordSyncFailure(*tc.TableIdentity, recordID, gomock.Any(), 5).
+						Return(&model.SyncFailure{Status: model.SyncFailureStatusRetrying, Attempts: 1}, nil)
+					mocks.failure.EXPECT().ClearSyncFailures(*tc.TableIdentity, []string{}).Return(nil)
+				} else {
+					mocks.failure.EXPECT().ClearSyncFailures(*tc.TableIdentity, []string{recordID}).Return(nil)
+				}
+			}
+
+			stats, err := s.RetrySyncFailure(recordID, &tc)
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFailed, stats.Failed)
+			assert.Equal(t, 1-tt.wantFailed, stats.Updated)
+		})
+	}
+}
+
+func TestDiscardSyncFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		ok       bool
+		err      error
+		wantCode int
+	}{
+		{name: "discarded", ok: true},
+		{name: "not found", wantCode: errs.SyncFailureNotFoundErrorCode},
+		{name: "update failed", err: errors.New("db down"), wantCode: errs.UpdateSyncFailureErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mocks := newTestSyncFailureService(t)
+			tc := newTestTableConfig()
+
+			mocks.failure.EXPECT().DiscardSyncFailure(*tc.TableIdentity, "rec-1").Return(tt.ok, tt.err)
+
+			err := s.DiscardSyncFailure("rec-1", &tc)
+			if tt.wantCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+// GetSyncJob 查询同步任务的处理进度
+func (s *SheetServiceImpl) GetSyncJob(jobID string, tableConfig *domain.TableConfig) (*domain.SyncJob, error) {
+	job, err := s.syncJobDAO.GetSyncJob(*tableConfig.TableIdentity, jobID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.SyncJobNotFoundError(err)
+	}
+	if err != nil {
+		s.log.Error("GetSyncJob 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("job_id", jobID),
+		)
+		return nil, errs.GetSyncJobError(err)
+	}
+
+	res := toDomainSyncJob(job)
+	return &res, nil
+}
+
+// ListSyncJobs 查询表格下的同步任务列表，按创建时间倒序分页
+func (s *SheetServiceImpl) ListSyncJobs(pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.SyncJobs, error) {
+	lastID := new(uint64)
+	if pageToken != nil && *pageToken != "" {
+		ld, err := decodePageToken(*pageToken)
+		if err != nil {
+			return nil, errs.PageTokenInvalidError(err)
+		}
+		lastID = ld
+	}
+
+	jobs, hasMore, err := s.syncJobDAO.ListSyncJobsByTable(*tableConfig.TableIdentity, lastID, limitSize)
+	if err != nil {
+		s.log.Error("ListSyncJobs 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetSyncJobError(err)
+	}
+
+	ans := make([]domain.SyncJob, 0, len(jobs))
+	for _, j := range jobs {
+		ans = append(ans, toDomainSyncJob(j))
+	}
+
+	// 生成 nextPageToken
+	var nextToken *string
+	if hasMore && len(jobs) > 0 {
+		token, _ := encodePageToken(jobs[len(jobs)-1].ID)
+		nextToken = &token
+	}
+
+	return &domain.SyncJobs{
+		Jobs:      ans,
+		HasMore:   &hasMore,
+		PageToken: nextToken,
+	}, nil
+}
+
+// createSyncJob 创建一个排队中的同步任务，返回任务 ID
+func (s *SheetServiceImpl) createSyncJob(kind string, tableConfig *domain.TableConfig) (string, error) {
+	jobID := uuid.NewString()
+	err := s.syncJobDAO.CreateSyncJob(&model.SyncJob{
+		JobID:         &jobID,
+		TableIdentify: tableConfig.TableIdentity,
+		Kind:          kind,
+		State:         model.SyncJobStateQueued,
+	})
+	if err != nil {
+		s.log.Error("CreateSyncJob 创建同步任务失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return "", errs.CreateSyncJobError(err)
+	}
+
+	return jobID, nil
+}
+
+// 以下方法只更新任务进度，失败时记录日志，不影响同步本身
+
+func (s *SheetServiceImpl) setSyncJobBatches(jobID string, batches, total int, watermark *int64) {
+	if err := s.syncJobDAO.SetSyncJobBatches(jobID, batches, total, watermark); err != nil {
+		s.log.Error("SetSyncJobBatches 更新同步任务失败",
+			logger.String("error", err.Error()),
+			logger.String("job_id", jobID),
+		)
+	}
+}
+
+func (s *SheetServiceImpl) markSyncJobRunning(jobID string) {
+	if jobID == "" {
+		return
+	}
+	if err := s.syncJobDAO.MarkSyncJobRunning(jobID); err != nil {
+		s.log.Error("MarkSyncJobRunning 更新同步任务失败",
+			logger.String("error", err.Error()),
+			logger.String("job_id", jobID),
+		)
+	}
+}
+
+func (s *SheetServiceImpl) recordSyncJobBatch(jobID string, batchNo int, stats domain.SyncStats) {
+	if jobID == "" {
+		return
+	}
+	if err := s.syncJobDAO.AddSyncJobBatchResult(jobID, batchNo, stats.Fetched, stats.Updated, stats.Failed, stats.Error); err != nil {
+		s.log.Error("AddSyncJobBatchResult 更新同步任务失败",
+			logger.String("error", err.Error()),
+			logger.String("job_id", jobID),
+			logger.Int("batch_no", batchNo),
+		)
+	}
+}
+
+func toDomainSyncJob(m *model.SyncJob) domain.SyncJob {
+	job := domain.SyncJob{
+		Kind:         m.Kind,
+		State:        m.State,
+		Total:        m.Total,
+		Batches:      m.Batches,
+		BatchesDone:  m.BatchesDone,
+		Fetched:      m.Fetched,
+		Updated:      m.Updated,
+		Failed:       m.Failed,
+		ErrorSamples: m.ErrorSamples,
+		CreatedAt:    m.CreatedAt,
+		StartedAt:    m.StartedAt,
+		FinishedAt:   m.FinishedAt,
+	}
+	if m.JobID != nil {
+		job.JobID = *m.JobID
+	}
+	if m.TableIdentify != nil {
+		job.TableIdentify = *m.TableIdentify
+	}
+	if job.ErrorSamples == nil {
+		job.ErrorSamples = []string{}
+	}
+
+	return job
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSyncQueue(t *testing.T) cache.SyncQueue {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	q, err := cache.NewSyncQueue(client)
+	require.NoError(t, err)
+	return q
+}
+
+func recordIDs(n int) []string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, fmt.Sprintf("rec-%d", i))
+	}
+	return ids
+}
+
+// 多次入队时批次序号在整个任务内连续编号，重复投递的批次按序号去重
+func TestEnqueueRecordBatchesNumbersBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	syncJobDAO := daoMock.NewMockSyncJobDAO(ctrl)
+	failureDAO := daoMock.NewMockSyncFailureDAO(ctrl)
+	s := &SheetServiceImpl{
+		log:        newTestLogger(),
+		queue:      newTestSyncQueue(t),
+		syncJobDAO: syncJobDAO,
+		failureDAO: failureDAO,
+	}
+	tc := newTestTableConfig()
+	failureDAO.EXPECT().GetExcludedRecordIDs(*tc.TableIdentity, gomock.Any()).Return(nil, nil).AnyTimes()
+	submission := &domain.SyncSubmission{JobID: "job-1", RecordIDs: []string{}}
+
+	batches, err := s.enqueueRecordBatches(submission, recordIDs(queueBatchSize+1), &tc)
+	require.NoError(t, err)
+	assert.Equal(t, 2, batches)
+	batches, err = s.enqueueRecordBatches(submission, recordIDs(3), &tc)
+	require.NoError(t, err)
+	assert.Equal(t, 1, batches)
+	assert.Equal(t, 3, submission.Batches)
+	assert.Equal(t, queueBatchSize+4, submission.Total)
+
+	msgs, err := s.queue.Pop(context.Background(), "test", 10, time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	for i, m := range msgs {
+		var msg SyncMsg
+		require.NoError(t, json.Unmarshal(m.Payload, &msg))
+		assert.Equal(t, "job-1", msg.JobID)
+		assert.Equal(t, i+1, msg.BatchNo)
+	}
+
+	// 超过最大投递次数的批次按原序号记录为失败，与此前的投递结果合并为同一个批次
+	last := msgs[2]
+	last.RetryCount = syncMaxDeliveries + 1
+	syncJobDAO.EXPECT().
+		AddSyncJobBatchResult("job-1", 3, 0, 0, 3, gomock.Any()).
+		Return(nil)
+	s.handleSyncMessage(last)
+}
+
+func modifiedRecord(id string, modified int64) *larkbitable.AppTableRecord {
+	return &larkbitable.AppTableRecord{RecordId: &id, LastModifiedTime: &modified}
+}
+
+func searchResp(records ...*larkbitable.AppTableRecord) *larkbitable.SearchAppTableRecordResp {
+	hasMore, total := false, len(records)
+	return &larkbitable.SearchAppTableRecordResp{
+		Data: &larkbitable.SearchAppTableRecordRespData{
+			Items:   records,
+			HasMore: &hasMore,
+			Total:   &total,
+		},
+	}
+}
+
+// 高水位随任务一起保存，由任务成功结束时推进；队列已满时不携带高水位
+func TestIncrementalSyncTableRecordsDefersWatermark(t *testing.T) {
+	since := time.Now().Add(-time.Hour).UnixMilli()
+	modified := since + int64(time.Minute/time.Millisecond)
+
+	tests := []struct {
+		name          string
+		fillQueue     bool
+		modifiedField string
+		wantKind      string
+		wantWatermark *int64
+	}{
+		{
+			name:          "watermark saved on job",
+			modifiedField: "修改时间",
+			wantKind:      model.SyncJobKindIncremental,
+			wantWatermark: &modified,
+		},
+		{
+			name:          "full scan reported as incremental_scan",
+			wantKind:      model.SyncJobKindIncrementalScan,
+			wantWatermark: &modified,
+		},
+		{
+			name:          "no watermark when queue full",
+			fillQueue:     true,
+			modifiedField: "修改时间",
+			wantKind:      model.SyncJobKindIncremental,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := larkMock.NewMockClient(ctrl)
+			syncJobDAO := daoMock.NewMockSyncJobDAO(ctrl)
+			watermarkDAO := daoMock.NewMockSyncWatermarkDAO(ctrl)
+			failureDAO := daoMock.NewMockSyncFailureDAO(ctrl)
+			failureDAO.EXPECT().GetExcludedRecordIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			s := &SheetServiceImpl{
+				c:            client,
+				log:          newTestLogger(),
+				queue:        newTestSyncQueue(t),
+				syncJobDAO:   syncJobDAO,
+				watermarkDAO: watermarkDAO,
+				failureDAO:   failureDAO,
+				syncCfg:      &config.SyncConfig{ModifiedTimeField: tt.modifiedField},
+			}
+			tc := newTestTableConfig()
+			viewID := "mock-view-id"
+			tc.ViewID = &viewID
+
+			if tt.fillQueue {
+				for {
+					if err := s.queue.Push(context.Background(), []byte("{}")); err != nil {
+						break
+					}
+				}
+			}
+
+			watermarkDAO.EXPECT().GetWatermark(*tc.TableIdentity).
+				Return(&model.SyncWatermark{LastModifiedTime: since}, nil)
+			client.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+				Return(searchResp(
+					modifiedRecord("rec-old", since-1),
+					modifiedRecord("rec-new", modified),
+				), nil)
+			syncJobDAO.EXPECT().CreateSyncJob(gomock.Any()).
+				DoAndReturn(func(m *model.SyncJob) error {
+					assert.Equal(t, tt.wantKind, m.Kind)
+					return nil
+				})
+			syncJobDAO.EXPECT().SetSyncJobBatches(gomock.Any(), gomock.Any(), gomock.Any(), tt.wantWatermark).
+				Return(nil)
+
+			submission, err := s.IncrementalSyncTableRecords(&tc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fillQueue, submission.QueueFull)
+		})
+	}
+}
+
+// 死信与已放弃的记录在所有自动同步路径入队前被过滤，整批都被过滤时不占用批次序号
+func TestSyncPathsSkipDeadLetterRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	failureDAO := daoMock.NewMockSyncFailureDAO(ctrl)
+	s := &SheetServiceImpl{
+		log:        newTestLogger(),
+		queue:      newTestSyncQueue(t),
+		failureDAO: failureDAO,
+	}
+	tc := newTestTableConfig()
+
+	failureDAO.EXPECT().GetExcludedRecordIDs(*tc.TableIdentity, []string{"rec-0", "rec-1", ""}).
+		Return([]string{"rec-1"}, nil)
+	failureDAO.EXPECT().GetExcludedRecordIDs(*tc.TableIdentity, []string{"rec-dead"}).
+		Return([]string{"rec-dead"}, nil)
+	failureDAO.EXPECT().GetExcludedRecordIDs(*tc.TableIdentity, []string{"rec-2", "rec-3"}).
+		Return([]string{"rec-3"}, nil)
+
+	submission := &domain.SyncSubmission{JobID: "job-1", RecordIDs: []string{}}
+	batches, err := s.enqueueRecordBatches(submission, []string{"rec-0", "rec-1", ""}, &tc)
+	require.NoError(t, err)
+	assert.Equal(t, 1, batches)
+	batches, err = s.enqueueRecordBatches(submission, []string{"rec-dead"}, &tc)
+	require.NoError(t, err)
+	assert.Equal(t, 0, batches)
+	assert.Equal(t, []string{"rec-0"}, submission.RecordIDs)
+	assert.Equal(t, 1, submission.Batches)
+
+	require.NoError(t, s.SyncChangedRecords([]string{"rec-2", "rec-3"}, &tc))
+
+	msgs, err := s.queue.Pop(context.Background(), "test", 10, time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	var got [][]string
+	for _, m := range msgs {
+		var msg SyncMsg
+		require.NoError(t, json.Unmarshal(m.Payload, &msg))
+		got = append(got, msg.RecordIDs)
+	}
+	assert.Equal(t, [][]string{{"rec-0"}, {"rec-2"}}, got)
+}
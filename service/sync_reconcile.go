@@ -0,0 +1,145 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+)
+
+const reconcilePageSize = 500 // 对账时每页读取的数据库记录数
+
+// SyncDeletedRecords 处理飞书事件推送的已删除记录，反馈记录表格直接软删除，FAQ 表格则整体同步
+func (s *SheetServiceImpl) SyncDeletedRecords(recordIDs []string, tableConfig *domain.TableConfig) error {
+	if bytes.Contains([]byte(*tableConfig.TableIdentity), []byte("-faq")) {
+		return s.SyncChangedRecords(recordIDs, tableConfig)
+	}
+
+	_, err := s.softDeleteRecords(recordIDs, *tableConfig)
+	return err
+}
+
+// ReconcileDeletedRecords 对比飞书与数据库中的记录 ID，软删除飞书中已不存在的记录，返回删除的记录数
+// 不使用视图过滤，避免被视图隐藏的记录被误删
+func (s *SheetServiceImpl) ReconcileDeletedRecords(tableConfig *domain.TableConfig) (int, error) {
+	// 扫描飞书期间新增的记录不在 larkIDs 中，只对比扫描开始前创建的数据库记录
+	scanStart := time.Now()
+	larkIDs := make(map[string]struct{})
+
+	it := lark.NewSearchRecordIterator(context.Background(), s.c, func(pageToken string) *larkbitable.SearchAppTableRecordReq {
+		return larkbitable.NewSearchAppTableRecordReqBuilder().
+			AppToken(*tableConfig.TableToken).
+			TableId(*tableConfig.TableID).
+			PageToken(pageToken).
+			PageSize(lark.MaxSearchPageSize).
+			Body(larkbitable.NewSearchAppTableRecordReqBodyBuilder().
+				AutomaticFields(false).
+				Build()).
+			Build()
+	})
+	for it.Next() {
+		if r := it.Record(); r.RecordId != nil {
+			larkIDs[*r.RecordId] = struct{}{}
+		}
+	}
+	if err := it.Err(); err != nil {
+		s.log.Error("ReconcileDeletedRecords 查询飞书记录失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return 0, toLarkError(err)
+	}
+
+	// 找出数据库中存在但飞书中已不存在的记录
+	var missing []string
+	var lastID *uint64
+	scanned := 0
+	for {
+		records, hasMore, err := s.sheetDao.ListRecordIDsByTable(*tableConfig.TableIdentity, scanStart, lastID, reconcilePageSize)
+		if err != nil {
+			s.log.Error("ReconcileDeletedRecords 数据库查询失败",
+				logger.String("error", err.Error()),
+				logger.String("table_identity", *tableConfig.TableIdentity),
+			)
+			return 0, errs.GetRecordIDsByTableError(err)
+		}
+		for _, r := range records {
+			if _, ok := larkIDs[*r.RecordID]; !ok {
+				missing = append(missing, *r.RecordID)
+			}
+		}
+		scanned += len(records)
+
+		if !hasMore || len(records) == 0 {
+			break
+		}
+		lastID = &records[len(records)-1].ID
+	}
+
+	// 飞书返回空表但数据库中有记录时，多半是表格配置错误，不做删除
+	if len(larkIDs) == 0 && scanned > 0 {
+		s.log.Warn("ReconcileDeletedRecords lark table is empty, skipped",
+			logger.String("table_identity", *tableConfig.TableIdentity),
+			logger.Int("db_record_count", scanned),
+		)
+		return 0, errs.ReconcileAbortedError(fmt.Errorf("lark table %s is empty but db has %d records", *tableConfig.TableIdentity, scanned))
+	}
+
+	// 待删除的记录过多时，可能是飞书接口返回不完整或表格被误操作，中止对账等待人工确认
+	if len(missing) > s.syncCfg.ReconcileMaxCount || float64(len(missing)) > float64(scanned)*s.syncCfg.ReconcileMaxRatio {
+		s.log.Warn("ReconcileDeletedRecords too many missing records, skipped",
+			logger.String("table_identity", *tableConfig.TableIdentity),
+			logger.Int("lark_record_count", len(larkIDs)),
+			logger.Int("db_record_count", scanned),
+			logger.Int("missing_count", len(missing)),
+		)
+		return 0, errs.ReconcileAbortedError(fmt.Errorf("%d of %d records in table %s are missing from lark, exceeds reconcile limit", len(missing), scanned, *tableConfig.TableIdentity))
+	}
+
+	deleted := 0
+	for i := 0; i < len(missing); i += queueBatchSize {
+		end := i + queueBatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		n, err := s.softDeleteRecords(missing[i:end], *tableConfig)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+
+	s.log.Info("ReconcileDeletedRecords finished",
+		logger.String("table_identity", *tableConfig.TableIdentity),
+		logger.Int("lark_record_count", len(larkIDs)),
+		logger.Int("db_record_count", scanned),
+		logger.Int("deleted_count", deleted),
+	)
+
+	return deleted, nil
+}
+
+// softDeleteRecords 软删除飞书中已删除的记录，返回实际删除的记录数
+func (s *SheetServiceImpl) softDeleteRecords(recordIDs []string, tableConfig domain.TableConfig) (int, error) {
+	n, err := s.sheetDao.SoftDeleteSheetRecords(*tableConfig.TableIdentity, recordIDs)
+	if err != nil {
+		s.log.Error("SoftDeleteSheetRecords 软删除记录失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+			logger.Int("record_count", len(recordIDs)),
+		)
+		return 0, errs.DeleteRecordDBError(err)
+	}
+
+	// 记录已删除，不再需要重试
+	s.clearSyncFailures(tableConfig, recordIDs)
+
+	return int(n), nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/config"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	larkMock "github.com/muxi-Infra/FeedBack-Backend/pkg/lark/mock"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dbRecords(ids ...string) []*model.Sheet {
+	records := make([]*model.Sheet, 0, len(ids))
+	for i := range ids {
+		records = append(records, &model.Sheet{ID: uint64(len(ids) - i), RecordID: &ids[i]})
+	}
+	return records
+}
+
+func larkRecords(ids ...string) []*larkbitable.AppTableRecord {
+	records := make([]*larkbitable.AppTableRecord, 0, len(ids))
+	for i := range ids {
+		records = append(records, &larkbitable.AppTableRecord{RecordId: &ids[i]})
+	}
+	return records
+}
+
+// 只删除飞书中已不存在的记录，待删除记录超过比例或数量上限、飞书表格为空时中止对账
+func TestReconcileDeletedRecords(t *testing.T) {
+	tests := []struct {
+		name        string
+		lark        []string
+		db          []string
+		maxRatio    float64
+		maxCount    int
+		wantDeleted []string
+		wantCode    int
+	}{
+		{
+			name:        "deletes missing records",
+			lark:        recordIDs(9),
+			db:          append(recordIDs(9), "rec-deleted"),
+			maxRatio:    0.2,
+			maxCount:    500,
+			wantDeleted: []string{"rec-deleted"},
+		},
+		{name: "nothing missing", lark: recordIDs(3), db: recordIDs(3), maxRatio: 0.2, maxCount: 500},
+		{
+			name:     "missing ratio exceeded",
+			lark:     recordIDs(3),
+			db:       append(recordIDs(3), "rec-a"),
+			maxRatio: 0.2,
+			maxCount: 500,
+			wantCode: errs.ReconcileAbortedErrorCode,
+		},
+		{
+			name:     "missing count exceeded",
+			lark:     recordIDs(10),
+			db:       append(recordIDs(10), "rec-a", "rec-b"),
+			maxRatio: 1,
+			maxCount: 1,
+			wantCode: errs.ReconcileAbortedErrorCode,
+		},
+		{name: "lark table empty", db: recordIDs(1), maxRatio: 1, maxCount: 500, wantCode: errs.ReconcileAbortedErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := larkMock.NewMockClient(ctrl)
+			sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+			failureDAO := daoMock.NewMockSyncFailureDAO(ctrl)
+			s := &SheetServiceImpl{
+				c:          client,
+				log:        newTestLogger(),
+				sheetDao:   sheetDAO,
+				failureDAO: failureDAO,
+				syncCfg:    &config.SyncConfig{ReconcileMaxRatio: tt.maxRatio, ReconcileMaxCount: tt.maxCount},
+			}
+			tc := newTestTableConfig()
+
+			client.EXPECT().GetAppTableRecord(gomock.Any(), gomock.Any()).
+				Return(searchResp(larkRecords(tt.lark...)...), nil)
+			sheetDAO.EXPECT().ListRecordIDsByTable(*tc.TableIdentity, gomock.Any(), nil, reconcilePageSize).
+				Return(dbRecords(tt.db...), false, nil)
+			if len(tt.wantDeleted) > 0 {
+				sheetDAO.EXPECT().SoftDeleteSheetRecords(*tc.TableIdentity, tt.wantDeleted).
+					Return(int64(len(tt.wantDeleted)), nil)
+				failureDAO.EXPECT().ClearSyncFailures(*tc.TableIdentity, tt.wantDeleted).Return(nil)
+			}
+
+			deleted, err := s.ReconcileDeletedRecords(&tc)
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+				assert.Zero(t, deleted)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.wantDeleted), deleted)
+		})
+	}
+}
+
+// 反馈记录表格直接软删除事件推送的记录，FAQ 表格不删除，整体重新同步
+func TestSyncDeletedRecords(t *testing.T) {
+	t.Run("feedback table", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+		failureDAO := daoMock.NewMockSyncFailureDAO(ctrl)
+		s := &SheetServiceImpl{
+			log:        newTestLogger(),
+			queue:      newTestSyncQueue(t),
+			sheetDao:   sheetDAO,
+			failureDAO: failureDAO,
+		}
+		tc := newTestTableConfig()
+
+		sheetDAO.EXPECT().SoftDeleteSheetRecords(*tc.TableIdentity, []string{"rec-1", "rec-2"}).Return(int64(2), nil)
+		failureDAO.EXPECT().ClearSyncFailures(*tc.TableIdentity, []string{"rec-1", "rec-2"}).Return(nil)
+		require.NoError(t, s.SyncDeletedRecords([]string{"rec-1", "rec-2"}, &tc))
+
+		msgs, err := s.queue.Pop(context.Background(), "test", 10, time.Millisecond)
+		require.NoError(t, err)
+		assert.Empty(t, msgs)
+	})
+
+	t.Run("faq table", func(t *testing.T) {
+		// 未设置 DAO 的 mock，FAQ 表格访问数据库时测试失败
+		s := &SheetServiceImpl{
+			log:   newTestLogger(),
+			queue: newTestSyncQueue(t),
+		}
+		tc := newTestTableConfig()
+		identity := "mock-table-faq"
+		tc.TableIdentity = &identity
+
+		require.NoError(t, s.SyncDeletedRecords([]string{"rec-1"}, &tc))
+
+		msgs, err := s.queue.Pop(context.Background(), "test", 10, time.Millisecond)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		var msg SyncMsg
+		require.NoError(t, json.Unmarshal(msgs[0].Payload, &msg))
+		assert.Equal(t, SyncKindTable, msg.Kind)
+		assert.Equal(t, identity, *msg.TableConfig.TableIdentity)
+	})
+}
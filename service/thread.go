@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+const (
+	FeedbackSenderStudent = "student" // 学生追问
+	FeedbackSenderStaff   = "staff"   // 工作人员回复
+
+	FeedbackSourceAPI  = "api"  // 通过接口发送
+	FeedbackSourceLark = "lark" // 同步时从飞书回复列导入
+
+	feedbackMessageMaxLength = 2000 // 单条留言的长度上限（字符数）
+	threadModerationField    = "追问" // 审核追问内容时使用的列名，用于日志
+)
+
+//go:generate mockgen -destination=./mock/thread_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service ThreadService
+type ThreadService interface {
+	ListMessages(recordID, studentID string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.FeedbackMessages, error)
+	PostStudentMessage(ctx context.Context, recordID, studentID, content string, tableConfig *domain.TableConfig) (*domain.FeedbackMessage, error)
+	PostStaffMessage(recordID, staffName, content string, tableConfig *domain.TableConfig) (*domain.FeedbackMessage, error)
+	ImportLarkReply(recordID, studentID, reply string, revision int64, tableConfig *domain.TableConfig) error
+}
+
+type ThreadServiceImpl struct {
+	c          lark.Client
+	log        logger.Logger
+	m          MessageService
+	sheetDao   dao.SheetDAO
+	threadDAO  dao.FeedbackMessageDAO
+	moderation ModerationService
+	life       *lifecycle.Lifecycle
+}
+
+func NewThreadService(c lark.Client, log logger.Logger, m MessageService, sheetDao dao.SheetDAO, threadDAO dao.FeedbackMessageDAO, moderation ModerationService, life *lifecycle.Lifecycle) ThreadService {
+	return &ThreadServiceImpl{
+		c:          c,
+		log:        log,
+		m:          m,
+		sheetDao:   sheetDao,
+		threadDAO:  threadDAO,
+		moderation: moderation,
+		life:       life,
+	}
+}
+
+// ListMessages 查询记录下的对话，按时间正序，支持分页；studentID 不为空时只允许查询该学生自己的记录
+func (t *ThreadServiceImpl) ListMessages(recordID, studentID string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.FeedbackMessages, error) {
+	if _, err := t.getRecord(recordID, studentID, tableConfig); err != nil {
+		return nil, err
+	}
+
+	lastID := new(uint64)
+	if pageToken != nil && *pageToken != "" {
+		ld, err := decodePageToken(*pageToken)
+		if err != nil {
+			return nil, errs.PageTokenInvalidError(err)
+		}
+		lastID = ld
+	}
+
+	list, hasMore, err := t.threadDAO.ListMessages(*tableConfig.TableIdentity, recordID, lastID, limitSize)
+	if err != nil {
+		t.log.Error("ListMessages 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return nil, errs.GetFeedbackMessageError(err)
+	}
+
+	ans := make([]domain.FeedbackMessage, 0, len(list))
+	for _, msg := range list {
+		ans = append(ans, toDomainFeedbackMessage(msg))
+	}
+
+	// 生成 nextPageToken
+	var nextToken *string
+	if hasMore && len(list) > 0 {
+		token, _ := encodePageToken(list[len(list)-1].ID)
+		nextToken = &token
+	}
+
+	return &domain.FeedbackMessages{
+		Messages:  ans,
+		HasMore:   &hasMore,
+		PageToken: nextToken,
+	}, nil
+}
+
+// PostStudentMessage 学生在自己的记录下追问，发送新反馈卡片提醒工作人员，并回写对话记录到飞书
+// 追问会回写到飞书，与新建记录一样经过内容审核
+func (t *ThreadServiceImpl) PostStudentMessage(ctx context.Context, recordID, studentID, content string, tableConfig *domain.TableConfig) (*domain.FeedbackMessage, error) {
+	record, err := t.getRecord(recordID, studentID, tableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	moderated := &domain.TableRecord{Record: map[string]any{threadModerationField: content}}
+	if err := t.moderation.ModerateRecord(ctx, moderated, tableConfig); err != nil {
+		return nil, err
+	}
+	content, _ = moderated.Record[threadModerationField].(string)
+
+	msg, err := t.createMessage(record, FeedbackSenderStudent, studentID, content, FeedbackSourceAPI, tableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提醒和回写不阻塞接口返回，由 lifecycle 管理，关闭服务时等待完成
+	t.life.Go("thread notify staff", func(context.Context) {
+		t.notifyStaff(record, msg, tableConfig)
+		t.mirrorThread(recordID, tableConfig)
+	})
+
+	return msg, nil
+}
+
+// PostStaffMessage 工作人员回复记录，由通知消费者通知学生，并回写对话记录到飞书
+func (t *ThreadServiceImpl) PostStaffMessage(recordID, staffName, content string, tableConfig *domain.TableConfig) (*domain.FeedbackMessage, error) {
+	record, err := t.getRecord(recordID, "", tableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := t.createMessage(record, FeedbackSenderStaff, staffName, content, FeedbackSourceAPI, tableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	t.life.Go("thread mirror", func(context.Context) {
+		t.mirrorThread(recordID, tableConfig)
+	})
+
+	return msg, nil
+}
+
+// ImportLarkReply 将飞书回复列的内容作为工作人员的回复导入，回复列与上一次导入的内容相同时忽略
+// revision 为记录的最后修改时间，同一版本只导入一次，因此重复同步不会重复导入，而回复改动后再改回原内容时仍会导入
+// 只在同步时调用，记录已写入数据库，因此不再校验记录是否存在
+func (t *ThreadServiceImpl) ImportLarkReply(recordID, studentID, reply string, revision int64, tableConfig *domain.TableConfig) error {
+	reply = strings.TrimSpace(reply)
+	if reply == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(reply) > feedbackMessageMaxLength {
+		reply = truncateRunes(reply, feedbackMessageMaxLength)
+	}
+
+	last, err := t.threadDAO.GetLatestMessage(*tableConfig.TableIdentity, recordID, FeedbackSourceLark)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.log.Error("GetLatestMessage 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return errs.GetFeedbackMessageError(err)
+	}
+	if last != nil && last.Content == reply {
+		// 回复列没有变化，本次修改的是其他字段
+		return nil
+	}
+
+	m := &model.FeedbackMessage{
+		TableIdentify: tableConfig.TableIdentity,
+		RecordID:      &recordID,
+		UserID:        &studentID,
+		Sender:        FeedbackSenderStaff,
+		SenderName:    "飞书",
+		Content:       reply,
+		Source:        FeedbackSourceLark,
+	}
+	// 飞书未返回修改时间时不设置版本，只按内容是否变化判断
+	if revision > 0 {
+		key := "rev:" + strconv.FormatInt(revision, 10)
+		m.SourceKey = &key
+	}
+	created, err := t.threadDAO.CreateMessage(m)
+	if err != nil {
+		t.log.Error("ImportLarkReply 保存回复失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return errs.CreateFeedbackMessageError(err)
+	}
+
+	if created {
+		t.log.Info("ImportLarkReply imported staff reply from lark",
+			logger.String("table_identity", *tableConfig.TableIdentity),
+			logger.String("record_id", recordID),
+		)
+		t.mirrorThread(recordID, tableConfig)
+	}
+
+	return nil
+}
+
+// getRecord 查询留言所属的记录，studentID 不为空时只查询该学生的记录
+func (t *ThreadServiceImpl) getRecord(recordID, studentID string, tableConfig *domain.TableConfig) (*model.Sheet, error) {
+	var (
+		record *model.Sheet
+		err    error
+	)
+	if studentID != "" {
+		record, err = t.sheetDao.GetSheetRecordByRecordID(*tableConfig.TableIdentity, studentID, recordID)
+	} else {
+		record, err = t.sheetDao.GetSheetRecord(*tableConfig.TableIdentity, recordID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.FeedbackRecordNotFoundError(fmt.Errorf("record %s not found", recordID))
+	}
+	if err != nil {
+		t.log.Error("getRecord 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return nil, errs.GetFeedbackMessageError(err)
+	}
+
+	return record, nil
+}
+
+func (t *ThreadServiceImpl) createMessage(record *model.Sheet, sender, senderName, content, source string, tableConfig *domain.TableConfig) (*domain.FeedbackMessage, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errs.FeedbackMessageInvalidError(errors.New("content is empty"))
+	}
+	if utf8.RuneCountInString(content) > feedbackMessageMaxLength {
+		return nil, errs.FeedbackMessageInvalidError(fmt.Errorf("content exceeds %d characters", feedbackMessageMaxLength))
+	}
+
+	m := &model.FeedbackMessage{
+		TableIdentify: tableConfig.TableIdentity,
+		RecordID:      record.RecordID,
+		UserID:        record.UserID,
+		Sender:        sender,
+		SenderName:    senderName,
+		Content:       content,
+		Source:        source,
+		// 学生的追问通过飞书卡片提醒工作人员，不需要通知学生
+		IsNoticed: sender == FeedbackSenderStudent,
+	}
+	if _, err := t.threadDAO.CreateMessage(m); err != nil {
+		t.log.Error("CreateMessage 保存留言失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", *record.RecordID),
+		)
+		return nil, errs.CreateFeedbackMessageError(err)
+	}
+
+	msg := toDomainFeedbackMessage(m)
+	return &msg, nil
+}
+
+// notifyStaff 通过新反馈卡片提醒工作人员学生的追问，失败时只记录日志
+func (t *ThreadServiceImpl) notifyStaff(record *model.Sheet, msg *domain.FeedbackMessage, tableConfig *domain.TableConfig) {
+	var url string
+	if record.ShareUrl != nil {
+		url = *record.ShareUrl
+	}
+
+	if _, err := t.m.SendLarkNotification("学生追问："+msg.Content, url, nil, tableConfig); err != nil {
+		t.log.Error("notifyStaff 发送追问提醒失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", msg.RecordID),
+		)
+	}
+}
+
+// mirrorThread 将记录下的完整对话回写到飞书的对话列，表格未配置对话列时跳过，失败时只记录日志
+func (t *ThreadServiceImpl) mirrorThread(recordID string, tableConfig *domain.TableConfig) {
+	// 学生接口传入的表格配置来自 token，只有表格基础信息，对话列以最新的表格配置为准
+	cfg, ok := getTableConfig(*tableConfig.TableIdentity)
+	if !ok || cfg.ThreadField == "" {
+		return
+	}
+
+	list, err := t.threadDAO.GetThread(*tableConfig.TableIdentity, recordID)
+	if err != nil {
+		t.log.Error("GetThread 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return
+	}
+
+	req := larkbitable.NewUpdateAppTableRecordReqBuilder().
+		AppToken(*cfg.TableToken).
+		TableId(*cfg.TableID).
+		RecordId(recordID).
+		AppTableRecord(larkbitable.NewAppTableRecordBuilder().
+			Fields(map[string]interface{}{cfg.ThreadField: threadText(list)}).
+			Build()).
+		Build()
+
+	resp, err := t.c.UpdateRecord(context.Background(), req)
+	if err != nil {
+		t.log.Error("mirrorThread UpdateRecord 调用失败",
+			logger.String("error", err.Error()),
+			logger.String("record_id", recordID),
+		)
+		return
+	}
+	if !resp.Success() {
+		t.log.Error("mirrorThread Lark 接口错误",
+			logger.String("request_id", resp.RequestId()),
+			logger.String("error", larkcore.Prettify(resp.CodeError)),
+			logger.String("record_id", recordID),
+		)
+	}
+}
+
+// threadText 将对话渲染为飞书多行文本，每条留言一段
+func threadText(list []*model.FeedbackMessage) string {
+	var b strings.Builder
+	for i, msg := range list {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		sender := "学生"
+		if msg.Sender == FeedbackSenderStaff {
+			sender = "工作人员"
+		}
+		fmt.Fprintf(&b, "[%s] %s（%s）：\n%s", msg.CreatedAt.Format("2006-01-02 15:04"), sender, msg.SenderName, msg.Content)
+	}
+	return b.String()
+}
+
+// truncateRunes 按字符截断字符串，超出时末尾加省略号
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "……"
+}
+
+func toDomainFeedbackMessage(m *model.FeedbackMessage) domain.FeedbackMessage {
+	msg := domain.FeedbackMessage{
+		ID:         m.ID,
+		Sender:     m.Sender,
+		SenderName: m.SenderName,
+		Content:    m.Content,
+		Source:     m.Source,
+		CreatedAt:  m.CreatedAt,
+	}
+	if m.RecordID != nil {
+		msg.RecordID = *m.RecordID
+	}
+
+	return msg
+}
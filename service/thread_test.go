@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/errorx"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	serviceMock "github.com/muxi-Infra/FeedBack-Backend/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// 飞书回复列按记录版本导入：内容未变化时忽略，改动后再改回相同内容时作为新回复导入
+func TestImportLarkReplyDedupByRevision(t *testing.T) {
+	recordID, studentID := "rec-1", "2023001"
+
+	tests := []struct {
+		name       string
+		reply      string
+		revision   int64
+		last       *model.FeedbackMessage
+		wantCreate bool
+		wantKey    *string
+	}{
+		{name: "first reply", reply: "已处理", revision: 1700000000000, wantCreate: true, wantKey: stringPtr("rev:1700000000000")},
+		{name: "other field edited", reply: "已处理", revision: 1700000000001, last: &model.FeedbackMessage{Content: "已处理"}},
+		{name: "reply changed", reply: "请补充截图", revision: 1700000000002, last: &model.FeedbackMessage{Content: "已处理"}, wantCreate: true, wantKey: stringPtr("rev:1700000000002")},
+		{name: "same reply repeated later", reply: "已处理", revision: 1700000000003, last: &model.FeedbackMessage{Content: "请补充截图"}, wantCreate: true, wantKey: stringPtr("rev:1700000000003")},
+		{name: "surrounding spaces ignored", reply: "  已处理\n", revision: 1700000000004, last: &model.FeedbackMessage{Content: "已处理"}},
+		{name: "missing revision", reply: "已处理", last: &model.FeedbackMessage{Content: "处理中"}, wantCreate: true},
+		{name: "empty reply", reply: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			threadDAO := daoMock.NewMockFeedbackMessageDAO(ctrl)
+			th := &ThreadServiceImpl{log: newTestLogger(), threadDAO: threadDAO, life: lifecycle.New()}
+			tc := newTestTableConfig()
+
+			if tt.last != nil {
+				threadDAO.EXPECT().GetLatestMessage(*tc.TableIdentity, recordID, FeedbackSourceLark).Return(tt.last, nil)
+			} else if tt.revision > 0 {
+				threadDAO.EXPECT().GetLatestMessage(*tc.TableIdentity, recordID, FeedbackSourceLark).Return(nil, gorm.ErrRecordNotFound)
+			}
+			if tt.wantCreate {
+				threadDAO.EXPECT().CreateMessage(gomock.Any()).
+					DoAndReturn(func(m *model.FeedbackMessage) (bool, error) {
+						assert.Equal(t, FeedbackSenderStaff, m.Sender)
+						assert.Equal(t, FeedbackSourceLark, m.Source)
+						assert.Equal(t, studentID, *m.UserID)
+						assert.Equal(t, tt.wantKey, m.SourceKey)
+						return true, nil
+					})
+			}
+
+			require.NoError(t, th.ImportLarkReply(recordID, studentID, tt.reply, tt.revision, &tc))
+		})
+	}
+}
+
+// 同一版本被重复同步时，唯一索引忽略写入，不再回写对话
+func TestImportLarkReplySameRevisionIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	threadDAO := daoMock.NewMockFeedbackMessageDAO(ctrl)
+	th := &ThreadServiceImpl{log: newTestLogger(), threadDAO: threadDAO, life: lifecycle.New()}
+	tc := newTestTableConfig()
+	tc.ThreadField = "对话"
+	setTestTables(t, tc)
+
+	threadDAO.EXPECT().GetLatestMessage(*tc.TableIdentity, "rec-1", FeedbackSourceLark).Return(&model.FeedbackMessage{Content: "处理中"}, nil)
+	threadDAO.EXPECT().CreateMessage(gomock.Any()).Return(false, nil)
+
+	require.NoError(t, th.ImportLarkReply("rec-1", "2023001", "已处理", 1700000000000, &tc))
+}
+
+// 学生追问后提醒工作人员的任务由 lifecycle 管理，关闭服务时等待任务完成
+func TestPostStudentMessageNotifiesUnderLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+	threadDAO := daoMock.NewMockFeedbackMessageDAO(ctrl)
+	message := serviceMock.NewMockMessageService(ctrl)
+	life := lifecycle.New()
+	th := &ThreadServiceImpl{log: newTestLogger(), m: message, sheetDao: sheetDAO, threadDAO: threadDAO, moderation: newTestModerationService(ModerationMask), life: life}
+	tc := newTestTableConfig()
+	setTestTables(t, tc)
+
+	recordID, studentID := "rec-1", "2023001"
+	shareURL := "https://share/rec-1"
+	sheetDAO.EXPECT().GetSheetRecordByRecordID(*tc.TableIdentity, studentID, recordID).
+		Return(&model.Sheet{RecordID: &recordID, UserID: &studentID, ShareUrl: &shareURL}, nil)
+	threadDAO.EXPECT().CreateMessage(gomock.Any()).
+		DoAndReturn(func(m *model.FeedbackMessage) (bool, error) {
+			assert.Equal(t, FeedbackSenderStudent, m.Sender)
+			assert.True(t, m.IsNoticed)
+			return true, nil
+		})
+
+	notified := make(chan struct{})
+	message.EXPECT().SendLarkNotification("学生追问：还没有解决", shareURL, nil, gomock.Any()).
+		DoAndReturn(func(string, string, []domain.LarkReceiver, *domain.TableConfig) (domain.LarkDeliveryReport, error) {
+			// 阻塞到关闭开始后，验证 Shutdown 会等待任务结束
+			time.Sleep(50 * time.Millisecond)
+			close(notified)
+			return domain.LarkDeliveryReport{}, nil
+		})
+
+	msg, err := th.PostStudentMessage(context.Background(), recordID, studentID, " 还没有解决 ", &tc)
+	require.NoError(t, err)
+	assert.Equal(t, "还没有解决", msg.Content)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, life.Shutdown(ctx))
+	select {
+	case <-notified:
+	default:
+		t.Fatal("shutdown returned before staff notification finished")
+	}
+}
+
+// 追问按表格的审核处理方式打码或拒绝，拒绝时不写入对话记录
+func TestPostStudentMessageModeratesContent(t *testing.T) {
+	recordID, studentID := "rec-1", "2023001"
+
+	tests := []struct {
+		name     string
+		action   string
+		wantCode int
+	}{
+		{name: "masked", action: ModerationMask},
+		{name: "rejected", action: ModerationReject, wantCode: errs.ContentRejectedErrorCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sheetDAO := daoMock.NewMockSheetDAO(ctrl)
+			threadDAO := daoMock.NewMockFeedbackMessageDAO(ctrl)
+			message := serviceMock.NewMockMessageService(ctrl)
+			life := lifecycle.New()
+			th := &ThreadServiceImpl{log: newTestLogger(), m: message, sheetDao: sheetDAO, threadDAO: threadDAO, moderation: newTestModerationService(tt.action), life: life}
+			tc := newTestTableConfig()
+			setTestTables(t, tc)
+
+			sheetDAO.EXPECT().GetSheetRecordByRecordID(*tc.TableIdentity, studentID, recordID).
+				Return(&model.Sheet{RecordID: &recordID, UserID: &studentID}, nil)
+			if tt.wantCode == 0 {
+				threadDAO.EXPECT().CreateMessage(gomock.Any()).Return(true, nil)
+				message.EXPECT().SendLarkNotification("学生追问：电话 ***********", "", nil, gomock.Any()).
+					Return(domain.LarkDeliveryReport{}, nil)
+			}
+
+			msg, err := th.PostStudentMessage(context.Background(), recordID, studentID, "电话 13812345678", &tc)
+			require.NoError(t, life.Shutdown(context.Background()))
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, errorx.ToCustomError(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "电话 ***********", msg.Content)
+		})
+	}
+}
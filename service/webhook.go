@@ -0,0 +1,480 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	"github.com/muxi-Infra/FeedBack-Backend/errs"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"gorm.io/gorm"
+)
+
+const (
+	WebhookEventRecordCreated   = "record.created"   // 用户提交了新反馈
+	WebhookEventRecordUpdated   = "record.updated"   // 飞书记录同步到数据库
+	WebhookEventRecordCompleted = "record.completed" // 记录的进度变为已完成
+
+	WebhookSignatureHeader = "X-Feedback-Signature" // sha256=HMAC-SHA256(secret, timestamp + "." + body) 的十六进制
+	WebhookTimestampHeader = "X-Feedback-Timestamp" // 发送时的 Unix 时间戳（秒），订阅方可据此拒绝重放请求
+	WebhookEventHeader     = "X-Feedback-Event"
+	WebhookDeliveryHeader  = "X-Feedback-Delivery" // 事件 ID
+
+	webhookPollInterval   = 5 * time.Second
+	webhookBatchSize      = 20
+	webhookLease          = 2 * time.Minute
+	webhookBaseBackoff    = 10 * time.Second
+	webhookMaxBackoff     = time.Hour
+	webhookMaxAttempts    = 8
+	webhookRequestTimeout = 10 * time.Second
+	webhookMaxErrorBody   = 512 // 记录到 last_error 的响应体长度上限
+)
+
+//go:generate mockgen -destination=./mock/webhook_mock.go -package=mocks github.com/muxi-Infra/FeedBack-Backend/service WebhookService
+type WebhookService interface {
+	CreateWebhook(rawURL string, events []string, secret string, tableConfig *domain.TableConfig) (*domain.WebhookSubscription, error)
+	ListWebhooks(tableConfig *domain.TableConfig) ([]domain.WebhookSubscription, error)
+	DeleteWebhook(id uint64, tableConfig *domain.TableConfig) error
+	ListWebhookDeliveries(subscriptionID uint64, status string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.WebhookDeliveries, error)
+	RedeliverWebhook(deliveryID uint64, tableConfig *domain.TableConfig) error
+	PublishRecordEvents(event string, records []domain.WebhookRecord, tableConfig domain.TableConfig) error
+}
+
+type WebhookServiceImpl struct {
+	log        logger.Logger
+	webhookDAO dao.WebhookDAO
+	client     *http.Client
+}
+
+func NewWebhookService(log logger.Logger, webhookDAO dao.WebhookDAO, elector *LeaderElector) WebhookService {
+	w := &WebhookServiceImpl{
+		log:        log,
+		webhookDAO: webhookDAO,
+		client:     &http.Client{Timeout: webhookRequestTimeout},
+	}
+
+	// 后台投递 webhook 事件，只在主节点上运行
+	elector.Go("webhook worker", func(ctx context.Context) {
+		ticker := time.NewTicker(webhookPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.processDueDeliveries(ctx)
+			}
+		}
+	})
+
+	return w
+}
+
+// CreateWebhook 为表格创建 webhook 订阅，secret 为空时随机生成
+func (w *WebhookServiceImpl) CreateWebhook(rawURL string, events []string, secret string, tableConfig *domain.TableConfig) (*domain.WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errs.WebhookURLInvalidError(fmt.Errorf("invalid webhook url: %s", rawURL))
+	}
+
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errs.CreateWebhookError(err)
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	m := &model.WebhookSubscription{
+		TableIdentify: tableConfig.TableIdentity,
+		URL:           rawURL,
+		Secret:        secret,
+		Events:        events,
+		Enabled:       true,
+	}
+	err = w.webhookDAO.CreateSubscription(m)
+	if err != nil {
+		w.log.Error("CreateSubscription 保存 webhook 订阅失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.CreateWebhookError(err)
+	}
+
+	res := toDomainWebhookSubscription(m)
+	res.Secret = m.Secret
+	return &res, nil
+}
+
+// ListWebhooks 查询表格下的 webhook 订阅，不返回 secret
+func (w *WebhookServiceImpl) ListWebhooks(tableConfig *domain.TableConfig) ([]domain.WebhookSubscription, error) {
+	list, err := w.webhookDAO.ListSubscriptions(*tableConfig.TableIdentity)
+	if err != nil {
+		w.log.Error("ListSubscriptions 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return nil, errs.GetWebhookError(err)
+	}
+
+	ans := make([]domain.WebhookSubscription, 0, len(list))
+	for _, m := range list {
+		ans = append(ans, toDomainWebhookSubscription(m))
+	}
+	return ans, nil
+}
+
+// DeleteWebhook 删除 webhook 订阅，尚未投递的事件不再投递
+func (w *WebhookServiceImpl) DeleteWebhook(id uint64, tableConfig *domain.TableConfig) error {
+	ok, err := w.webhookDAO.DeleteSubscription(*tableConfig.TableIdentity, id)
+	if err != nil {
+		w.log.Error("DeleteSubscription 删除 webhook 订阅失败",
+			logger.String("error", err.Error()),
+			logger.Int("subscription_id", int(id)),
+		)
+		return errs.UpdateWebhookError(err)
+	}
+	if !ok {
+		return errs.WebhookNotFoundError(fmt.Errorf("webhook not found: %d", id))
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries 查询订阅的投递记录，status 为空时返回全部状态
+func (w *WebhookServiceImpl) ListWebhookDeliveries(subscriptionID uint64, status string, pageToken *string, limitSize int, tableConfig *domain.TableConfig) (*domain.WebhookDeliveries, error) {
+	lastID := new(uint64)
+	if pageToken != nil && *pageToken != "" {
+		ld, err := decodePageToken(*pageToken)
+		if err != nil {
+			return nil, errs.PageTokenInvalidError(err)
+		}
+		lastID = ld
+	}
+
+	list, hasMore, err := w.webhookDAO.ListDeliveries(*tableConfig.TableIdentity, subscriptionID, status, lastID, limitSize)
+	if err != nil {
+		w.log.Error("ListDeliveries 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.Int("subscription_id", int(subscriptionID)),
+		)
+		return nil, errs.GetWebhookError(err)
+	}
+
+	ans := make([]domain.WebhookDelivery, 0, len(list))
+	for _, m := range list {
+		ans = append(ans, toDomainWebhookDelivery(m))
+	}
+
+	// 生成 nextPageToken
+	var nextToken *string
+	if hasMore && len(list) > 0 {
+		token, _ := encodePageToken(list[len(list)-1].ID)
+		nextToken = &token
+	}
+
+	return &domain.WebhookDeliveries{
+		Deliveries: ans,
+		HasMore:    &hasMore,
+		PageToken:  nextToken,
+	}, nil
+}
+
+// RedeliverWebhook 重新投递一次事件，请求体与事件 ID 保持不变，重试次数清零
+func (w *WebhookServiceImpl) RedeliverWebhook(deliveryID uint64, tableConfig *domain.TableConfig) error {
+	d, err := w.webhookDAO.GetDelivery(*tableConfig.TableIdentity, deliveryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errs.WebhookDeliveryNotFoundError(err)
+	}
+	if err != nil {
+		w.log.Error("GetDelivery 数据库查询失败",
+			logger.String("error", err.Error()),
+			logger.Int("delivery_id", int(deliveryID)),
+		)
+		return errs.GetWebhookError(err)
+	}
+
+	// 订阅已删除时无处投递
+	_, err = w.webhookDAO.GetSubscription(*tableConfig.TableIdentity, d.SubscriptionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errs.WebhookNotFoundError(err)
+	}
+	if err != nil {
+		return errs.GetWebhookError(err)
+	}
+
+	_, err = w.webhookDAO.ResetDelivery(*tableConfig.TableIdentity, deliveryID, time.Now())
+	if err != nil {
+		w.log.Error("ResetDelivery 重置投递记录失败",
+			logger.String("error", err.Error()),
+			logger.Int("delivery_id", int(deliveryID)),
+		)
+		return errs.UpdateWebhookError(err)
+	}
+
+	return nil
+}
+
+// PublishRecordEvents 为订阅了该事件的 webhook 登记投递任务，由后台投递并重试
+func (w *WebhookServiceImpl) PublishRecordEvents(event string, records []domain.WebhookRecord, tableConfig domain.TableConfig) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	subs, err := w.webhookDAO.ListSubscriptions(*tableConfig.TableIdentity)
+	if err != nil {
+		return errs.GetWebhookError(err)
+	}
+
+	now := time.Now()
+	var deliveries []*model.WebhookDelivery
+	for _, r := range records {
+		recordID := r.RecordID
+		// 同一事件投递给多个订阅时使用相同的事件 ID
+		eventID := uuid.NewString()
+		payload, err := json.Marshal(domain.WebhookEvent{
+			ID:            eventID,
+			Event:         event,
+			TableIdentify: *tableConfig.TableIdentity,
+			RecordID:      recordID,
+			OccurredAt:    now,
+			Data: domain.WebhookEventData{
+				Record:   r.Record,
+				ShareURL: r.ShareURL,
+			},
+		})
+		if err != nil {
+			return errs.SerializationError(err)
+		}
+
+		for _, sub := range subs {
+			if !sub.Enabled || !containsString(sub.Events, event) {
+				continue
+			}
+			deliveries = append(deliveries, &model.WebhookDelivery{
+				SubscriptionID: sub.ID,
+				TableIdentify:  tableConfig.TableIdentity,
+				RecordID:       &recordID,
+				EventID:        eventID,
+				Event:          event,
+				Payload:        string(payload),
+				Status:         model.WebhookDeliveryStatusPending,
+				NextRetryAt:    now,
+			})
+		}
+	}
+
+	err = w.webhookDAO.CreateDeliveries(deliveries)
+	if err != nil {
+		w.log.Error("CreateDeliveries 登记 webhook 投递任务失败",
+			logger.String("error", err.Error()),
+			logger.String("event", event),
+			logger.String("table_identity", *tableConfig.TableIdentity),
+		)
+		return errs.CreateWebhookError(err)
+	}
+
+	return nil
+}
+
+// processDueDeliveries 投递到期的事件，ctx 取消后不再认领新任务
+func (w *WebhookServiceImpl) processDueDeliveries(ctx context.Context) {
+	now := time.Now()
+	list, err := w.webhookDAO.GetDueDeliveries(now, webhookBatchSize)
+	if err != nil {
+		w.log.Error("GetDueDeliveries 查询待投递事件失败",
+			logger.String("error", err.Error()),
+		)
+		return
+	}
+
+	// 同一批次中的订阅只查询一次
+	subs := make(map[uint64]*model.WebhookSubscription)
+	for i := range list {
+		if ctx.Err() != nil {
+			return
+		}
+		item := &list[i]
+		leaseUntil := now.Add(webhookLease)
+		ok, err := w.webhookDAO.ClaimDelivery(item.ID, item.NextRetryAt, leaseUntil)
+		if err != nil {
+			w.log.Error("ClaimDelivery 认领投递任务失败",
+				logger.String("error", err.Error()),
+				logger.Int("delivery_id", int(item.ID)),
+			)
+			continue
+		}
+		if !ok {
+			// 已被其他实例认领
+			continue
+		}
+		item.NextRetryAt = leaseUntil
+
+		sub, ok := subs[item.SubscriptionID]
+		if !ok {
+			sub, err = w.webhookDAO.GetSubscription(*item.TableIdentify, item.SubscriptionID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// 订阅已删除
+				item.Status = model.WebhookDeliveryStatusDead
+				w.saveDelivery(item)
+				continue
+			}
+			if err != nil {
+				w.log.Error("GetSubscription 查询 webhook 订阅失败",
+					logger.String("error", err.Error()),
+					logger.Int("subscription_id", int(item.SubscriptionID)),
+				)
+				// 租约到期后重新认领
+				continue
+			}
+			subs[item.SubscriptionID] = sub
+		}
+
+		w.deliver(ctx, sub, item)
+	}
+}
+
+// deliver 投递一次事件并保存结果，失败时按指数退避安排重试
+func (w *WebhookServiceImpl) deliver(ctx context.Context, sub *model.WebhookSubscription, item *model.WebhookDelivery) {
+	statusCode, err := w.send(ctx, sub, item)
+	item.Attempts++
+	item.LastStatusCode = statusCode
+	if err == nil {
+		now := time.Now()
+		item.Status = model.WebhookDeliveryStatusDelivered
+		item.LastError = nil
+		item.DeliveredAt = &now
+		w.saveDelivery(item)
+		return
+	}
+
+	msg := err.Error()
+	item.LastError = &msg
+	if item.Attempts >= webhookMaxAttempts {
+		item.Status = model.WebhookDeliveryStatusDead
+		w.log.Error("webhook delivery exceeded max attempts, marked dead",
+			logger.Int("delivery_id", int(item.ID)),
+			logger.String("url", sub.URL),
+			logger.String("error", msg),
+		)
+	} else {
+		item.NextRetryAt = time.Now().Add(backoff(item.Attempts, webhookBaseBackoff, webhookMaxBackoff))
+		w.log.Warn("webhook delivery failed, will retry",
+			logger.Int("delivery_id", int(item.ID)),
+			logger.String("url", sub.URL),
+			logger.Int("attempts", item.Attempts),
+			logger.String("error", msg),
+		)
+	}
+	w.saveDelivery(item)
+}
+
+// send 发送签名后的请求，返回 HTTP 状态码，非 2xx 视为失败
+func (w *WebhookServiceImpl) send(ctx context.Context, sub *model.WebhookSubscription, item *model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewBufferString(item.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, item.Event)
+	req.Header.Set(WebhookDeliveryHeader, item.EventID)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(sub.Secret, timestamp, []byte(item.Payload)))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxErrorBody))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, truncateUTF8(string(body), webhookMaxErrorBody))
+	}
+	// 读完响应体以复用连接
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+func (w *WebhookServiceImpl) saveDelivery(item *model.WebhookDelivery) {
+	err := w.webhookDAO.UpdateDelivery(item)
+	if err != nil {
+		w.log.Error("UpdateDelivery 保存投递结果失败",
+			logger.String("error", err.Error()),
+			logger.Int("delivery_id", int(item.ID)),
+		)
+	}
+}
+
+// SignWebhookPayload 计算 webhook 签名：HMAC-SHA256(secret, timestamp + "." + body) 的十六进制
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func toDomainWebhookSubscription(m *model.WebhookSubscription) domain.WebhookSubscription {
+	s := domain.WebhookSubscription{
+		ID:        m.ID,
+		URL:       m.URL,
+		Events:    m.Events,
+		Enabled:   m.Enabled,
+		CreatedAt: m.CreatedAt,
+	}
+	if m.TableIdentify != nil {
+		s.TableIdentify = *m.TableIdentify
+	}
+
+	return s
+}
+
+func toDomainWebhookDelivery(m *model.WebhookDelivery) domain.WebhookDelivery {
+	d := domain.WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		Event:          m.Event,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		NextRetryAt:    m.NextRetryAt,
+		DeliveredAt:    m.DeliveredAt,
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.RecordID != nil {
+		d.RecordID = *m.RecordID
+	}
+
+	return d
+}
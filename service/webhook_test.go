@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/muxi-Infra/FeedBack-Backend/domain"
+	daoMock "github.com/muxi-Infra/FeedBack-Backend/repository/dao/mock"
+	"github.com/muxi-Infra/FeedBack-Backend/repository/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "mock-secret"
+
+// webhookReceiver 模拟订阅方，校验签名后按 status 依次返回状态码，用完后返回最后一个
+type webhookReceiver struct {
+	t        *testing.T
+	statuses []int
+	hits     atomic.Int32
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	n := int(r.hits.Add(1))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+	timestamp := req.Header.Get(WebhookTimestampHeader)
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	assert.NoError(r.t, err)
+	assert.InDelta(r.t, time.Now().Unix(), ts, 5)
+
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	assert.Equal(r.t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get(WebhookSignatureHeader))
+	assert.Equal(r.t, WebhookEventRecordUpdated, req.Header.Get(WebhookEventHeader))
+	assert.Equal(r.t, "event-1", req.Header.Get(WebhookDeliveryHeader))
+	assert.Equal(r.t, "application/json", req.Header.Get("Content-Type"))
+
+	status := r.statuses[len(r.statuses)-1]
+	if n <= len(r.statuses) {
+		status = r.statuses[n-1]
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("receiver says " + strconv.Itoa(status)))
+}
+
+// runWebhookWorker 以内存中的一条投递记录模拟数据库，反复执行投递循环，返回每次失败后安排的重试间隔
+func runWebhookWorker(t *testing.T, url string, rounds int) (*model.WebhookDelivery, []time.Duration) {
+	ctrl := gomock.NewController(t)
+	webhookDAO := daoMock.NewMockWebhookDAO(ctrl)
+	w := &WebhookServiceImpl{
+		log:        newTestLogger(),
+		webhookDAO: webhookDAO,
+		client:     &http.Client{Timeout: time.Second},
+	}
+
+	identity := "mock-table-identity"
+	recordID := "rec-1"
+	item := model.WebhookDelivery{
+		ID:             1,
+		SubscriptionID: 1,
+		TableIdentify:  &identity,
+		RecordID:       &recordID,
+		EventID:        "event-1",
+		Event:          WebhookEventRecordUpdated,
+		Payload:        `{"id":"event-1"}`,
+		Status:         model.WebhookDeliveryStatusPending,
+		NextRetryAt:    time.Now(),
+	}
+	sub := &model.WebhookSubscription{ID: 1, TableIdentify: &identity, URL: url, Secret: testWebhookSecret, Enabled: true}
+
+	var delays []time.Duration
+	// 测试中不等待真实的退避时间，只要状态仍是 pending 就视为到期
+	webhookDAO.EXPECT().GetDueDeliveries(gomock.Any(), webhookBatchSize).
+		DoAndReturn(func(time.Time, int) ([]model.WebhookDelivery, error) {
+			if item.Status != model.WebhookDeliveryStatusPending {
+				return nil, nil
+			}
+			return []model.WebhookDelivery{item}, nil
+		}).AnyTimes()
+	webhookDAO.EXPECT().ClaimDelivery(item.ID, gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	webhookDAO.EXPECT().GetSubscription(identity, sub.ID).Return(sub, nil).AnyTimes()
+	webhookDAO.EXPECT().UpdateDelivery(gomock.Any()).
+		DoAndReturn(func(m *model.WebhookDelivery) error {
+			if m.Status == model.WebhookDeliveryStatusPending {
+				delays = append(delays, time.Until(m.NextRetryAt))
+			}
+			item = *m
+			return nil
+		}).AnyTimes()
+
+	for i := 0; i < rounds; i++ {
+		w.processDueDeliveries(context.Background())
+	}
+	return &item, delays
+}
+
+func TestWebhookDeliverySucceedsAfterRetry(t *testing.T) {
+	receiver := &webhookReceiver{t: t, statuses: []int{http.StatusBadGateway, http.StatusOK}}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	item, delays := runWebhookWorker(t, srv.URL, 5)
+
+	assert.Equal(t, int32(2), receiver.hits.Load())
+	assert.Equal(t, model.WebhookDeliveryStatusDelivered, item.Status)
+	assert.Equal(t, 2, item.Attempts)
+	assert.Equal(t, http.StatusOK, item.LastStatusCode)
+	assert.Nil(t, item.LastError)
+	assert.NotNil(t, item.DeliveredAt)
+	require.Len(t, delays, 1)
+	assert.InDelta(t, webhookBaseBackoff.Seconds(), delays[0].Seconds(), 1)
+}
+
+// 持续失败时按指数退避重试，达到最大次数后标记为 dead，不再向订阅方发送请求
+func TestWebhookDeliveryBackoffUntilDead(t *testing.T) {
+	receiver := &webhookReceiver{t: t, statuses: []int{http.StatusInternalServerError}}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	item, delays := runWebhookWorker(t, srv.URL, webhookMaxAttempts+3)
+
+	assert.Equal(t, int32(webhookMaxAttempts), receiver.hits.Load())
+	assert.Equal(t, model.WebhookDeliveryStatusDead, item.Status)
+	assert.Equal(t, webhookMaxAttempts, item.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, item.LastStatusCode)
+	require.NotNil(t, item.LastError)
+	assert.Contains(t, *item.LastError, "receiver says 500")
+
+	want := []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second,
+		160 * time.Second, 320 * time.Second, 640 * time.Second,
+	}
+	require.Len(t, delays, len(want))
+	for i := range want {
+		assert.InDelta(t, want[i].Seconds(), delays[i].Seconds(), 1, "attempt %d", i+1)
+	}
+}
+
+func TestBackoffCapsAtMax(t *testing.T) {
+	assert.Equal(t, webhookBaseBackoff, backoff(1, webhookBaseBackoff, webhookMaxBackoff))
+	assert.Equal(t, webhookMaxBackoff, backoff(20, webhookBaseBackoff, webhookMaxBackoff))
+}
+
+// 只为启用且订阅了该事件的 webhook 登记投递，同一记录投递给多个订阅时事件 ID 相同
+func TestPublishRecordEventsFiltersSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	webhookDAO := daoMock.NewMockWebhookDAO(ctrl)
+	w := &WebhookServiceImpl{log: newTestLogger(), webhookDAO: webhookDAO}
+	tc := newTestTableConfig()
+
+	webhookDAO.EXPECT().ListSubscriptions(*tc.TableIdentity).Return([]*model.WebhookSubscription{
+		{ID: 1, Events: []string{WebhookEventRecordUpdated}, Enabled: true},
+		{ID: 2, Events: []string{WebhookEventRecordCreated}, Enabled: true},
+		{ID: 3, Events: []string{WebhookEventRecordUpdated}, Enabled: false},
+		{ID: 4, Events: []string{WebhookEventRecordCreated, WebhookEventRecordUpdated}, Enabled: true},
+	}, nil)
+	webhookDAO.EXPECT().CreateDeliveries(gomock.Any()).
+		DoAndReturn(func(list []*model.WebhookDelivery) error {
+			require.Len(t, list, 2)
+			assert.Equal(t, uint64(1), list[0].SubscriptionID)
+			assert.Equal(t, uint64(4), list[1].SubscriptionID)
+			assert.Equal(t, list[0].EventID, list[1].EventID)
+
+			var event domain.WebhookEvent
+			require.NoError(t, json.Unmarshal([]byte(list[0].Payload), &event))
+			assert.Equal(t, WebhookEventRecordUpdated, event.Event)
+			assert.Equal(t, "rec-1", event.RecordID)
+			assert.Equal(t, "https://share", event.Data.ShareURL)
+			return nil
+		})
+
+	err := w.PublishRecordEvents(WebhookEventRecordUpdated, []domain.WebhookRecord{
+		{RecordID: "rec-1", Record: map[string]any{"进度": "处理中"}, ShareURL: "https://share"},
+	}, tc)
+	require.NoError(t, err)
+
+	// 没有记录时不查询订阅
+	require.NoError(t, w.PublishRecordEvents(WebhookEventRecordUpdated, nil, tc))
+}
@@ -0,0 +1,24 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/muxi-Infra/FeedBack-Backend/controller"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/ginx"
+)
+
+// RegisterAdminRouter 注册管理员路由，使用 Basic Auth 保护
+func RegisterAdminRouter(r *gin.RouterGroup, adh controller.AdminHandler, basicAuthMiddleware gin.HandlerFunc) {
+	c := r.Group("/admin", basicAuthMiddleware)
+	{
+		c.GET("/sync/failures", ginx.WrapReq(adh.ListSyncFailures))
+		c.GET("/sync/failures/:record_id", ginx.WrapReq(adh.GetSyncFailure))
+		c.POST("/sync/failures/:record_id/retry", ginx.WrapReq(adh.RetrySyncFailure))
+		c.DELETE("/sync/failures/:record_id", ginx.WrapReq(adh.DiscardSyncFailure))
+		c.POST("/sync/reconcile", ginx.WrapReq(adh.ReconcileDeletedRecords))
+		c.GET("/notifications/failures", ginx.WrapReq(adh.ListFailedNotifications))
+		c.POST("/digest/send", ginx.WrapReq(adh.SendDigest))
+		c.GET("/records/:record_id/messages", ginx.WrapReq(adh.ListRecordMessages))
+		c.POST("/records/:record_id/messages", ginx.WrapReq(adh.PostStaffMessage))
+		c.GET("/ratings/stats", ginx.WrapReq(adh.GetRatingStats))
+	}
+}
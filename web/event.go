@@ -0,0 +1,14 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/muxi-Infra/FeedBack-Backend/controller"
+)
+
+// RegisterLarkEventRouter 注册飞书事件回调路由，由飞书服务端调用，鉴权依赖 Verification Token 与签名校验
+func RegisterLarkEventRouter(r *gin.RouterGroup, eh controller.LarkEventHandler) {
+	c := r.Group("/lark")
+	{
+		c.POST("event", eh.HandleEvent)
+	}
+}
@@ -0,0 +1,23 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/muxi-Infra/FeedBack-Backend/controller"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/ginx"
+)
+
+func RegisterNotificationRouter(r *gin.RouterGroup, nh controller.NotificationHandler, authMiddleware gin.HandlerFunc) {
+	c := r.Group("/notification")
+	{
+		c.GET("/preference", authMiddleware, ginx.WrapClaimsAndReq(nh.GetNotificationPreference))
+		c.PUT("/preference", authMiddleware, ginx.WrapClaimsAndReq(nh.UpdateNotificationPreference))
+	}
+
+	// 站内通知
+	i := r.Group("/notifications")
+	{
+		i.GET("", authMiddleware, ginx.WrapClaimsAndReq(nh.ListNotifications))
+		i.POST("/read", authMiddleware, ginx.WrapClaimsAndReq(nh.MarkNotificationsRead))
+		i.GET("/unread_count", authMiddleware, ginx.WrapClaimsAndReq(nh.GetUnreadNotificationCount))
+	}
+}
@@ -13,8 +13,16 @@ func RegisterSheetHandlerV2(r *gin.RouterGroup, sh controller.SheetV2Handler, au
 		c.POST("/sync", authMiddleware, ginx.WrapClaimsAndReq(sh.SyncUnsyncedTableRecords))
 		c.POST("sync/user", authMiddleware, ginx.WrapClaimsAndReq(sh.ForceSyncUserTableRecords))
 		c.POST("/sync/force", authMiddleware, ginx.WrapClaimsAndReq(sh.ForceSyncTableRecords))
+		c.POST("/sync/incremental", authMiddleware, ginx.WrapClaimsAndReq(sh.IncrementalSyncTableRecords))
 		c.GET("/records/faq", authMiddleware, ginx.WrapClaimsAndReq(sh.GetFAQRecord))
 		c.POST("/records/faq", authMiddleware, ginx.WrapClaimsAndReq(sh.UpdateFAQResolutionRecord))
 		c.POST("/sync/faq", authMiddleware, ginx.WrapClaimsAndReq(sh.SyncFAQRecord))
+		c.GET("/sync/jobs", authMiddleware, ginx.WrapClaimsAndReq(sh.ListSyncJobs))
+		c.GET("/sync/jobs/:id", authMiddleware, ginx.WrapClaimsAndReq(sh.GetSyncJob))
+		c.GET("/records/:record_id/messages", authMiddleware, ginx.WrapClaimsAndReq(sh.ListRecordMessages))
+		c.POST("/records/:record_id/messages", authMiddleware, ginx.WrapClaimsAndReq(sh.PostRecordMessage))
+		c.PUT("/records/:record_id", authMiddleware, ginx.WrapClaimsAndReq(sh.EditRecord))
+		c.POST("/records/:record_id/withdraw", authMiddleware, ginx.WrapClaimsAndReq(sh.WithdrawRecord))
+		c.POST("/records/:record_id/rating", authMiddleware, ginx.WrapClaimsAndReq(sh.RateRecord))
 	}
 }
@@ -18,7 +18,8 @@ func NewGinEngine(corsMiddleware *middleware.CorsMiddleware,
 	limitMiddleware *middleware.LimitMiddleware,
 	swag controller.SwagHandler,
 	sh controller.SheetV1Handler, ah controller.AuthHandler, mh controller.MessageHandler,
-	shV2 controller.SheetV2Handler,
+	shV2 controller.SheetV2Handler, eh controller.LarkEventHandler, adh controller.AdminHandler,
+	wh controller.WebhookHandler, nh controller.NotificationHandler,
 ) *gin.Engine {
 	gin.ForceConsoleColor()
 	r := gin.Default()
@@ -48,6 +49,10 @@ func NewGinEngine(corsMiddleware *middleware.CorsMiddleware,
 	apiV2 := r.Group("/api/v2")
 
 	RegisterSheetHandlerV2(apiV2, shV2, authMiddleware.MiddlewareFunc())
+	RegisterLarkEventRouter(apiV2, eh)
+	RegisterAdminRouter(apiV2, adh, basicAuthMiddleware.MiddlewareFunc())
+	RegisterWebhookRouter(apiV2, wh, basicAuthMiddleware.MiddlewareFunc())
+	RegisterNotificationRouter(apiV2, nh, authMiddleware.MiddlewareFunc())
 
 	return r
 }
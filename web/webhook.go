@@ -0,0 +1,19 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/muxi-Infra/FeedBack-Backend/controller"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/ginx"
+)
+
+// RegisterWebhookRouter 注册 webhook 订阅管理路由，使用 Basic Auth 保护
+func RegisterWebhookRouter(r *gin.RouterGroup, wh controller.WebhookHandler, basicAuthMiddleware gin.HandlerFunc) {
+	c := r.Group("/admin/webhooks", basicAuthMiddleware)
+	{
+		c.POST("", ginx.WrapReq(wh.CreateWebhook))
+		c.GET("", ginx.WrapReq(wh.ListWebhooks))
+		c.DELETE("/:id", ginx.WrapReq(wh.DeleteWebhook))
+		c.GET("/:id/deliveries", ginx.WrapReq(wh.ListWebhookDeliveries))
+		c.POST("/deliveries/:delivery_id/redeliver", ginx.WrapReq(wh.RedeliverWebhook))
+	}
+}
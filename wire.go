@@ -10,6 +10,7 @@ import (
 	"github.com/muxi-Infra/FeedBack-Backend/middleware"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/ijwt"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
 	"github.com/muxi-Infra/FeedBack-Backend/repository"
 	"github.com/muxi-Infra/FeedBack-Backend/service"
@@ -22,6 +23,7 @@ func InitApp() (*App, error) {
 		config.ProviderSet,
 		ioc.ProviderSet,
 		logger.NewZapLogger,
+		lifecycle.New,
 		lark.ProviderSet,
 		ijwt.NewJWT,
 		repository.ProviderSet,
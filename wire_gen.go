@@ -13,6 +13,7 @@ import (
 	"github.com/muxi-Infra/FeedBack-Backend/middleware"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/ijwt"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/lark"
+	"github.com/muxi-Infra/FeedBack-Backend/pkg/lifecycle"
 	"github.com/muxi-Infra/FeedBack-Backend/pkg/logger"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/cache"
 	"github.com/muxi-Infra/FeedBack-Backend/repository/dao"
@@ -50,19 +51,59 @@ func InitApp() (*App, error) {
 	sheetDAO := dao.NewSheetDAO(db)
 	faqdao := dao.NewFAQDAO(db)
 	faqResolutionStateCache := cache.NewFAQResolutionStateCache(client)
-	sheetService := service.NewSheetService(client2, loggerLogger, faqResolutionDAO, sheetDAO, faqdao, faqResolutionStateCache)
+	syncQueue, err := cache.NewSyncQueue(client)
+	if err != nil {
+		return nil, err
+	}
+	syncJobDAO := dao.NewSyncJobDAO(db)
+	syncWatermarkDAO := dao.NewSyncWatermarkDAO(db)
+	syncFailureDAO := dao.NewSyncFailureDAO(db)
+	webhookDAO := dao.NewWebhookDAO(db)
+	leaderLease := cache.NewLeaderLease(client)
+	lifecycleLifecycle := lifecycle.New()
+	leaderElector := service.NewLeaderElector(leaderLease, loggerLogger, lifecycleLifecycle, registry)
+	webhookService := service.NewWebhookService(loggerLogger, webhookDAO, leaderElector)
 	larkMessage := config.NewLarkMessageConfig()
+	noticeConfig := config.NewNoticeConfig()
 	ccnuBoxMessage := config.NewCCNUBoxMessageConfig()
-	messageService := service.NewMessageService(client2, loggerLogger, larkMessage, ccnuBoxMessage, sheetDAO)
-	sheetV1Handler := controller.NewSheet(sheetService, messageService)
+	mailConfig := config.NewMailConfig()
+	notifierRegistry := service.NewNotifierRegistry(loggerLogger, ccnuBoxMessage, mailConfig, noticeConfig)
+	notificationDeliveryDAO := dao.NewNotificationDeliveryDAO(db)
+	notificationPreferenceDAO := dao.NewNotificationPreferenceDAO(db)
+	notificationDAO := dao.NewNotificationDAO(db)
+	feedbackMessageDAO := dao.NewFeedbackMessageDAO(db)
+	noticeTrigger := cache.NewNoticeTrigger(client)
+	messageService := service.NewMessageService(client2, loggerLogger, larkMessage, noticeConfig, notifierRegistry, sheetDAO, notificationDeliveryDAO, notificationPreferenceDAO, notificationDAO, feedbackMessageDAO, noticeTrigger, leaderElector, registry)
+	moderationConfig := config.NewModerationConfig()
+	moderationService := service.NewModerationService(loggerLogger, moderationConfig)
+	threadService := service.NewThreadService(client2, loggerLogger, messageService, sheetDAO, feedbackMessageDAO, moderationService, lifecycleLifecycle)
+	recordRevisionDAO := dao.NewRecordRevisionDAO(db)
+	recordRatingDAO := dao.NewRecordRatingDAO(db)
+	syncConfig := config.NewSyncConfig()
+	sheetService := service.NewSheetService(client2, loggerLogger, faqResolutionDAO, sheetDAO, faqdao, faqResolutionStateCache, syncQueue, syncJobDAO, syncWatermarkDAO, syncFailureDAO, webhookService, threadService, recordRevisionDAO, recordRatingDAO, moderationService, syncConfig, lifecycleLifecycle, leaderElector)
+	outboxDAO := dao.NewOutboxDAO(db)
+	outboxService := service.NewOutboxService(loggerLogger, outboxDAO, sheetService, messageService, webhookService, leaderElector)
+	duplicateConfig := config.NewDuplicateConfig()
+	submissionCache := cache.NewSubmissionCache(client)
+	submissionService := service.NewSubmissionService(loggerLogger, duplicateConfig, sheetDAO, submissionCache)
+	sheetV1Handler := controller.NewSheet(sheetService, messageService, outboxService, submissionService, moderationService, threadService)
 	baseTable := config.NewBaseTable()
-	authService := service.NewAuthService(baseTable, clientConfig, client2, loggerLogger)
+	larkEvent := config.NewLarkEventConfig()
+	authService := service.NewAuthService(baseTable, clientConfig, larkEvent, syncConfig, client2, loggerLogger, syncQueue, lifecycleLifecycle, leaderElector)
 	authHandler := controller.NewAuth(jwt, authService)
 	messageHandler := controller.NewMessage(messageService)
-	sheetV2Handler := controller.NewSheetV2(sheetService, messageService)
-	engine := web.NewGinEngine(corsMiddleware, authMiddleware, basicAuthMiddleware, loggerMiddleware, prometheusMiddleware, limitMiddleware, swagHandler, sheetV1Handler, authHandler, messageHandler, sheetV2Handler)
+	sheetV2Handler := controller.NewSheetV2(sheetService, messageService, threadService)
+	larkEventHandler := controller.NewLarkEvent(larkEvent, authService, sheetService, loggerLogger)
+	digestDAO := dao.NewDigestDAO(db)
+	digestService := service.NewDigestService(client2, loggerLogger, larkMessage, digestDAO, leaderElector)
+	adminHandler := controller.NewAdmin(sheetService, authService, messageService, digestService, threadService)
+	webhookHandler := controller.NewWebhook(webhookService, authService)
+	notificationHandler := controller.NewNotification(messageService)
+	engine := web.NewGinEngine(corsMiddleware, authMiddleware, basicAuthMiddleware, loggerMiddleware, prometheusMiddleware, limitMiddleware, swagHandler, sheetV1Handler, authHandler, messageHandler, sheetV2Handler, larkEventHandler, adminHandler, webhookHandler, notificationHandler)
 	app := &App{
-		r: engine,
+		r:   engine,
+		lc:  lifecycleLifecycle,
+		log: loggerLogger,
 	}
 	return app, nil
 }